@@ -1,5 +1,3 @@
-// +build go1.5
-
 package request
 
 import (
@@ -9,20 +7,13 @@ import (
 )
 
 func copyHTTPRequest(r *http.Request, body io.ReadCloser) *http.Request {
-	req := &http.Request{
-		URL:           &url.URL{},
-		Header:        http.Header{},
-		Close:         r.Close,
-		Body:          body,
-		Host:          r.Host,
-		Method:        r.Method,
-		Proto:         r.Proto,
-		ContentLength: r.ContentLength,
-		// Cancel will be deprecated in 1.7 and will be replaced with Context
-		Cancel: r.Cancel,
-	}
-
+	req := new(http.Request)
+	*req = *r
+	req.URL = &url.URL{}
 	*req.URL = *r.URL
+	req.Body = body
+
+	req.Header = http.Header{}
 	for k, v := range r.Header {
 		for _, vv := range v {
 			req.Header.Add(k, vv)
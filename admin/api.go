@@ -0,0 +1,152 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/frodenas/brokerapi"
+	"github.com/frodenas/brokerapi/auth"
+	"github.com/gorilla/mux"
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/rdsbroker"
+	"github.com/alphagov/paas-rds-broker/sqlengine"
+)
+
+const rebalanceWindowsLogKey = "rebalance-windows"
+const undeleteInstanceLogKey = "undelete-instance"
+const finalSnapshotsLogKey = "final-snapshots"
+const serviceKeysLogKey = "service-keys"
+const revokeServiceKeyLogKey = "revoke-service-key"
+
+const statusUnprocessableEntity = 422
+
+// Broker is the set of operator actions exposed by the admin API.
+type Broker interface {
+	RebalanceWindows() ([]rdsbroker.WindowsAssignment, error)
+	UndeleteInstance(instanceID string) error
+	FinalSnapshots() ([]rdsbroker.FinalSnapshot, error)
+	ServiceKeys(instanceID string) ([]rdsbroker.ServiceKey, error)
+	RevokeServiceKey(instanceID, bindingID string) error
+}
+
+// New returns the handler of the admin API, protected by the same credentials
+// as the service broker API.
+func New(broker Broker, logger lager.Logger, credentials brokerapi.BrokerCredentials) http.Handler {
+	logger = logger.Session("admin")
+	router := mux.NewRouter()
+
+	router.HandleFunc("/admin/windows/rebalance", rebalanceWindows(broker, logger)).Methods("POST")
+	router.HandleFunc("/admin/instances/{instance_id}/undelete", undeleteInstance(broker, logger)).Methods("POST")
+	router.HandleFunc("/admin/snapshots/final", finalSnapshots(broker, logger)).Methods("GET")
+	router.HandleFunc("/admin/instances/{instance_id}/service-keys", serviceKeys(broker, logger)).Methods("GET")
+	router.HandleFunc("/admin/instances/{instance_id}/service-keys/{binding_id}", revokeServiceKey(broker, logger)).Methods("DELETE")
+
+	return auth.NewWrapper(credentials.Username, credentials.Password).Wrap(router)
+}
+
+func rebalanceWindows(broker Broker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		assignments, err := broker.RebalanceWindows()
+		if err != nil {
+			switch err {
+			case rdsbroker.ErrWindowSchedulerNotConfigured:
+				respondWithError(w, logger, rebalanceWindowsLogKey, statusUnprocessableEntity, err)
+			default:
+				respondWithError(w, logger, rebalanceWindowsLogKey, http.StatusInternalServerError, err)
+			}
+			return
+		}
+
+		respond(w, http.StatusOK, assignments)
+	}
+}
+
+func undeleteInstance(broker Broker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		instanceID := mux.Vars(req)["instance_id"]
+		logger := logger.WithData(lager.Data{"instance-id": instanceID})
+
+		if err := broker.UndeleteInstance(instanceID); err != nil {
+			switch err {
+			case brokerapi.ErrInstanceDoesNotExist:
+				respondWithError(w, logger, undeleteInstanceLogKey, http.StatusNotFound, err)
+			case rdsbroker.ErrInstanceNotSoftDeleted:
+				respondWithError(w, logger, undeleteInstanceLogKey, statusUnprocessableEntity, err)
+			default:
+				respondWithError(w, logger, undeleteInstanceLogKey, http.StatusInternalServerError, err)
+			}
+			return
+		}
+
+		respond(w, http.StatusOK, struct{}{})
+	}
+}
+
+func finalSnapshots(broker Broker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		snapshots, err := broker.FinalSnapshots()
+		if err != nil {
+			respondWithError(w, logger, finalSnapshotsLogKey, http.StatusInternalServerError, err)
+			return
+		}
+
+		respond(w, http.StatusOK, snapshots)
+	}
+}
+
+func serviceKeys(broker Broker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		instanceID := mux.Vars(req)["instance_id"]
+		logger := logger.WithData(lager.Data{"instance-id": instanceID})
+
+		keys, err := broker.ServiceKeys(instanceID)
+		if err != nil {
+			respondWithServiceKeyError(w, logger, serviceKeysLogKey, err)
+			return
+		}
+
+		respond(w, http.StatusOK, keys)
+	}
+}
+
+func revokeServiceKey(broker Broker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		instanceID := mux.Vars(req)["instance_id"]
+		bindingID := mux.Vars(req)["binding_id"]
+		logger := logger.WithData(lager.Data{"instance-id": instanceID, "binding-id": bindingID})
+
+		if err := broker.RevokeServiceKey(instanceID, bindingID); err != nil {
+			respondWithServiceKeyError(w, logger, revokeServiceKeyLogKey, err)
+			return
+		}
+
+		respond(w, http.StatusOK, struct{}{})
+	}
+}
+
+func respondWithServiceKeyError(w http.ResponseWriter, logger lager.Logger, action string, err error) {
+	switch err {
+	case brokerapi.ErrInstanceDoesNotExist, rdsbroker.ErrServiceKeyDoesNotExist:
+		respondWithError(w, logger, action, http.StatusNotFound, err)
+	case sqlengine.ErrServiceKeysNotSupported:
+		respondWithError(w, logger, action, statusUnprocessableEntity, err)
+	default:
+		respondWithError(w, logger, action, http.StatusInternalServerError, err)
+	}
+}
+
+func respondWithError(w http.ResponseWriter, logger lager.Logger, action string, status int, err error) {
+	logger.Error(action, err)
+	respond(w, status, brokerapi.ErrorResponse{
+		Description: err.Error(),
+	})
+}
+
+func respond(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.Encode(response)
+}
@@ -0,0 +1,270 @@
+package admin_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/frodenas/brokerapi"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/alphagov/paas-rds-broker/admin"
+	"github.com/alphagov/paas-rds-broker/admin/fakes"
+	"github.com/alphagov/paas-rds-broker/rdsbroker"
+	"github.com/alphagov/paas-rds-broker/sqlengine"
+)
+
+var _ = Describe("Admin API", func() {
+	var (
+		broker  *fakes.FakeBroker
+		handler http.Handler
+
+		logger   lager.Logger
+		testSink *lagertest.TestSink
+	)
+
+	BeforeEach(func() {
+		broker = &fakes.FakeBroker{}
+
+		logger = lager.NewLogger("admin_test")
+		testSink = lagertest.NewTestSink()
+		logger.RegisterSink(testSink)
+
+		handler = New(broker, logger, brokerapi.BrokerCredentials{
+			Username: "username",
+			Password: "password",
+		})
+	})
+
+	doRequest := func(method, path string, authenticated bool) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, "http://example.com"+path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		if authenticated {
+			req.SetBasicAuth("username", "password")
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	It("requires the broker credentials", func() {
+		w := doRequest("POST", "/admin/windows/rebalance", false)
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		Expect(broker.RebalanceWindowsCalled).To(BeFalse())
+	})
+
+	Describe("rebalancing the windows", func() {
+		BeforeEach(func() {
+			broker.RebalanceWindowsAssignments = []rdsbroker.WindowsAssignment{
+				{
+					InstanceID:                 "instance-1",
+					PreferredBackupWindow:      "02:00-02:30",
+					PreferredMaintenanceWindow: "tue:03:00-tue:03:30",
+				},
+			}
+		})
+
+		It("returns the new windows", func() {
+			w := doRequest("POST", "/admin/windows/rebalance", true)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(broker.RebalanceWindowsCalled).To(BeTrue())
+
+			var assignments []rdsbroker.WindowsAssignment
+			Expect(json.Unmarshal(w.Body.Bytes(), &assignments)).To(Succeed())
+			Expect(assignments).To(Equal(broker.RebalanceWindowsAssignments))
+		})
+
+		It("only accepts POST requests", func() {
+			w := doRequest("GET", "/admin/windows/rebalance", true)
+			Expect(w.Code).ToNot(Equal(http.StatusOK))
+			Expect(broker.RebalanceWindowsCalled).To(BeFalse())
+		})
+
+		Context("when the window scheduler is not configured", func() {
+			BeforeEach(func() {
+				broker.RebalanceWindowsError = rdsbroker.ErrWindowSchedulerNotConfigured
+			})
+
+			It("returns a 422", func() {
+				w := doRequest("POST", "/admin/windows/rebalance", true)
+				Expect(w.Code).To(Equal(422))
+				Expect(w.Body.String()).To(ContainSubstring("the window scheduler is not configured"))
+			})
+		})
+
+		Context("when rebalancing fails", func() {
+			BeforeEach(func() {
+				broker.RebalanceWindowsError = errors.New("operation failed")
+			})
+
+			It("returns a 500", func() {
+				w := doRequest("POST", "/admin/windows/rebalance", true)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body.String()).To(ContainSubstring("operation failed"))
+			})
+		})
+	})
+
+	Describe("undeleting an instance", func() {
+		It("undeletes the instance", func() {
+			w := doRequest("POST", "/admin/instances/instance-1/undelete", true)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(broker.UndeleteInstanceCalled).To(BeTrue())
+			Expect(broker.UndeleteInstanceID).To(Equal("instance-1"))
+		})
+
+		Context("when the instance does not exist", func() {
+			BeforeEach(func() {
+				broker.UndeleteInstanceError = brokerapi.ErrInstanceDoesNotExist
+			})
+
+			It("returns a 404", func() {
+				w := doRequest("POST", "/admin/instances/instance-1/undelete", true)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the instance has not been soft-deleted", func() {
+			BeforeEach(func() {
+				broker.UndeleteInstanceError = rdsbroker.ErrInstanceNotSoftDeleted
+			})
+
+			It("returns a 422", func() {
+				w := doRequest("POST", "/admin/instances/instance-1/undelete", true)
+				Expect(w.Code).To(Equal(422))
+				Expect(w.Body.String()).To(ContainSubstring("the instance has not been soft-deleted"))
+			})
+		})
+
+		Context("when undeleting fails", func() {
+			BeforeEach(func() {
+				broker.UndeleteInstanceError = errors.New("operation failed")
+			})
+
+			It("returns a 500", func() {
+				w := doRequest("POST", "/admin/instances/instance-1/undelete", true)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("listing the final snapshots", func() {
+		BeforeEach(func() {
+			broker.FinalSnapshotsSnapshots = []rdsbroker.FinalSnapshot{
+				{
+					SnapshotID: "snapshot-1",
+					InstanceID: "instance-1",
+					Status:     "available",
+					CreatedAt:  time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC),
+				},
+			}
+		})
+
+		It("returns the final snapshots", func() {
+			w := doRequest("GET", "/admin/snapshots/final", true)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(broker.FinalSnapshotsCalled).To(BeTrue())
+
+			var snapshots []rdsbroker.FinalSnapshot
+			Expect(json.Unmarshal(w.Body.Bytes(), &snapshots)).To(Succeed())
+			Expect(snapshots).To(Equal(broker.FinalSnapshotsSnapshots))
+		})
+
+		Context("when listing the final snapshots fails", func() {
+			BeforeEach(func() {
+				broker.FinalSnapshotsError = errors.New("operation failed")
+			})
+
+			It("returns a 500", func() {
+				w := doRequest("GET", "/admin/snapshots/final", true)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("listing the service keys of an instance", func() {
+		BeforeEach(func() {
+			expiresAt := time.Date(2016, 1, 3, 3, 4, 5, 0, time.UTC)
+			broker.ServiceKeysKeys = []rdsbroker.ServiceKey{
+				{
+					BindingID: "binding-1",
+					Username:  "user1",
+					ReadOnly:  true,
+					CreatedAt: time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC),
+					ExpiresAt: &expiresAt,
+				},
+			}
+		})
+
+		It("returns the service keys", func() {
+			w := doRequest("GET", "/admin/instances/instance-1/service-keys", true)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(broker.ServiceKeysInstanceID).To(Equal("instance-1"))
+
+			var keys []rdsbroker.ServiceKey
+			Expect(json.Unmarshal(w.Body.Bytes(), &keys)).To(Succeed())
+			Expect(keys).To(Equal(broker.ServiceKeysKeys))
+		})
+
+		Context("when the instance does not exist", func() {
+			BeforeEach(func() {
+				broker.ServiceKeysError = brokerapi.ErrInstanceDoesNotExist
+			})
+
+			It("returns a 404", func() {
+				w := doRequest("GET", "/admin/instances/instance-1/service-keys", true)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when service keys are not recorded", func() {
+			BeforeEach(func() {
+				broker.ServiceKeysError = sqlengine.ErrServiceKeysNotSupported
+			})
+
+			It("returns a 422", func() {
+				w := doRequest("GET", "/admin/instances/instance-1/service-keys", true)
+				Expect(w.Code).To(Equal(422))
+			})
+		})
+	})
+
+	Describe("revoking a service key", func() {
+		It("revokes the service key", func() {
+			w := doRequest("DELETE", "/admin/instances/instance-1/service-keys/binding-1", true)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(broker.RevokeServiceKeyInstanceID).To(Equal("instance-1"))
+			Expect(broker.RevokeServiceKeyBindingID).To(Equal("binding-1"))
+		})
+
+		Context("when the service key does not exist", func() {
+			BeforeEach(func() {
+				broker.RevokeServiceKeyError = rdsbroker.ErrServiceKeyDoesNotExist
+			})
+
+			It("returns a 404", func() {
+				w := doRequest("DELETE", "/admin/instances/instance-1/service-keys/binding-1", true)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(ContainSubstring("the service key does not exist"))
+			})
+		})
+
+		Context("when revoking fails", func() {
+			BeforeEach(func() {
+				broker.RevokeServiceKeyError = errors.New("operation failed")
+			})
+
+			It("returns a 500", func() {
+				w := doRequest("DELETE", "/admin/instances/instance-1/service-keys/binding-1", true)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package fakes
+
+import (
+	"github.com/alphagov/paas-rds-broker/rdsbroker"
+)
+
+type FakeBroker struct {
+	RebalanceWindowsCalled      bool
+	RebalanceWindowsAssignments []rdsbroker.WindowsAssignment
+	RebalanceWindowsError       error
+
+	UndeleteInstanceCalled bool
+	UndeleteInstanceID     string
+	UndeleteInstanceError  error
+
+	FinalSnapshotsCalled    bool
+	FinalSnapshotsSnapshots []rdsbroker.FinalSnapshot
+	FinalSnapshotsError     error
+
+	ServiceKeysCalled     bool
+	ServiceKeysInstanceID string
+	ServiceKeysKeys       []rdsbroker.ServiceKey
+	ServiceKeysError      error
+
+	RevokeServiceKeyCalled     bool
+	RevokeServiceKeyInstanceID string
+	RevokeServiceKeyBindingID  string
+	RevokeServiceKeyError      error
+}
+
+func (f *FakeBroker) RebalanceWindows() ([]rdsbroker.WindowsAssignment, error) {
+	f.RebalanceWindowsCalled = true
+
+	return f.RebalanceWindowsAssignments, f.RebalanceWindowsError
+}
+
+func (f *FakeBroker) UndeleteInstance(instanceID string) error {
+	f.UndeleteInstanceCalled = true
+	f.UndeleteInstanceID = instanceID
+
+	return f.UndeleteInstanceError
+}
+
+func (f *FakeBroker) FinalSnapshots() ([]rdsbroker.FinalSnapshot, error) {
+	f.FinalSnapshotsCalled = true
+
+	return f.FinalSnapshotsSnapshots, f.FinalSnapshotsError
+}
+
+func (f *FakeBroker) ServiceKeys(instanceID string) ([]rdsbroker.ServiceKey, error) {
+	f.ServiceKeysCalled = true
+	f.ServiceKeysInstanceID = instanceID
+
+	return f.ServiceKeysKeys, f.ServiceKeysError
+}
+
+func (f *FakeBroker) RevokeServiceKey(instanceID, bindingID string) error {
+	f.RevokeServiceKeyCalled = true
+	f.RevokeServiceKeyInstanceID = instanceID
+	f.RevokeServiceKeyBindingID = bindingID
+
+	return f.RevokeServiceKeyError
+}
@@ -2,6 +2,7 @@ package awsrds
 
 import (
 	"errors"
+	"time"
 )
 
 type DBInstance interface {
@@ -10,9 +11,30 @@ type DBInstance interface {
 	Create(ID string, dbInstanceDetails DBInstanceDetails) error
 	Modify(ID string, dbInstanceDetails DBInstanceDetails, applyImmediately bool) error
 	Delete(ID string, skipFinalSnapshot bool) error
+	Stop(ID string) error
+	Start(ID string) error
 	GetTag(ID, tagKey string) (string, error)
+	GetTags(ID string) (map[string]string, error)
+	AddTags(ID string, tags map[string]string) error
+	RemoveTags(ID string, tagKeys []string) error
+	DescribeSnapshots(dbInstanceIdentifierPrefix, tagKey, tagValue string) ([]*DBSnapshotDetails, error)
+	DescribeInstanceSnapshots(ID string) ([]*DBSnapshotDetails, error)
+	CreateSnapshot(ID string, tags map[string]string) (string, error)
+	DeleteSnapshot(ID string) error
+	CopySnapshot(ID, targetID, kmsKeyID string, tags map[string]string) error
+	RestoreFromSnapshot(ID, snapshotID string, dbInstanceDetails DBInstanceDetails) error
+	Rename(ID, newID string) error
+	CopyParameterGroup(sourceID, ID string, tags map[string]string) error
+	ModifyParameterGroup(ID string, parameters map[string]string) error
+	DeleteParameterGroup(ID string) error
+	CreateOptionGroup(ID, engine, majorEngineVersion string, tags map[string]string) error
+	CopyOptionGroup(sourceID, ID string, tags map[string]string) error
+	ModifyOptionGroup(ID string, optionsToInclude []DBOption, optionsToRemove []string) error
+	DeleteOptionGroup(ID string) error
 }
 
+// DBInstanceDetails are the settings of a DB instance. The settings left nil or
+// empty are not changed by Modify.
 type DBInstanceDetails struct {
 	Identifier                 string
 	Status                     string
@@ -21,23 +43,27 @@ type DBInstanceDetails struct {
 	EngineVersion              string
 	Address                    string
 	AllocatedStorage           int64
-	AutoMinorVersionUpgrade    bool
+	AutoMinorVersionUpgrade    *bool
 	AvailabilityZone           string
 	BackupRetentionPeriod      int64
 	CharacterSetName           string
-	CopyTagsToSnapshot         bool
+	CopyTagsToSnapshot         *bool
 	DBName                     string
 	DBParameterGroupName       string
 	DBSecurityGroups           []string
 	DBSubnetGroupName          string
+	DbiResourceId              string
+	DeletionProtection         *bool
+	IAMDatabaseAuthentication  *bool
 	Iops                       int64
 	KmsKeyID                   string
 	LicenseModel               string
 	MasterUsername             string
 	MasterUserPassword         string
-	MultiAZ                    bool
+	MultiAZ                    *bool
 	OptionGroupName            string
 	PendingModifications       bool
+	PendingReboot              bool
 	Port                       int64
 	PreferredBackupWindow      string
 	PreferredMaintenanceWindow string
@@ -48,6 +74,26 @@ type DBInstanceDetails struct {
 	VpcSecurityGroupIds        []string
 }
 
+type DBSnapshotDetails struct {
+	Identifier           string
+	DBInstanceIdentifier string
+	Status               string
+	CreateTime           time.Time
+	FinalSnapshot        bool
+	Tags                 map[string]string
+}
+
+type DBOption struct {
+	Name                string
+	Settings            map[string]string
+	Port                int64
+	VpcSecurityGroupIds []string
+}
+
 var (
-	ErrDBInstanceDoesNotExist = errors.New("rds db instance does not exist")
+	ErrDBInstanceDoesNotExist        = errors.New("rds db instance does not exist")
+	ErrDBParameterGroupAlreadyExists = errors.New("rds db parameter group already exists")
+	ErrDBParameterGroupDoesNotExist  = errors.New("rds db parameter group does not exist")
+	ErrOptionGroupAlreadyExists      = errors.New("rds option group already exists")
+	ErrOptionGroupDoesNotExist       = errors.New("rds option group does not exist")
 )
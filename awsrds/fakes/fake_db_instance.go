@@ -5,6 +5,10 @@ import (
 )
 
 type FakeDBInstance struct {
+	// Instances, when set, are looked up by identifier by Describe, GetTag
+	// and GetTags, which fail with ErrDBInstanceDoesNotExist for unknown ones.
+	Instances map[string]awsrds.DBInstanceDetails
+
 	DescribeCalled            bool
 	DescribeID                string
 	DescribeDBInstanceDetails awsrds.DBInstanceDetails
@@ -32,15 +36,123 @@ type FakeDBInstance struct {
 	DeleteSkipFinalSnapshot bool
 	DeleteError             error
 
+	StopCalled bool
+	StopID     string
+	StopError  error
+
+	StartCalled bool
+	StartID     string
+	StartError  error
+
 	GetTagKey   string
 	GetTagValue string
 	GetTagError error
+
+	GetTagsID    string
+	GetTagsTags  map[string]string
+	GetTagsError error
+
+	AddTagsCalled bool
+	AddTagsID     string
+	AddTagsTags   map[string]string
+	AddTagsError  error
+
+	RemoveTagsCalled bool
+	RemoveTagsID     string
+	RemoveTagsKeys   []string
+	RemoveTagsError  error
+
+	DescribeSnapshotsCalled                     bool
+	DescribeSnapshotsDBInstanceIdentifierPrefix string
+	DescribeSnapshotsTagKey                     string
+	DescribeSnapshotsTagValue                   string
+	DescribeSnapshotsDBSnapshotDetails          []*awsrds.DBSnapshotDetails
+	DescribeSnapshotsError                      error
+
+	DescribeInstanceSnapshotsCalled            bool
+	DescribeInstanceSnapshotsID                string
+	DescribeInstanceSnapshotsDBSnapshotDetails []*awsrds.DBSnapshotDetails
+	DescribeInstanceSnapshotsError             error
+
+	CreateSnapshotCalled     bool
+	CreateSnapshotID         string
+	CreateSnapshotTags       map[string]string
+	CreateSnapshotSnapshotID string
+	CreateSnapshotError      error
+
+	DeleteSnapshotCalled bool
+	DeleteSnapshotIDs    []string
+	DeleteSnapshotError  error
+
+	CopySnapshotCalled   bool
+	CopySnapshotID       string
+	CopySnapshotTargetID string
+	CopySnapshotKmsKeyID string
+	CopySnapshotTags     map[string]string
+	CopySnapshotError    error
+
+	RestoreFromSnapshotCalled            bool
+	RestoreFromSnapshotID                string
+	RestoreFromSnapshotSnapshotID        string
+	RestoreFromSnapshotDBInstanceDetails awsrds.DBInstanceDetails
+	RestoreFromSnapshotError             error
+
+	RenameCalled bool
+	RenameIDs    []string
+	RenameNewIDs []string
+	RenameError  error
+
+	CopyParameterGroupCalled   bool
+	CopyParameterGroupSourceID string
+	CopyParameterGroupID       string
+	CopyParameterGroupTags     map[string]string
+	CopyParameterGroupError    error
+
+	ModifyParameterGroupCalled     bool
+	ModifyParameterGroupID         string
+	ModifyParameterGroupParameters map[string]string
+	ModifyParameterGroupError      error
+
+	DeleteParameterGroupCalled bool
+	DeleteParameterGroupID     string
+	DeleteParameterGroupError  error
+
+	CreateOptionGroupCalled             bool
+	CreateOptionGroupID                 string
+	CreateOptionGroupEngine             string
+	CreateOptionGroupMajorEngineVersion string
+	CreateOptionGroupTags               map[string]string
+	CreateOptionGroupError              error
+
+	CopyOptionGroupCalled   bool
+	CopyOptionGroupSourceID string
+	CopyOptionGroupID       string
+	CopyOptionGroupTags     map[string]string
+	CopyOptionGroupError    error
+
+	ModifyOptionGroupCalled           bool
+	ModifyOptionGroupID               string
+	ModifyOptionGroupOptionsToInclude []awsrds.DBOption
+	ModifyOptionGroupOptionsToRemove  []string
+	ModifyOptionGroupError            error
+
+	DeleteOptionGroupCalled bool
+	DeleteOptionGroupID     string
+	DeleteOptionGroupError  error
 }
 
 func (f *FakeDBInstance) Describe(ID string) (awsrds.DBInstanceDetails, error) {
 	f.DescribeCalled = true
 	f.DescribeID = ID
 
+	if f.Instances != nil && f.DescribeError == nil {
+		dbInstanceDetails, ok := f.Instances[ID]
+		if !ok {
+			return awsrds.DBInstanceDetails{}, awsrds.ErrDBInstanceDoesNotExist
+		}
+		return dbInstanceDetails, nil
+	}
+
 	return f.DescribeDBInstanceDetails, f.DescribeError
 }
 
@@ -49,9 +161,31 @@ func (f *FakeDBInstance) GetTag(ID, tagKey string) (string, error) {
 	f.GetTagKey = tagKey
 	f.DescribeID = ID
 
+	if f.Instances != nil {
+		dbInstanceDetails, ok := f.Instances[ID]
+		if !ok {
+			return "", awsrds.ErrDBInstanceDoesNotExist
+		}
+		return dbInstanceDetails.Tags[tagKey], nil
+	}
+
 	return f.GetTagValue, f.GetTagError
 }
 
+func (f *FakeDBInstance) GetTags(ID string) (map[string]string, error) {
+	f.GetTagsID = ID
+
+	if f.Instances != nil {
+		dbInstanceDetails, ok := f.Instances[ID]
+		if !ok {
+			return nil, awsrds.ErrDBInstanceDoesNotExist
+		}
+		return dbInstanceDetails.Tags, nil
+	}
+
+	return f.GetTagsTags, f.GetTagsError
+}
+
 func (f *FakeDBInstance) DescribeByTag(tagKey, tagValue string) ([]*awsrds.DBInstanceDetails, error) {
 	f.DescribeByTagCalled = true
 	f.DescribeByTagKey = tagKey
@@ -84,3 +218,150 @@ func (f *FakeDBInstance) Delete(ID string, skipFinalSnapshot bool) error {
 
 	return f.DeleteError
 }
+
+func (f *FakeDBInstance) Stop(ID string) error {
+	f.StopCalled = true
+	f.StopID = ID
+
+	return f.StopError
+}
+
+func (f *FakeDBInstance) Start(ID string) error {
+	f.StartCalled = true
+	f.StartID = ID
+
+	return f.StartError
+}
+
+func (f *FakeDBInstance) AddTags(ID string, tags map[string]string) error {
+	f.AddTagsCalled = true
+	f.AddTagsID = ID
+	f.AddTagsTags = tags
+
+	return f.AddTagsError
+}
+
+func (f *FakeDBInstance) RemoveTags(ID string, tagKeys []string) error {
+	f.RemoveTagsCalled = true
+	f.RemoveTagsID = ID
+	f.RemoveTagsKeys = tagKeys
+
+	return f.RemoveTagsError
+}
+
+func (f *FakeDBInstance) DescribeSnapshots(dbInstanceIdentifierPrefix, tagKey, tagValue string) ([]*awsrds.DBSnapshotDetails, error) {
+	f.DescribeSnapshotsCalled = true
+	f.DescribeSnapshotsDBInstanceIdentifierPrefix = dbInstanceIdentifierPrefix
+	f.DescribeSnapshotsTagKey = tagKey
+	f.DescribeSnapshotsTagValue = tagValue
+
+	return f.DescribeSnapshotsDBSnapshotDetails, f.DescribeSnapshotsError
+}
+
+func (f *FakeDBInstance) DescribeInstanceSnapshots(ID string) ([]*awsrds.DBSnapshotDetails, error) {
+	f.DescribeInstanceSnapshotsCalled = true
+	f.DescribeInstanceSnapshotsID = ID
+
+	return f.DescribeInstanceSnapshotsDBSnapshotDetails, f.DescribeInstanceSnapshotsError
+}
+
+func (f *FakeDBInstance) CreateSnapshot(ID string, tags map[string]string) (string, error) {
+	f.CreateSnapshotCalled = true
+	f.CreateSnapshotID = ID
+	f.CreateSnapshotTags = tags
+
+	return f.CreateSnapshotSnapshotID, f.CreateSnapshotError
+}
+
+func (f *FakeDBInstance) DeleteSnapshot(ID string) error {
+	f.DeleteSnapshotCalled = true
+	f.DeleteSnapshotIDs = append(f.DeleteSnapshotIDs, ID)
+
+	return f.DeleteSnapshotError
+}
+
+func (f *FakeDBInstance) CopySnapshot(ID, targetID, kmsKeyID string, tags map[string]string) error {
+	f.CopySnapshotCalled = true
+	f.CopySnapshotID = ID
+	f.CopySnapshotTargetID = targetID
+	f.CopySnapshotKmsKeyID = kmsKeyID
+	f.CopySnapshotTags = tags
+
+	return f.CopySnapshotError
+}
+
+func (f *FakeDBInstance) RestoreFromSnapshot(ID, snapshotID string, dbInstanceDetails awsrds.DBInstanceDetails) error {
+	f.RestoreFromSnapshotCalled = true
+	f.RestoreFromSnapshotID = ID
+	f.RestoreFromSnapshotSnapshotID = snapshotID
+	f.RestoreFromSnapshotDBInstanceDetails = dbInstanceDetails
+
+	return f.RestoreFromSnapshotError
+}
+
+func (f *FakeDBInstance) Rename(ID, newID string) error {
+	f.RenameCalled = true
+	f.RenameIDs = append(f.RenameIDs, ID)
+	f.RenameNewIDs = append(f.RenameNewIDs, newID)
+
+	return f.RenameError
+}
+
+func (f *FakeDBInstance) CopyParameterGroup(sourceID, ID string, tags map[string]string) error {
+	f.CopyParameterGroupCalled = true
+	f.CopyParameterGroupSourceID = sourceID
+	f.CopyParameterGroupID = ID
+	f.CopyParameterGroupTags = tags
+
+	return f.CopyParameterGroupError
+}
+
+func (f *FakeDBInstance) ModifyParameterGroup(ID string, parameters map[string]string) error {
+	f.ModifyParameterGroupCalled = true
+	f.ModifyParameterGroupID = ID
+	f.ModifyParameterGroupParameters = parameters
+
+	return f.ModifyParameterGroupError
+}
+
+func (f *FakeDBInstance) DeleteParameterGroup(ID string) error {
+	f.DeleteParameterGroupCalled = true
+	f.DeleteParameterGroupID = ID
+
+	return f.DeleteParameterGroupError
+}
+
+func (f *FakeDBInstance) CreateOptionGroup(ID, engine, majorEngineVersion string, tags map[string]string) error {
+	f.CreateOptionGroupCalled = true
+	f.CreateOptionGroupID = ID
+	f.CreateOptionGroupEngine = engine
+	f.CreateOptionGroupMajorEngineVersion = majorEngineVersion
+	f.CreateOptionGroupTags = tags
+
+	return f.CreateOptionGroupError
+}
+
+func (f *FakeDBInstance) CopyOptionGroup(sourceID, ID string, tags map[string]string) error {
+	f.CopyOptionGroupCalled = true
+	f.CopyOptionGroupSourceID = sourceID
+	f.CopyOptionGroupID = ID
+	f.CopyOptionGroupTags = tags
+
+	return f.CopyOptionGroupError
+}
+
+func (f *FakeDBInstance) ModifyOptionGroup(ID string, optionsToInclude []awsrds.DBOption, optionsToRemove []string) error {
+	f.ModifyOptionGroupCalled = true
+	f.ModifyOptionGroupID = ID
+	f.ModifyOptionGroupOptionsToInclude = optionsToInclude
+	f.ModifyOptionGroupOptionsToRemove = optionsToRemove
+
+	return f.ModifyOptionGroupError
+}
+
+func (f *FakeDBInstance) DeleteOptionGroup(ID string) error {
+	f.DeleteOptionGroupCalled = true
+	f.DeleteOptionGroupID = ID
+
+	return f.DeleteOptionGroupError
+}
@@ -3,7 +3,10 @@ package awsrds
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -12,6 +15,17 @@ import (
 	"github.com/pivotal-golang/lager"
 )
 
+const finalSnapshotSuffix = "-final-snapshot"
+
+// finalSnapshotTag is set on a DB instance being deleted to the identifier of
+// its final snapshot, which gets the tags of the instance.
+const finalSnapshotTag = "Final Snapshot"
+
+// maxModifiedParameters is the number of parameters RDS accepts in a single
+// ModifyDBParameterGroup call.
+const maxModifiedParameters = 20
+const snapshotTimestampFormat = "20060102-150405"
+
 type RDSDBInstance struct {
 	region    string
 	partition string
@@ -94,6 +108,7 @@ func (r *RDSDBInstance) DescribeByTag(tagKey, tagValue string) ([]*DBInstanceDet
 		for _, t := range listTagsForResourceOutput.TagList {
 			if *t.Key == tagKey && *t.Value == tagValue {
 				d := r.buildDBInstance(dbInstance)
+				d.Tags = RDSTagsValues(listTagsForResourceOutput.TagList)
 				dbInstanceDetails = append(dbInstanceDetails, &d)
 				break
 			}
@@ -104,12 +119,20 @@ func (r *RDSDBInstance) DescribeByTag(tagKey, tagValue string) ([]*DBInstanceDet
 }
 
 func (r *RDSDBInstance) GetTag(ID, tagKey string) (string, error) {
+	tags, err := r.GetTags(ID)
+	if err != nil {
+		return "", err
+	}
+
+	return tags[tagKey], nil
+}
 
+func (r *RDSDBInstance) GetTags(ID string) (map[string]string, error) {
 	describeDBInstancesInput := &rds.DescribeDBInstancesInput{
 		DBInstanceIdentifier: aws.String(ID),
 	}
 
-	r.logger.Debug("get-tag", lager.Data{"input": describeDBInstancesInput})
+	r.logger.Debug("get-tags", lager.Data{"input": describeDBInstancesInput})
 
 	myInstance, err := r.rdssvc.DescribeDBInstances(describeDBInstancesInput)
 	if err != nil {
@@ -117,17 +140,17 @@ func (r *RDSDBInstance) GetTag(ID, tagKey string) (string, error) {
 		if awsErr, ok := err.(awserr.Error); ok {
 			if reqErr, ok := err.(awserr.RequestFailure); ok {
 				if reqErr.StatusCode() == 404 {
-					return "", ErrDBInstanceDoesNotExist
+					return nil, ErrDBInstanceDoesNotExist
 				}
 			}
-			return "", errors.New(awsErr.Code() + ": " + awsErr.Message())
+			return nil, errors.New(awsErr.Code() + ": " + awsErr.Message())
 		}
-		return "", err
+		return nil, err
 	}
 
 	dbArn, err := r.dbInstanceARN(*myInstance.DBInstances[0].DBInstanceIdentifier)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	listTagsForResourceInput := &rds.ListTagsForResourceInput{
@@ -136,16 +159,46 @@ func (r *RDSDBInstance) GetTag(ID, tagKey string) (string, error) {
 
 	listTagsForResourceOutput, err := r.rdssvc.ListTagsForResource(listTagsForResourceInput)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return RDSTagsValues(listTagsForResourceOutput.TagList), nil
+}
+
+func (r *RDSDBInstance) AddTags(ID string, tags map[string]string) error {
+	dbInstanceARN, err := r.dbInstanceARN(ID)
+	if err != nil {
+		return err
+	}
+
+	return AddTagsToResource(dbInstanceARN, BuilRDSTags(tags), r.rdssvc, r.logger)
+}
+
+func (r *RDSDBInstance) RemoveTags(ID string, tagKeys []string) error {
+	dbInstanceARN, err := r.dbInstanceARN(ID)
+	if err != nil {
+		return err
 	}
 
-	for _, t := range listTagsForResourceOutput.TagList {
-		if *t.Key == tagKey {
-			return *t.Value, nil
+	removeTagsFromResourceInput := &rds.RemoveTagsFromResourceInput{
+		ResourceName: aws.String(dbInstanceARN),
+		TagKeys:      aws.StringSlice(tagKeys),
+	}
+
+	r.logger.Debug("remove-tags-from-resource", lager.Data{"input": removeTagsFromResourceInput})
+
+	removeTagsFromResourceOutput, err := r.rdssvc.RemoveTagsFromResource(removeTagsFromResourceInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
 		}
+		return err
 	}
 
-	return "", nil
+	r.logger.Debug("remove-tags-from-resource", lager.Data{"output": removeTagsFromResourceOutput})
+
+	return nil
 }
 
 func (r *RDSDBInstance) Create(ID string, dbInstanceDetails DBInstanceDetails) error {
@@ -214,7 +267,24 @@ func (r *RDSDBInstance) Modify(ID string, dbInstanceDetails DBInstanceDetails, a
 }
 
 func (r *RDSDBInstance) Delete(ID string, skipFinalSnapshot bool) error {
-	deleteDBInstanceInput := r.buildDeleteDBInstanceInput(ID, skipFinalSnapshot)
+	dbInstanceDetails, err := r.Describe(ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.prepareForDeletion(ID, dbInstanceDetails, skipFinalSnapshot); err != nil {
+		return err
+	}
+
+	finalSnapshotID := ""
+	if !skipFinalSnapshot {
+		finalSnapshotID = r.dbSnapshotName(ID)
+		if err := r.AddTags(ID, map[string]string{finalSnapshotTag: finalSnapshotID}); err != nil {
+			return err
+		}
+	}
+
+	deleteDBInstanceInput := r.buildDeleteDBInstanceInput(ID, finalSnapshotID)
 	r.logger.Debug("delete-db-instance", lager.Data{"input": deleteDBInstanceInput})
 
 	deleteDBInstanceOutput, err := r.rdssvc.DeleteDBInstance(deleteDBInstanceInput)
@@ -236,6 +306,609 @@ func (r *RDSDBInstance) Delete(ID string, skipFinalSnapshot bool) error {
 	return nil
 }
 
+func (r *RDSDBInstance) Stop(ID string) error {
+	stopDBInstanceInput := &rds.StopDBInstanceInput{
+		DBInstanceIdentifier: aws.String(ID),
+	}
+	r.logger.Debug("stop-db-instance", lager.Data{"input": stopDBInstanceInput})
+
+	stopDBInstanceOutput, err := r.rdssvc.StopDBInstance(stopDBInstanceInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if reqErr, ok := err.(awserr.RequestFailure); ok {
+				if reqErr.StatusCode() == 404 {
+					return ErrDBInstanceDoesNotExist
+				}
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("stop-db-instance", lager.Data{"output": stopDBInstanceOutput})
+
+	return nil
+}
+
+func (r *RDSDBInstance) Start(ID string) error {
+	startDBInstanceInput := &rds.StartDBInstanceInput{
+		DBInstanceIdentifier: aws.String(ID),
+	}
+	r.logger.Debug("start-db-instance", lager.Data{"input": startDBInstanceInput})
+
+	startDBInstanceOutput, err := r.rdssvc.StartDBInstance(startDBInstanceInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if reqErr, ok := err.(awserr.RequestFailure); ok {
+				if reqErr.StatusCode() == 404 {
+					return ErrDBInstanceDoesNotExist
+				}
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("start-db-instance", lager.Data{"output": startDBInstanceOutput})
+
+	return nil
+}
+
+// prepareForDeletion disables the deletion protection, as the broker is the
+// owner of the instance and it only guards against deletions happening outside
+// of it, and makes sure the final snapshot gets the tags of the instance.
+func (r *RDSDBInstance) prepareForDeletion(ID string, dbInstanceDetails DBInstanceDetails, skipFinalSnapshot bool) error {
+	modifyDBInstanceInput := &rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(ID),
+		ApplyImmediately:     aws.Bool(true),
+	}
+
+	if aws.BoolValue(dbInstanceDetails.DeletionProtection) {
+		modifyDBInstanceInput.DeletionProtection = aws.Bool(false)
+	}
+
+	if !skipFinalSnapshot && !aws.BoolValue(dbInstanceDetails.CopyTagsToSnapshot) {
+		modifyDBInstanceInput.CopyTagsToSnapshot = aws.Bool(true)
+	}
+
+	if modifyDBInstanceInput.DeletionProtection == nil && modifyDBInstanceInput.CopyTagsToSnapshot == nil {
+		return nil
+	}
+
+	r.logger.Debug("prepare-for-deletion", lager.Data{"input": modifyDBInstanceInput})
+
+	_, err := r.rdssvc.ModifyDBInstance(modifyDBInstanceInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DescribeSnapshots returns the manual snapshots with the given tag of the DB
+// instances whose identifier starts with dbInstanceIdentifierPrefix.
+func (r *RDSDBInstance) DescribeSnapshots(dbInstanceIdentifierPrefix, tagKey, tagValue string) ([]*DBSnapshotDetails, error) {
+	dbSnapshotDetails := []*DBSnapshotDetails{}
+
+	describeDBSnapshotsInput := &rds.DescribeDBSnapshotsInput{
+		SnapshotType: aws.String("manual"),
+	}
+
+	dbSnapshots, err := r.describeSnapshots(describeDBSnapshotsInput, dbInstanceIdentifierPrefix)
+	if err != nil {
+		return dbSnapshotDetails, err
+	}
+
+	for _, dbSnapshot := range dbSnapshots {
+		if value, ok := dbSnapshot.Tags[tagKey]; ok && value == tagValue {
+			dbSnapshotDetails = append(dbSnapshotDetails, dbSnapshot)
+		}
+	}
+
+	return dbSnapshotDetails, nil
+}
+
+func (r *RDSDBInstance) DescribeInstanceSnapshots(ID string) ([]*DBSnapshotDetails, error) {
+	describeDBSnapshotsInput := &rds.DescribeDBSnapshotsInput{
+		DBInstanceIdentifier: aws.String(ID),
+		SnapshotType:         aws.String("manual"),
+	}
+
+	return r.describeSnapshots(describeDBSnapshotsInput, ID)
+}
+
+func (r *RDSDBInstance) CreateSnapshot(ID string, tags map[string]string) (string, error) {
+	createDBSnapshotInput := &rds.CreateDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(ID),
+		DBSnapshotIdentifier: aws.String(fmt.Sprintf("%s-snapshot-%s", ID, time.Now().UTC().Format(snapshotTimestampFormat))),
+	}
+
+	if len(tags) > 0 {
+		createDBSnapshotInput.Tags = BuilRDSTags(tags)
+	}
+
+	r.logger.Debug("create-db-snapshot", lager.Data{"input": createDBSnapshotInput})
+
+	createDBSnapshotOutput, err := r.rdssvc.CreateDBSnapshot(createDBSnapshotInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if reqErr, ok := err.(awserr.RequestFailure); ok {
+				if reqErr.StatusCode() == 404 {
+					return "", ErrDBInstanceDoesNotExist
+				}
+			}
+			return "", errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return "", err
+	}
+
+	r.logger.Debug("create-db-snapshot", lager.Data{"output": createDBSnapshotOutput})
+
+	return aws.StringValue(createDBSnapshotInput.DBSnapshotIdentifier), nil
+}
+
+func (r *RDSDBInstance) DeleteSnapshot(ID string) error {
+	deleteDBSnapshotInput := &rds.DeleteDBSnapshotInput{
+		DBSnapshotIdentifier: aws.String(ID),
+	}
+	r.logger.Debug("delete-db-snapshot", lager.Data{"input": deleteDBSnapshotInput})
+
+	deleteDBSnapshotOutput, err := r.rdssvc.DeleteDBSnapshot(deleteDBSnapshotInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("delete-db-snapshot", lager.Data{"output": deleteDBSnapshotOutput})
+
+	return nil
+}
+
+// CopySnapshot copies a DB snapshot, encrypting the copy with the given KMS
+// key.
+func (r *RDSDBInstance) CopySnapshot(ID, targetID, kmsKeyID string, tags map[string]string) error {
+	copyDBSnapshotInput := &rds.CopyDBSnapshotInput{
+		SourceDBSnapshotIdentifier: aws.String(ID),
+		TargetDBSnapshotIdentifier: aws.String(targetID),
+	}
+
+	if kmsKeyID != "" {
+		copyDBSnapshotInput.KmsKeyId = aws.String(kmsKeyID)
+	}
+
+	if len(tags) > 0 {
+		copyDBSnapshotInput.Tags = BuilRDSTags(tags)
+	}
+
+	r.logger.Debug("copy-db-snapshot", lager.Data{"input": copyDBSnapshotInput})
+
+	copyDBSnapshotOutput, err := r.rdssvc.CopyDBSnapshot(copyDBSnapshotInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("copy-db-snapshot", lager.Data{"output": copyDBSnapshotOutput})
+
+	return nil
+}
+
+// RestoreFromSnapshot creates a new DB instance from a DB snapshot. Only the
+// settings accepted by RDS on restore are used, the other ones have to be set
+// with Modify once the DB instance is available.
+func (r *RDSDBInstance) RestoreFromSnapshot(ID, snapshotID string, dbInstanceDetails DBInstanceDetails) error {
+	restoreDBInstanceInput := &rds.RestoreDBInstanceFromDBSnapshotInput{
+		DBInstanceIdentifier:    aws.String(ID),
+		DBSnapshotIdentifier:    aws.String(snapshotID),
+		AutoMinorVersionUpgrade: aws.Bool(aws.BoolValue(dbInstanceDetails.AutoMinorVersionUpgrade)),
+		CopyTagsToSnapshot:      aws.Bool(aws.BoolValue(dbInstanceDetails.CopyTagsToSnapshot)),
+		DeletionProtection:      aws.Bool(aws.BoolValue(dbInstanceDetails.DeletionProtection)),
+		MultiAZ:                 aws.Bool(aws.BoolValue(dbInstanceDetails.MultiAZ)),
+		PubliclyAccessible:      aws.Bool(dbInstanceDetails.PubliclyAccessible),
+	}
+
+	restoreDBInstanceInput.EnableIAMDatabaseAuthentication = aws.Bool(aws.BoolValue(dbInstanceDetails.IAMDatabaseAuthentication))
+
+	if dbInstanceDetails.AvailabilityZone != "" {
+		restoreDBInstanceInput.AvailabilityZone = aws.String(dbInstanceDetails.AvailabilityZone)
+	}
+
+	if dbInstanceDetails.DBInstanceClass != "" {
+		restoreDBInstanceInput.DBInstanceClass = aws.String(dbInstanceDetails.DBInstanceClass)
+	}
+
+	if dbInstanceDetails.DBParameterGroupName != "" {
+		restoreDBInstanceInput.DBParameterGroupName = aws.String(dbInstanceDetails.DBParameterGroupName)
+	}
+
+	if dbInstanceDetails.DBSubnetGroupName != "" {
+		restoreDBInstanceInput.DBSubnetGroupName = aws.String(dbInstanceDetails.DBSubnetGroupName)
+	}
+
+	if dbInstanceDetails.OptionGroupName != "" {
+		restoreDBInstanceInput.OptionGroupName = aws.String(dbInstanceDetails.OptionGroupName)
+	}
+
+	if dbInstanceDetails.StorageType != "" {
+		restoreDBInstanceInput.StorageType = aws.String(dbInstanceDetails.StorageType)
+	}
+
+	if dbInstanceDetails.Iops > 0 {
+		restoreDBInstanceInput.Iops = aws.Int64(dbInstanceDetails.Iops)
+	}
+
+	if len(dbInstanceDetails.Tags) > 0 {
+		restoreDBInstanceInput.Tags = BuilRDSTags(dbInstanceDetails.Tags)
+	}
+
+	r.logger.Debug("restore-db-instance-from-db-snapshot", lager.Data{"input": restoreDBInstanceInput})
+
+	restoreDBInstanceOutput, err := r.rdssvc.RestoreDBInstanceFromDBSnapshot(restoreDBInstanceInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("restore-db-instance-from-db-snapshot", lager.Data{"output": restoreDBInstanceOutput})
+
+	return nil
+}
+
+// Rename changes the identifier of a DB instance straight away.
+func (r *RDSDBInstance) Rename(ID, newID string) error {
+	modifyDBInstanceInput := &rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier:    aws.String(ID),
+		NewDBInstanceIdentifier: aws.String(newID),
+		ApplyImmediately:        aws.Bool(true),
+	}
+	r.logger.Debug("rename-db-instance", lager.Data{"input": modifyDBInstanceInput})
+
+	modifyDBInstanceOutput, err := r.rdssvc.ModifyDBInstance(modifyDBInstanceInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if reqErr, ok := err.(awserr.RequestFailure); ok {
+				if reqErr.StatusCode() == 404 {
+					return ErrDBInstanceDoesNotExist
+				}
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("rename-db-instance", lager.Data{"output": modifyDBInstanceOutput})
+
+	return nil
+}
+
+// CopyParameterGroup creates a DB parameter group with the same family and
+// parameters as an existing one.
+func (r *RDSDBInstance) CopyParameterGroup(sourceID, ID string, tags map[string]string) error {
+	copyDBParameterGroupInput := &rds.CopyDBParameterGroupInput{
+		SourceDBParameterGroupIdentifier:  aws.String(sourceID),
+		TargetDBParameterGroupIdentifier:  aws.String(ID),
+		TargetDBParameterGroupDescription: aws.String(fmt.Sprintf("Copy of %s for %s", sourceID, ID)),
+	}
+
+	if len(tags) > 0 {
+		copyDBParameterGroupInput.Tags = BuilRDSTags(tags)
+	}
+
+	r.logger.Debug("copy-db-parameter-group", lager.Data{"input": copyDBParameterGroupInput})
+
+	copyDBParameterGroupOutput, err := r.rdssvc.CopyDBParameterGroup(copyDBParameterGroupInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "DBParameterGroupAlreadyExists" {
+				return ErrDBParameterGroupAlreadyExists
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("copy-db-parameter-group", lager.Data{"output": copyDBParameterGroupOutput})
+
+	return nil
+}
+
+// ModifyParameterGroup sets parameters of a DB parameter group. Dynamic
+// parameters are applied straight away, static ones when the DB instances
+// using the group are rebooted.
+func (r *RDSDBInstance) ModifyParameterGroup(ID string, parameters map[string]string) error {
+	applyTypes := map[string]string{}
+	describeDBParametersInput := &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(ID),
+	}
+	err := r.rdssvc.DescribeDBParametersPages(describeDBParametersInput, func(page *rds.DescribeDBParametersOutput, lastPage bool) bool {
+		for _, parameter := range page.Parameters {
+			if aws.BoolValue(parameter.IsModifiable) {
+				applyTypes[aws.StringValue(parameter.ParameterName)] = aws.StringValue(parameter.ApplyType)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "DBParameterGroupNotFound" {
+				return ErrDBParameterGroupDoesNotExist
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rdsParameters []*rds.Parameter
+	for _, name := range names {
+		applyType, ok := applyTypes[name]
+		if !ok {
+			return fmt.Errorf("DB parameter '%s' does not exist or cannot be modified in DB parameter group '%s'", name, ID)
+		}
+		applyMethod := "immediate"
+		if applyType == "static" {
+			applyMethod = "pending-reboot"
+		}
+		rdsParameters = append(rdsParameters, &rds.Parameter{
+			ParameterName:  aws.String(name),
+			ParameterValue: aws.String(parameters[name]),
+			ApplyMethod:    aws.String(applyMethod),
+		})
+	}
+
+	for len(rdsParameters) > 0 {
+		batch := rdsParameters
+		if len(batch) > maxModifiedParameters {
+			batch = batch[:maxModifiedParameters]
+		}
+		rdsParameters = rdsParameters[len(batch):]
+
+		modifyDBParameterGroupInput := &rds.ModifyDBParameterGroupInput{
+			DBParameterGroupName: aws.String(ID),
+			Parameters:           batch,
+		}
+		r.logger.Debug("modify-db-parameter-group", lager.Data{"input": modifyDBParameterGroupInput})
+
+		modifyDBParameterGroupOutput, err := r.rdssvc.ModifyDBParameterGroup(modifyDBParameterGroupInput)
+		if err != nil {
+			r.logger.Error("aws-rds-error", err)
+			if awsErr, ok := err.(awserr.Error); ok {
+				return errors.New(awsErr.Code() + ": " + awsErr.Message())
+			}
+			return err
+		}
+
+		r.logger.Debug("modify-db-parameter-group", lager.Data{"output": modifyDBParameterGroupOutput})
+	}
+
+	return nil
+}
+
+// DeleteParameterGroup deletes a DB parameter group, which must not be used
+// by any DB instance anymore.
+func (r *RDSDBInstance) DeleteParameterGroup(ID string) error {
+	deleteDBParameterGroupInput := &rds.DeleteDBParameterGroupInput{
+		DBParameterGroupName: aws.String(ID),
+	}
+	r.logger.Debug("delete-db-parameter-group", lager.Data{"input": deleteDBParameterGroupInput})
+
+	deleteDBParameterGroupOutput, err := r.rdssvc.DeleteDBParameterGroup(deleteDBParameterGroupInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "DBParameterGroupNotFound" {
+				return ErrDBParameterGroupDoesNotExist
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("delete-db-parameter-group", lager.Data{"output": deleteDBParameterGroupOutput})
+
+	return nil
+}
+
+// CreateOptionGroup creates an empty option group for an engine major
+// version.
+func (r *RDSDBInstance) CreateOptionGroup(ID, engine, majorEngineVersion string, tags map[string]string) error {
+	createOptionGroupInput := &rds.CreateOptionGroupInput{
+		OptionGroupName:        aws.String(ID),
+		OptionGroupDescription: aws.String(fmt.Sprintf("Options of %s", ID)),
+		EngineName:             aws.String(engine),
+		MajorEngineVersion:     aws.String(majorEngineVersion),
+	}
+
+	if len(tags) > 0 {
+		createOptionGroupInput.Tags = BuilRDSTags(tags)
+	}
+
+	r.logger.Debug("create-option-group", lager.Data{"input": createOptionGroupInput})
+
+	createOptionGroupOutput, err := r.rdssvc.CreateOptionGroup(createOptionGroupInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "OptionGroupAlreadyExistsFault" {
+				return ErrOptionGroupAlreadyExists
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("create-option-group", lager.Data{"output": createOptionGroupOutput})
+
+	return nil
+}
+
+// CopyOptionGroup creates an option group with the same engine and options as
+// an existing one.
+func (r *RDSDBInstance) CopyOptionGroup(sourceID, ID string, tags map[string]string) error {
+	copyOptionGroupInput := &rds.CopyOptionGroupInput{
+		SourceOptionGroupIdentifier:  aws.String(sourceID),
+		TargetOptionGroupIdentifier:  aws.String(ID),
+		TargetOptionGroupDescription: aws.String(fmt.Sprintf("Copy of %s for %s", sourceID, ID)),
+	}
+
+	if len(tags) > 0 {
+		copyOptionGroupInput.Tags = BuilRDSTags(tags)
+	}
+
+	r.logger.Debug("copy-option-group", lager.Data{"input": copyOptionGroupInput})
+
+	copyOptionGroupOutput, err := r.rdssvc.CopyOptionGroup(copyOptionGroupInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "OptionGroupAlreadyExistsFault" {
+				return ErrOptionGroupAlreadyExists
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("copy-option-group", lager.Data{"output": copyOptionGroupOutput})
+
+	return nil
+}
+
+// ModifyOptionGroup adds or reconfigures options of an option group and
+// removes others, straight away. Options to remove which are not in the group
+// are ignored.
+func (r *RDSDBInstance) ModifyOptionGroup(ID string, optionsToInclude []DBOption, optionsToRemove []string) error {
+	describeOptionGroupsInput := &rds.DescribeOptionGroupsInput{
+		OptionGroupName: aws.String(ID),
+	}
+	r.logger.Debug("describe-option-groups", lager.Data{"input": describeOptionGroupsInput})
+
+	describeOptionGroupsOutput, err := r.rdssvc.DescribeOptionGroups(describeOptionGroupsInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "OptionGroupNotFoundFault" {
+				return ErrOptionGroupDoesNotExist
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	existingOptions := map[string]bool{}
+	for _, optionGroup := range describeOptionGroupsOutput.OptionGroupsList {
+		for _, option := range optionGroup.Options {
+			existingOptions[aws.StringValue(option.OptionName)] = true
+		}
+	}
+
+	modifyOptionGroupInput := &rds.ModifyOptionGroupInput{
+		OptionGroupName:  aws.String(ID),
+		ApplyImmediately: aws.Bool(true),
+	}
+
+	for _, option := range optionsToInclude {
+		optionConfiguration := &rds.OptionConfiguration{
+			OptionName: aws.String(option.Name),
+		}
+		settingNames := make([]string, 0, len(option.Settings))
+		for name := range option.Settings {
+			settingNames = append(settingNames, name)
+		}
+		sort.Strings(settingNames)
+		for _, name := range settingNames {
+			optionConfiguration.OptionSettings = append(optionConfiguration.OptionSettings, &rds.OptionSetting{
+				Name:  aws.String(name),
+				Value: aws.String(option.Settings[name]),
+			})
+		}
+		if option.Port > 0 {
+			optionConfiguration.Port = aws.Int64(option.Port)
+		}
+		if len(option.VpcSecurityGroupIds) > 0 {
+			optionConfiguration.VpcSecurityGroupMemberships = aws.StringSlice(option.VpcSecurityGroupIds)
+		}
+		modifyOptionGroupInput.OptionsToInclude = append(modifyOptionGroupInput.OptionsToInclude, optionConfiguration)
+	}
+
+	for _, name := range optionsToRemove {
+		if existingOptions[name] {
+			modifyOptionGroupInput.OptionsToRemove = append(modifyOptionGroupInput.OptionsToRemove, aws.String(name))
+		}
+	}
+
+	if len(modifyOptionGroupInput.OptionsToInclude) == 0 && len(modifyOptionGroupInput.OptionsToRemove) == 0 {
+		return nil
+	}
+
+	r.logger.Debug("modify-option-group", lager.Data{"input": modifyOptionGroupInput})
+
+	modifyOptionGroupOutput, err := r.rdssvc.ModifyOptionGroup(modifyOptionGroupInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "OptionGroupNotFoundFault" {
+				return ErrOptionGroupDoesNotExist
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("modify-option-group", lager.Data{"output": modifyOptionGroupOutput})
+
+	return nil
+}
+
+// DeleteOptionGroup deletes an option group, which must not be used by any DB
+// instance anymore.
+func (r *RDSDBInstance) DeleteOptionGroup(ID string) error {
+	deleteOptionGroupInput := &rds.DeleteOptionGroupInput{
+		OptionGroupName: aws.String(ID),
+	}
+	r.logger.Debug("delete-option-group", lager.Data{"input": deleteOptionGroupInput})
+
+	deleteOptionGroupOutput, err := r.rdssvc.DeleteOptionGroup(deleteOptionGroupInput)
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			if awsErr.Code() == "OptionGroupNotFoundFault" {
+				return ErrOptionGroupDoesNotExist
+			}
+			return errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return err
+	}
+
+	r.logger.Debug("delete-option-group", lager.Data{"output": deleteOptionGroupOutput})
+
+	return nil
+}
+
 func (r *RDSDBInstance) buildDBInstance(dbInstance *rds.DBInstance) DBInstanceDetails {
 	dbInstanceDetails := DBInstanceDetails{
 		Identifier:       aws.StringValue(dbInstance.DBInstanceIdentifier),
@@ -245,6 +918,17 @@ func (r *RDSDBInstance) buildDBInstance(dbInstance *rds.DBInstance) DBInstanceDe
 		DBName:           aws.StringValue(dbInstance.DBName),
 		MasterUsername:   aws.StringValue(dbInstance.MasterUsername),
 		AllocatedStorage: aws.Int64Value(dbInstance.AllocatedStorage),
+
+		AutoMinorVersionUpgrade: dbInstance.AutoMinorVersionUpgrade,
+		CopyTagsToSnapshot:      dbInstance.CopyTagsToSnapshot,
+		DeletionProtection:      dbInstance.DeletionProtection,
+		MultiAZ:                 dbInstance.MultiAZ,
+
+		DbiResourceId:             aws.StringValue(dbInstance.DbiResourceId),
+		IAMDatabaseAuthentication: dbInstance.IAMDatabaseAuthenticationEnabled,
+
+		PreferredBackupWindow:      aws.StringValue(dbInstance.PreferredBackupWindow),
+		PreferredMaintenanceWindow: aws.StringValue(dbInstance.PreferredMaintenanceWindow),
 	}
 
 	if dbInstance.Endpoint != nil {
@@ -252,9 +936,26 @@ func (r *RDSDBInstance) buildDBInstance(dbInstance *rds.DBInstance) DBInstanceDe
 		dbInstanceDetails.Port = aws.Int64Value(dbInstance.Endpoint.Port)
 	}
 
+	for _, dbParameterGroup := range dbInstance.DBParameterGroups {
+		dbInstanceDetails.DBParameterGroupName = aws.StringValue(dbParameterGroup.DBParameterGroupName)
+		if aws.StringValue(dbParameterGroup.ParameterApplyStatus) == "pending-reboot" {
+			dbInstanceDetails.PendingReboot = true
+		}
+	}
+
+	// A DB instance moving to another option group is a member of both until
+	// the change is applied
+	for _, optionGroupMembership := range dbInstance.OptionGroupMemberships {
+		if aws.StringValue(optionGroupMembership.Status) == "in-sync" {
+			dbInstanceDetails.OptionGroupName = aws.StringValue(optionGroupMembership.OptionGroupName)
+		} else {
+			dbInstanceDetails.PendingModifications = true
+		}
+	}
+
 	if dbInstance.PendingModifiedValues != nil {
 		emptyPendingModifiedValues := &rds.PendingModifiedValues{}
-		if *dbInstance.PendingModifiedValues != *emptyPendingModifiedValues {
+		if !reflect.DeepEqual(*dbInstance.PendingModifiedValues, *emptyPendingModifiedValues) {
 			dbInstanceDetails.PendingModifications = true
 		}
 	}
@@ -262,6 +963,58 @@ func (r *RDSDBInstance) buildDBInstance(dbInstance *rds.DBInstance) DBInstanceDe
 	return dbInstanceDetails
 }
 
+// describeSnapshots only lists the tags of the snapshots whose DB instance
+// identifier starts with dbInstanceIdentifierPrefix, as it takes a call per
+// snapshot.
+func (r *RDSDBInstance) describeSnapshots(describeDBSnapshotsInput *rds.DescribeDBSnapshotsInput, dbInstanceIdentifierPrefix string) ([]*DBSnapshotDetails, error) {
+	dbSnapshotDetails := []*DBSnapshotDetails{}
+
+	var dbSnapshots []*rds.DBSnapshot
+	err := r.rdssvc.DescribeDBSnapshotsPages(describeDBSnapshotsInput, func(page *rds.DescribeDBSnapshotsOutput, lastPage bool) bool {
+		dbSnapshots = append(dbSnapshots, page.DBSnapshots...)
+		return true
+	})
+	if err != nil {
+		r.logger.Error("aws-rds-error", err)
+		if awsErr, ok := err.(awserr.Error); ok {
+			return dbSnapshotDetails, errors.New(awsErr.Code() + ": " + awsErr.Message())
+		}
+		return dbSnapshotDetails, err
+	}
+
+	for _, dbSnapshot := range dbSnapshots {
+		if !strings.HasPrefix(aws.StringValue(dbSnapshot.DBInstanceIdentifier), dbInstanceIdentifierPrefix) {
+			continue
+		}
+
+		listTagsForResourceInput := &rds.ListTagsForResourceInput{
+			ResourceName: dbSnapshot.DBSnapshotArn,
+		}
+		listTagsForResourceOutput, err := r.rdssvc.ListTagsForResource(listTagsForResourceInput)
+		if err != nil {
+			return dbSnapshotDetails, err
+		}
+
+		d := r.buildDBSnapshot(dbSnapshot)
+		d.Tags = RDSTagsValues(listTagsForResourceOutput.TagList)
+		// The final snapshots taken before they were tagged are named
+		// <id>-final-snapshot, without a timestamp
+		d.FinalSnapshot = d.Tags[finalSnapshotTag] == d.Identifier || strings.HasSuffix(d.Identifier, finalSnapshotSuffix)
+		dbSnapshotDetails = append(dbSnapshotDetails, &d)
+	}
+
+	return dbSnapshotDetails, nil
+}
+
+func (r *RDSDBInstance) buildDBSnapshot(dbSnapshot *rds.DBSnapshot) DBSnapshotDetails {
+	return DBSnapshotDetails{
+		Identifier:           aws.StringValue(dbSnapshot.DBSnapshotIdentifier),
+		DBInstanceIdentifier: aws.StringValue(dbSnapshot.DBInstanceIdentifier),
+		Status:               aws.StringValue(dbSnapshot.Status),
+		CreateTime:           aws.TimeValue(dbSnapshot.SnapshotCreateTime),
+	}
+}
+
 func (r *RDSDBInstance) buildCreateDBInstanceInput(ID string, dbInstanceDetails DBInstanceDetails) *rds.CreateDBInstanceInput {
 	createDBInstanceInput := &rds.CreateDBInstanceInput{
 		DBInstanceIdentifier: aws.String(ID),
@@ -272,7 +1025,7 @@ func (r *RDSDBInstance) buildCreateDBInstanceInput(ID string, dbInstanceDetails
 		createDBInstanceInput.AllocatedStorage = aws.Int64(dbInstanceDetails.AllocatedStorage)
 	}
 
-	createDBInstanceInput.AutoMinorVersionUpgrade = aws.Bool(dbInstanceDetails.AutoMinorVersionUpgrade)
+	createDBInstanceInput.AutoMinorVersionUpgrade = aws.Bool(aws.BoolValue(dbInstanceDetails.AutoMinorVersionUpgrade))
 
 	if dbInstanceDetails.AvailabilityZone != "" {
 		createDBInstanceInput.AvailabilityZone = aws.String(dbInstanceDetails.AvailabilityZone)
@@ -284,7 +1037,7 @@ func (r *RDSDBInstance) buildCreateDBInstanceInput(ID string, dbInstanceDetails
 		createDBInstanceInput.CharacterSetName = aws.String(dbInstanceDetails.CharacterSetName)
 	}
 
-	createDBInstanceInput.CopyTagsToSnapshot = aws.Bool(dbInstanceDetails.CopyTagsToSnapshot)
+	createDBInstanceInput.CopyTagsToSnapshot = aws.Bool(aws.BoolValue(dbInstanceDetails.CopyTagsToSnapshot))
 
 	if dbInstanceDetails.DBInstanceClass != "" {
 		createDBInstanceInput.DBInstanceClass = aws.String(dbInstanceDetails.DBInstanceClass)
@@ -306,6 +1059,12 @@ func (r *RDSDBInstance) buildCreateDBInstanceInput(ID string, dbInstanceDetails
 		createDBInstanceInput.DBSubnetGroupName = aws.String(dbInstanceDetails.DBSubnetGroupName)
 	}
 
+	createDBInstanceInput.DeletionProtection = aws.Bool(aws.BoolValue(dbInstanceDetails.DeletionProtection))
+
+	if aws.BoolValue(dbInstanceDetails.IAMDatabaseAuthentication) {
+		createDBInstanceInput.EnableIAMDatabaseAuthentication = aws.Bool(true)
+	}
+
 	if dbInstanceDetails.EngineVersion != "" {
 		createDBInstanceInput.EngineVersion = aws.String(dbInstanceDetails.EngineVersion)
 	}
@@ -326,7 +1085,7 @@ func (r *RDSDBInstance) buildCreateDBInstanceInput(ID string, dbInstanceDetails
 		createDBInstanceInput.MasterUserPassword = aws.String(dbInstanceDetails.MasterUserPassword)
 	}
 
-	createDBInstanceInput.MultiAZ = aws.Bool(dbInstanceDetails.MultiAZ)
+	createDBInstanceInput.MultiAZ = aws.Bool(aws.BoolValue(dbInstanceDetails.MultiAZ))
 
 	if dbInstanceDetails.OptionGroupName != "" {
 		createDBInstanceInput.OptionGroupName = aws.String(dbInstanceDetails.OptionGroupName)
@@ -381,13 +1140,17 @@ func (r *RDSDBInstance) buildModifyDBInstanceInput(ID string, dbInstanceDetails
 		}
 	}
 
-	modifyDBInstanceInput.AutoMinorVersionUpgrade = aws.Bool(dbInstanceDetails.AutoMinorVersionUpgrade)
+	if dbInstanceDetails.AutoMinorVersionUpgrade != nil {
+		modifyDBInstanceInput.AutoMinorVersionUpgrade = dbInstanceDetails.AutoMinorVersionUpgrade
+	}
 
 	if dbInstanceDetails.BackupRetentionPeriod > 0 {
 		modifyDBInstanceInput.BackupRetentionPeriod = aws.Int64(dbInstanceDetails.BackupRetentionPeriod)
 	}
 
-	modifyDBInstanceInput.CopyTagsToSnapshot = aws.Bool(dbInstanceDetails.CopyTagsToSnapshot)
+	if dbInstanceDetails.CopyTagsToSnapshot != nil {
+		modifyDBInstanceInput.CopyTagsToSnapshot = dbInstanceDetails.CopyTagsToSnapshot
+	}
 
 	if dbInstanceDetails.DBInstanceClass != "" {
 		modifyDBInstanceInput.DBInstanceClass = aws.String(dbInstanceDetails.DBInstanceClass)
@@ -401,6 +1164,14 @@ func (r *RDSDBInstance) buildModifyDBInstanceInput(ID string, dbInstanceDetails
 		modifyDBInstanceInput.DBSecurityGroups = aws.StringSlice(dbInstanceDetails.DBSecurityGroups)
 	}
 
+	if dbInstanceDetails.DeletionProtection != nil {
+		modifyDBInstanceInput.DeletionProtection = dbInstanceDetails.DeletionProtection
+	}
+
+	if dbInstanceDetails.IAMDatabaseAuthentication != nil {
+		modifyDBInstanceInput.EnableIAMDatabaseAuthentication = dbInstanceDetails.IAMDatabaseAuthentication
+	}
+
 	if dbInstanceDetails.EngineVersion != "" && dbInstanceDetails.EngineVersion != oldDBInstanceDetails.EngineVersion {
 		modifyDBInstanceInput.EngineVersion = aws.String(dbInstanceDetails.EngineVersion)
 		modifyDBInstanceInput.AllowMajorVersionUpgrade = aws.Bool(r.allowMajorVersionUpgrade(dbInstanceDetails.EngineVersion, oldDBInstanceDetails.EngineVersion))
@@ -410,7 +1181,9 @@ func (r *RDSDBInstance) buildModifyDBInstanceInput(ID string, dbInstanceDetails
 		modifyDBInstanceInput.MasterUserPassword = aws.String(dbInstanceDetails.MasterUserPassword)
 	}
 
-	modifyDBInstanceInput.MultiAZ = aws.Bool(dbInstanceDetails.MultiAZ)
+	if dbInstanceDetails.MultiAZ != nil {
+		modifyDBInstanceInput.MultiAZ = dbInstanceDetails.MultiAZ
+	}
 
 	if dbInstanceDetails.OptionGroupName != "" {
 		modifyDBInstanceInput.OptionGroupName = aws.String(dbInstanceDetails.OptionGroupName)
@@ -439,21 +1212,23 @@ func (r *RDSDBInstance) buildModifyDBInstanceInput(ID string, dbInstanceDetails
 	return modifyDBInstanceInput
 }
 
-func (r *RDSDBInstance) buildDeleteDBInstanceInput(ID string, skipFinalSnapshot bool) *rds.DeleteDBInstanceInput {
+func (r *RDSDBInstance) buildDeleteDBInstanceInput(ID string, finalSnapshotID string) *rds.DeleteDBInstanceInput {
 	deleteDBInstanceInput := &rds.DeleteDBInstanceInput{
 		DBInstanceIdentifier: aws.String(ID),
-		SkipFinalSnapshot:    aws.Bool(skipFinalSnapshot),
+		SkipFinalSnapshot:    aws.Bool(finalSnapshotID == ""),
 	}
 
-	if !skipFinalSnapshot {
-		deleteDBInstanceInput.FinalDBSnapshotIdentifier = aws.String(r.dbSnapshotName(ID))
+	if finalSnapshotID != "" {
+		deleteDBInstanceInput.FinalDBSnapshotIdentifier = aws.String(finalSnapshotID)
 	}
 
 	return deleteDBInstanceInput
 }
 
+// dbSnapshotName returns a new final snapshot name, made unique with a
+// timestamp so that it never collides with the snapshot of a previous attempt.
 func (r *RDSDBInstance) dbSnapshotName(ID string) string {
-	return fmt.Sprintf("%s-final-snapshot", ID)
+	return fmt.Sprintf("%s%s-%s", ID, finalSnapshotSuffix, time.Now().UTC().Format(snapshotTimestampFormat))
 }
 
 func (r *RDSDBInstance) dbInstanceARN(ID string) (string, error) {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -136,6 +137,89 @@ var _ = Describe("RDS DB Instance", func() {
 			})
 		})
 
+		Context("when RDS DB Instance has IAM database authentication", func() {
+			BeforeEach(func() {
+				describeDBInstance.DbiResourceId = aws.String("db-ABCDEFGHIJKL")
+				describeDBInstance.IAMDatabaseAuthenticationEnabled = aws.Bool(true)
+				properDBInstanceDetails.DbiResourceId = "db-ABCDEFGHIJKL"
+				properDBInstanceDetails.IAMDatabaseAuthentication = aws.Bool(true)
+			})
+
+			It("returns the proper DB Instance", func() {
+				dbInstanceDetails, err := rdsDBInstance.Describe(dbInstanceIdentifier)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstanceDetails).To(Equal(properDBInstanceDetails))
+			})
+		})
+
+		Context("when RDS DB Instance has deletion protection", func() {
+			BeforeEach(func() {
+				describeDBInstance.DeletionProtection = aws.Bool(true)
+				properDBInstanceDetails.DeletionProtection = aws.Bool(true)
+			})
+
+			It("returns the proper DB Instance", func() {
+				dbInstanceDetails, err := rdsDBInstance.Describe(dbInstanceIdentifier)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstanceDetails).To(Equal(properDBInstanceDetails))
+			})
+		})
+
+		Context("when the DB parameter group of the RDS DB Instance is pending a reboot", func() {
+			BeforeEach(func() {
+				describeDBInstance.DBParameterGroups = []*rds.DBParameterGroupStatus{
+					&rds.DBParameterGroupStatus{
+						DBParameterGroupName: aws.String("cf-instance-id"),
+						ParameterApplyStatus: aws.String("pending-reboot"),
+					},
+				}
+				properDBInstanceDetails.DBParameterGroupName = "cf-instance-id"
+				properDBInstanceDetails.PendingReboot = true
+			})
+
+			It("returns the proper DB Instance", func() {
+				dbInstanceDetails, err := rdsDBInstance.Describe(dbInstanceIdentifier)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstanceDetails).To(Equal(properDBInstanceDetails))
+			})
+		})
+
+		Context("when the RDS DB Instance is a member of an option group", func() {
+			BeforeEach(func() {
+				describeDBInstance.OptionGroupMemberships = []*rds.OptionGroupMembership{
+					&rds.OptionGroupMembership{
+						OptionGroupName: aws.String("cf-instance-id"),
+						Status:          aws.String("in-sync"),
+					},
+				}
+				properDBInstanceDetails.OptionGroupName = "cf-instance-id"
+			})
+
+			It("returns the proper DB Instance", func() {
+				dbInstanceDetails, err := rdsDBInstance.Describe(dbInstanceIdentifier)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstanceDetails).To(Equal(properDBInstanceDetails))
+			})
+
+			Context("and is moving to another option group", func() {
+				BeforeEach(func() {
+					describeDBInstance.OptionGroupMemberships[0].Status = aws.String("pending-removal")
+					describeDBInstance.OptionGroupMemberships = append(describeDBInstance.OptionGroupMemberships, &rds.OptionGroupMembership{
+						OptionGroupName: aws.String("plan-option-group"),
+						Status:          aws.String("pending-apply"),
+					})
+					properDBInstanceDetails.OptionGroupName = ""
+					properDBInstanceDetails.PendingModifications = true
+				})
+
+				It("reports pending modifications", func() {
+					dbInstanceDetails, err := rdsDBInstance.Describe(dbInstanceIdentifier)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstanceDetails).To(Equal(properDBInstanceDetails))
+				})
+			})
+		})
+
 		Context("when RDS DB Instance has pending modifications", func() {
 			BeforeEach(func() {
 				describeDBInstance.PendingModifiedValues = &rds.PendingModifiedValues{
@@ -205,8 +289,6 @@ var _ = Describe("RDS DB Instance", func() {
 
 	var _ = Describe("GetTag", func() {
 		var (
-			properDBInstanceDetails DBInstanceDetails
-
 			describeDBInstances []*rds.DBInstance
 			describeDBInstance  *rds.DBInstance
 
@@ -216,19 +298,6 @@ var _ = Describe("RDS DB Instance", func() {
 		)
 
 		BeforeEach(func() {
-			properDBInstanceDetails = DBInstanceDetails{
-				Identifier:       dbInstanceIdentifier,
-				Status:           "available",
-				Engine:           "test-engine",
-				EngineVersion:    "1.2.3",
-				DBName:           "test-dbname",
-				MasterUsername:   "test-master-username",
-				AllocatedStorage: int64(100),
-				Tags: map[string]string{
-					"SkipFinalSnapshot": "true",
-				},
-			}
-
 			describeDBInstance = &rds.DBInstance{
 				DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
 				DBInstanceStatus:     aws.String("available"),
@@ -298,6 +367,12 @@ var _ = Describe("RDS DB Instance", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(tagValue).To(Equal(expectedTag))
 		})
+
+		It("returns all the Tags", func() {
+			tags, err := rdsDBInstance.GetTags(dbInstanceIdentifier)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tags).To(Equal(map[string]string{"SkipFinalSnapshot": "true"}))
+		})
 	})
 
 	var _ = Describe("DescribeByTag", func() {
@@ -407,6 +482,7 @@ var _ = Describe("RDS DB Instance", func() {
 			dbInstanceDetailsList, err := rdsDBInstance.DescribeByTag("Broker Name", "mybroker")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(dbInstanceDetailsList).To(HaveLen(2))
+			Expect(dbInstanceDetailsList).To(Equal(expectedDBInstanceDetails))
 		})
 	})
 
@@ -428,6 +504,7 @@ var _ = Describe("RDS DB Instance", func() {
 				Engine:                  aws.String("test-engine"),
 				AutoMinorVersionUpgrade: aws.Bool(false),
 				CopyTagsToSnapshot:      aws.Bool(false),
+				DeletionProtection:      aws.Bool(false),
 				MultiAZ:                 aws.Bool(false),
 				PubliclyAccessible:      aws.Bool(false),
 				StorageEncrypted:        aws.Bool(false),
@@ -467,7 +544,7 @@ var _ = Describe("RDS DB Instance", func() {
 
 		Context("when has AutoMinorVersionUpgrade", func() {
 			BeforeEach(func() {
-				dbInstanceDetails.AutoMinorVersionUpgrade = true
+				dbInstanceDetails.AutoMinorVersionUpgrade = aws.Bool(true)
 				createDBInstanceInput.AutoMinorVersionUpgrade = aws.Bool(true)
 			})
 
@@ -515,7 +592,7 @@ var _ = Describe("RDS DB Instance", func() {
 
 		Context("when has CopyTagsToSnapshot", func() {
 			BeforeEach(func() {
-				dbInstanceDetails.CopyTagsToSnapshot = true
+				dbInstanceDetails.CopyTagsToSnapshot = aws.Bool(true)
 				createDBInstanceInput.CopyTagsToSnapshot = aws.Bool(true)
 			})
 
@@ -585,6 +662,30 @@ var _ = Describe("RDS DB Instance", func() {
 			})
 		})
 
+		Context("when has DeletionProtection", func() {
+			BeforeEach(func() {
+				dbInstanceDetails.DeletionProtection = aws.Bool(true)
+				createDBInstanceInput.DeletionProtection = aws.Bool(true)
+			})
+
+			It("does not return error", func() {
+				err := rdsDBInstance.Create(dbInstanceIdentifier, dbInstanceDetails)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when has IAMDatabaseAuthentication", func() {
+			BeforeEach(func() {
+				dbInstanceDetails.IAMDatabaseAuthentication = aws.Bool(true)
+				createDBInstanceInput.EnableIAMDatabaseAuthentication = aws.Bool(true)
+			})
+
+			It("does not return error", func() {
+				err := rdsDBInstance.Create(dbInstanceIdentifier, dbInstanceDetails)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
 		Context("when has EngineVersion", func() {
 			BeforeEach(func() {
 				dbInstanceDetails.EngineVersion = "1.2.3"
@@ -647,7 +748,7 @@ var _ = Describe("RDS DB Instance", func() {
 
 		Context("when has MultiAZ", func() {
 			BeforeEach(func() {
-				dbInstanceDetails.MultiAZ = true
+				dbInstanceDetails.MultiAZ = aws.Bool(true)
 				createDBInstanceInput.MultiAZ = aws.Bool(true)
 			})
 
@@ -847,11 +948,8 @@ var _ = Describe("RDS DB Instance", func() {
 			describeDBInstanceError = nil
 
 			modifyDBInstanceInput = &rds.ModifyDBInstanceInput{
-				DBInstanceIdentifier:    aws.String(dbInstanceIdentifier),
-				ApplyImmediately:        aws.Bool(applyImmediately),
-				AutoMinorVersionUpgrade: aws.Bool(false),
-				CopyTagsToSnapshot:      aws.Bool(false),
-				MultiAZ:                 aws.Bool(false),
+				DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+				ApplyImmediately:     aws.Bool(applyImmediately),
 			}
 			modifyDBInstanceError = nil
 
@@ -960,7 +1058,7 @@ var _ = Describe("RDS DB Instance", func() {
 
 		Context("when has AutoMinorVersionUpgrade", func() {
 			BeforeEach(func() {
-				dbInstanceDetails.AutoMinorVersionUpgrade = true
+				dbInstanceDetails.AutoMinorVersionUpgrade = aws.Bool(true)
 				modifyDBInstanceInput.AutoMinorVersionUpgrade = aws.Bool(true)
 			})
 
@@ -984,7 +1082,7 @@ var _ = Describe("RDS DB Instance", func() {
 
 		Context("when has CopyTagsToSnapshot", func() {
 			BeforeEach(func() {
-				dbInstanceDetails.CopyTagsToSnapshot = true
+				dbInstanceDetails.CopyTagsToSnapshot = aws.Bool(true)
 				modifyDBInstanceInput.CopyTagsToSnapshot = aws.Bool(true)
 			})
 
@@ -1030,6 +1128,42 @@ var _ = Describe("RDS DB Instance", func() {
 			})
 		})
 
+		Context("when has DeletionProtection", func() {
+			BeforeEach(func() {
+				dbInstanceDetails.DeletionProtection = aws.Bool(true)
+				modifyDBInstanceInput.DeletionProtection = aws.Bool(true)
+			})
+
+			It("does not return error", func() {
+				err := rdsDBInstance.Modify(dbInstanceIdentifier, dbInstanceDetails, applyImmediately)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when DeletionProtection is turned off", func() {
+			BeforeEach(func() {
+				dbInstanceDetails.DeletionProtection = aws.Bool(false)
+				modifyDBInstanceInput.DeletionProtection = aws.Bool(false)
+			})
+
+			It("does not return error", func() {
+				err := rdsDBInstance.Modify(dbInstanceIdentifier, dbInstanceDetails, applyImmediately)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when has IAMDatabaseAuthentication", func() {
+			BeforeEach(func() {
+				dbInstanceDetails.IAMDatabaseAuthentication = aws.Bool(true)
+				modifyDBInstanceInput.EnableIAMDatabaseAuthentication = aws.Bool(true)
+			})
+
+			It("does not return error", func() {
+				err := rdsDBInstance.Modify(dbInstanceIdentifier, dbInstanceDetails, applyImmediately)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
 		Context("when has EngineVersion", func() {
 			BeforeEach(func() {
 				dbInstanceDetails.EngineVersion = "1.2.4"
@@ -1058,7 +1192,7 @@ var _ = Describe("RDS DB Instance", func() {
 
 		Context("when has MultiAZ", func() {
 			BeforeEach(func() {
-				dbInstanceDetails.MultiAZ = true
+				dbInstanceDetails.MultiAZ = aws.Bool(true)
 				modifyDBInstanceInput.MultiAZ = aws.Bool(true)
 			})
 
@@ -1211,16 +1345,39 @@ var _ = Describe("RDS DB Instance", func() {
 	})
 
 	var _ = Describe("Delete", func() {
+		const account = "123456789012"
+
 		var (
 			skipFinalSnapshot         bool
 			finalDBSnapshotIdentifier string
 
+			describeDBInstance      *rds.DBInstance
+			describeDBInstanceError error
+
+			finalDBSnapshotIdentifierSent string
+
+			modifyDBInstanceInput *rds.ModifyDBInstanceInput
+			modifyDBInstanceError error
+
+			addTagsToResourceInput *rds.AddTagsToResourceInput
+			addTagsToResourceError error
+
 			deleteDBInstanceError error
 		)
 
 		BeforeEach(func() {
 			skipFinalSnapshot = true
 			finalDBSnapshotIdentifier = ""
+			finalDBSnapshotIdentifierSent = ""
+			addTagsToResourceInput = nil
+			addTagsToResourceError = nil
+			describeDBInstance = &rds.DBInstance{
+				DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+				DBInstanceStatus:     aws.String("available"),
+			}
+			describeDBInstanceError = nil
+			modifyDBInstanceInput = nil
+			modifyDBInstanceError = nil
 			deleteDBInstanceError = nil
 		})
 
@@ -1228,24 +1385,89 @@ var _ = Describe("RDS DB Instance", func() {
 			rdssvc.Handlers.Clear()
 
 			rdsCall = func(r *request.Request) {
-				Expect(r.Operation.Name).To(Equal("DeleteDBInstance"))
-				Expect(r.Params).To(BeAssignableToTypeOf(&rds.DeleteDBInstanceInput{}))
-				params := r.Params.(*rds.DeleteDBInstanceInput)
-				Expect(params.DBInstanceIdentifier).To(Equal(aws.String(dbInstanceIdentifier)))
-				if finalDBSnapshotIdentifier != "" {
-					Expect(*params.FinalDBSnapshotIdentifier).To(ContainSubstring(finalDBSnapshotIdentifier))
-				} else {
-					Expect(params.FinalDBSnapshotIdentifier).To(BeNil())
+				switch r.Operation.Name {
+				case "DescribeDBInstances":
+					data := r.Data.(*rds.DescribeDBInstancesOutput)
+					data.DBInstances = []*rds.DBInstance{describeDBInstance}
+					r.Error = describeDBInstanceError
+				case "ModifyDBInstance":
+					modifyDBInstanceInput = r.Params.(*rds.ModifyDBInstanceInput)
+					r.Error = modifyDBInstanceError
+				case "AddTagsToResource":
+					addTagsToResourceInput = r.Params.(*rds.AddTagsToResourceInput)
+					r.Error = addTagsToResourceError
+				case "DeleteDBInstance":
+					Expect(r.Params).To(BeAssignableToTypeOf(&rds.DeleteDBInstanceInput{}))
+					params := r.Params.(*rds.DeleteDBInstanceInput)
+					Expect(params.DBInstanceIdentifier).To(Equal(aws.String(dbInstanceIdentifier)))
+					if finalDBSnapshotIdentifier != "" {
+						Expect(*params.FinalDBSnapshotIdentifier).To(ContainSubstring(finalDBSnapshotIdentifier))
+						finalDBSnapshotIdentifierSent = *params.FinalDBSnapshotIdentifier
+					} else {
+						Expect(params.FinalDBSnapshotIdentifier).To(BeNil())
+					}
+					Expect(params.SkipFinalSnapshot).To(Equal(aws.Bool(skipFinalSnapshot)))
+					r.Error = deleteDBInstanceError
+				default:
+					Fail(fmt.Sprintf("Unexpected call to AWS RDS API: '%s'", r.Operation.Name))
 				}
-				Expect(params.SkipFinalSnapshot).To(Equal(aws.Bool(skipFinalSnapshot)))
-				r.Error = deleteDBInstanceError
 			}
 			rdssvc.Handlers.Send.PushBack(rdsCall)
+
+			stssvc.Handlers.Clear()
+
+			stsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("GetCallerIdentity"))
+				data := r.Data.(*sts.GetCallerIdentityOutput)
+				data.Account = aws.String(account)
+			}
+			stssvc.Handlers.Send.PushBack(stsCall)
 		})
 
 		It("does not return error", func() {
 			err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(modifyDBInstanceInput).To(BeNil())
+			Expect(addTagsToResourceInput).To(BeNil())
+		})
+
+		Context("when the DB instance has deletion protection", func() {
+			BeforeEach(func() {
+				describeDBInstance.DeletionProtection = aws.Bool(true)
+			})
+
+			It("disables the deletion protection before deleting it", func() {
+				err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(modifyDBInstanceInput).To(Equal(&rds.ModifyDBInstanceInput{
+					DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+					DeletionProtection:   aws.Bool(false),
+					ApplyImmediately:     aws.Bool(true),
+				}))
+			})
+
+			Context("and disabling the deletion protection fails", func() {
+				BeforeEach(func() {
+					modifyDBInstanceError = awserr.New("code", "message", errors.New("operation failed"))
+				})
+
+				It("returns the proper error", func() {
+					err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
+					Expect(err).To(MatchError("code: message"))
+				})
+			})
+		})
+
+		Context("when the DB instance does not exist", func() {
+			BeforeEach(func() {
+				awsError := awserr.New("code", "message", errors.New("operation failed"))
+				describeDBInstanceError = awserr.NewRequestFailure(awsError, 404, "request-id")
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
+				Expect(err).To(Equal(ErrDBInstanceDoesNotExist))
+			})
 		})
 
 		Context("when does not skip the final snapshot", func() {
@@ -1258,6 +1480,57 @@ var _ = Describe("RDS DB Instance", func() {
 				err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
 				Expect(err).ToNot(HaveOccurred())
 			})
+
+			It("uses a unique final snapshot name", func() {
+				err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(finalDBSnapshotIdentifierSent).To(MatchRegexp("^" + dbInstanceIdentifier + `-final-snapshot-\d{8}-\d{6}$`))
+			})
+
+			It("tags the DB instance with the final snapshot name", func() {
+				err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(addTagsToResourceInput).To(Equal(&rds.AddTagsToResourceInput{
+					ResourceName: aws.String("arn:" + partition + ":rds:rds-region:" + account + ":db:" + dbInstanceIdentifier),
+					Tags: []*rds.Tag{
+						&rds.Tag{Key: aws.String("Final Snapshot"), Value: aws.String(finalDBSnapshotIdentifierSent)},
+					},
+				}))
+			})
+
+			Context("and tagging the DB instance fails", func() {
+				BeforeEach(func() {
+					addTagsToResourceError = awserr.New("code", "message", errors.New("operation failed"))
+				})
+
+				It("does not delete the DB instance", func() {
+					err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
+					Expect(err).To(MatchError("code: message"))
+					Expect(finalDBSnapshotIdentifierSent).To(BeEmpty())
+				})
+			})
+
+			It("copies the tags of the DB instance to the final snapshot", func() {
+				err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(modifyDBInstanceInput).To(Equal(&rds.ModifyDBInstanceInput{
+					DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+					CopyTagsToSnapshot:   aws.Bool(true),
+					ApplyImmediately:     aws.Bool(true),
+				}))
+			})
+
+			Context("and the DB instance already copies its tags to snapshots", func() {
+				BeforeEach(func() {
+					describeDBInstance.CopyTagsToSnapshot = aws.Bool(true)
+				})
+
+				It("does not modify the DB instance", func() {
+					err := rdsDBInstance.Delete(dbInstanceIdentifier, skipFinalSnapshot)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(modifyDBInstanceInput).To(BeNil())
+				})
+			})
 		})
 
 		Context("when deleting the DB instance fails", func() {
@@ -1297,4 +1570,1013 @@ var _ = Describe("RDS DB Instance", func() {
 			})
 		})
 	})
+
+	var _ = Describe("Stop", func() {
+		var stopDBInstanceError error
+
+		BeforeEach(func() {
+			stopDBInstanceError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("StopDBInstance"))
+				Expect(r.Params).To(Equal(&rds.StopDBInstanceInput{
+					DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+				}))
+				r.Error = stopDBInstanceError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("does not return error", func() {
+			err := rdsDBInstance.Stop(dbInstanceIdentifier)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when stopping the DB instance fails", func() {
+			BeforeEach(func() {
+				stopDBInstanceError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.Stop(dbInstanceIdentifier)
+				Expect(err).To(MatchError("code: message"))
+			})
+
+			Context("and it is a 404 error", func() {
+				BeforeEach(func() {
+					stopDBInstanceError = awserr.NewRequestFailure(stopDBInstanceError.(awserr.Error), 404, "request-id")
+				})
+
+				It("returns the proper error", func() {
+					err := rdsDBInstance.Stop(dbInstanceIdentifier)
+					Expect(err).To(Equal(ErrDBInstanceDoesNotExist))
+				})
+			})
+		})
+	})
+
+	var _ = Describe("Start", func() {
+		var startDBInstanceError error
+
+		BeforeEach(func() {
+			startDBInstanceError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("StartDBInstance"))
+				Expect(r.Params).To(Equal(&rds.StartDBInstanceInput{
+					DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+				}))
+				r.Error = startDBInstanceError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("does not return error", func() {
+			err := rdsDBInstance.Start(dbInstanceIdentifier)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when starting the DB instance fails", func() {
+			BeforeEach(func() {
+				startDBInstanceError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.Start(dbInstanceIdentifier)
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("AddTags and RemoveTags", func() {
+		const account = "123456789012"
+
+		var (
+			dbInstanceARN string
+
+			addTagsToResourceInput      *rds.AddTagsToResourceInput
+			removeTagsFromResourceInput *rds.RemoveTagsFromResourceInput
+			rdsError                    error
+			getCallerIdentityError      error
+		)
+
+		BeforeEach(func() {
+			dbInstanceARN = "arn:" + partition + ":rds:rds-region:" + account + ":db:" + dbInstanceIdentifier
+			addTagsToResourceInput = nil
+			removeTagsFromResourceInput = nil
+			rdsError = nil
+			getCallerIdentityError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+			rdsCall = func(r *request.Request) {
+				switch r.Operation.Name {
+				case "AddTagsToResource":
+					addTagsToResourceInput = r.Params.(*rds.AddTagsToResourceInput)
+				case "RemoveTagsFromResource":
+					removeTagsFromResourceInput = r.Params.(*rds.RemoveTagsFromResourceInput)
+				default:
+					Fail(fmt.Sprintf("Unexpected call to AWS RDS API: '%s'", r.Operation.Name))
+				}
+				r.Error = rdsError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+
+			stssvc.Handlers.Clear()
+			stsCall = func(r *request.Request) {
+				data := r.Data.(*sts.GetCallerIdentityOutput)
+				data.Account = aws.String(account)
+				r.Error = getCallerIdentityError
+			}
+			stssvc.Handlers.Send.PushBack(stsCall)
+		})
+
+		It("adds the tags to the DB instance", func() {
+			err := rdsDBInstance.AddTags(dbInstanceIdentifier, map[string]string{"Owner": "Cloud Foundry"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(addTagsToResourceInput).To(Equal(&rds.AddTagsToResourceInput{
+				ResourceName: aws.String(dbInstanceARN),
+				Tags: []*rds.Tag{
+					&rds.Tag{Key: aws.String("Owner"), Value: aws.String("Cloud Foundry")},
+				},
+			}))
+		})
+
+		It("removes the tags from the DB instance", func() {
+			err := rdsDBInstance.RemoveTags(dbInstanceIdentifier, []string{"Owner"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removeTagsFromResourceInput).To(Equal(&rds.RemoveTagsFromResourceInput{
+				ResourceName: aws.String(dbInstanceARN),
+				TagKeys:      aws.StringSlice([]string{"Owner"}),
+			}))
+		})
+
+		Context("when the RDS call fails", func() {
+			BeforeEach(func() {
+				rdsError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				Expect(rdsDBInstance.AddTags(dbInstanceIdentifier, map[string]string{"Owner": "Cloud Foundry"})).To(MatchError("code: message"))
+				Expect(rdsDBInstance.RemoveTags(dbInstanceIdentifier, []string{"Owner"})).To(MatchError("code: message"))
+			})
+		})
+
+		Context("when getting the user account fails", func() {
+			BeforeEach(func() {
+				getCallerIdentityError = errors.New("operation failed")
+			})
+
+			It("returns the proper error", func() {
+				Expect(rdsDBInstance.AddTags(dbInstanceIdentifier, map[string]string{"Owner": "Cloud Foundry"})).To(MatchError("operation failed"))
+				Expect(rdsDBInstance.RemoveTags(dbInstanceIdentifier, []string{"Owner"})).To(MatchError("operation failed"))
+			})
+		})
+	})
+
+	var _ = Describe("DescribeSnapshots and DescribeInstanceSnapshots", func() {
+		var (
+			describeDBSnapshotsInput *rds.DescribeDBSnapshotsInput
+			describeDBSnapshots      []*rds.DBSnapshot
+			describeDBSnapshotsError error
+			createTime               time.Time
+			tags                     map[string][]*rds.Tag
+			listedTags               []string
+		)
+
+		BeforeEach(func() {
+			createTime = time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+			listedTags = nil
+			describeDBSnapshotsInput = nil
+			describeDBSnapshots = []*rds.DBSnapshot{
+				&rds.DBSnapshot{
+					DBSnapshotIdentifier: aws.String(dbInstanceIdentifier + "-final-snapshot-20160102-030405"),
+					DBSnapshotArn:        aws.String("arn:final"),
+					DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+					Status:               aws.String("available"),
+					SnapshotCreateTime:   aws.Time(createTime),
+				},
+				&rds.DBSnapshot{
+					DBSnapshotIdentifier: aws.String(dbInstanceIdentifier + "-snapshot-20160102-030405"),
+					DBSnapshotArn:        aws.String("arn:manual"),
+					DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+					Status:               aws.String("available"),
+					SnapshotCreateTime:   aws.Time(createTime),
+				},
+				&rds.DBSnapshot{
+					DBSnapshotIdentifier: aws.String("other-snapshot"),
+					DBSnapshotArn:        aws.String("arn:other"),
+					DBInstanceIdentifier: aws.String("other-instance"),
+					Status:               aws.String("available"),
+				},
+			}
+			describeDBSnapshotsError = nil
+			tags = map[string][]*rds.Tag{
+				"arn:final": []*rds.Tag{
+					&rds.Tag{Key: aws.String("Broker Name"), Value: aws.String("mybroker")},
+					&rds.Tag{Key: aws.String("Final Snapshot"), Value: aws.String(dbInstanceIdentifier + "-final-snapshot-20160102-030405")},
+				},
+				// Taken while a deletion was being retried
+				"arn:manual": []*rds.Tag{
+					&rds.Tag{Key: aws.String("Broker Name"), Value: aws.String("mybroker")},
+					&rds.Tag{Key: aws.String("Final Snapshot"), Value: aws.String(dbInstanceIdentifier + "-final-snapshot-20160102-030405")},
+				},
+				"arn:other": []*rds.Tag{
+					&rds.Tag{Key: aws.String("Broker Name"), Value: aws.String("otherbroker")},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				switch r.Operation.Name {
+				case "DescribeDBSnapshots":
+					describeDBSnapshotsInput = r.Params.(*rds.DescribeDBSnapshotsInput)
+					data := r.Data.(*rds.DescribeDBSnapshotsOutput)
+					data.DBSnapshots = describeDBSnapshots
+					r.Error = describeDBSnapshotsError
+				case "ListTagsForResource":
+					params := r.Params.(*rds.ListTagsForResourceInput)
+					listedTags = append(listedTags, aws.StringValue(params.ResourceName))
+					data := r.Data.(*rds.ListTagsForResourceOutput)
+					data.TagList = tags[aws.StringValue(params.ResourceName)]
+				default:
+					Fail(fmt.Sprintf("Unexpected call to AWS RDS API: '%s'", r.Operation.Name))
+				}
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("returns the manual snapshots with the tag of the DB instances with the prefix", func() {
+			dbSnapshotDetails, err := rdsDBInstance.DescribeSnapshots("cf-", "Broker Name", "mybroker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(describeDBSnapshotsInput.SnapshotType).To(Equal(aws.String("manual")))
+			Expect(listedTags).To(Equal([]string{"arn:final", "arn:manual"}))
+			Expect(dbSnapshotDetails).To(HaveLen(2))
+			Expect(dbSnapshotDetails[0].Identifier).To(Equal(dbInstanceIdentifier + "-final-snapshot-20160102-030405"))
+			Expect(dbSnapshotDetails[0].DBInstanceIdentifier).To(Equal(dbInstanceIdentifier))
+			Expect(dbSnapshotDetails[0].Status).To(Equal("available"))
+			Expect(dbSnapshotDetails[0].CreateTime).To(Equal(createTime))
+			Expect(dbSnapshotDetails[0].Tags).To(HaveKeyWithValue("Broker Name", "mybroker"))
+			Expect(dbSnapshotDetails[1].Identifier).To(Equal(dbInstanceIdentifier + "-snapshot-20160102-030405"))
+		})
+
+		It("recognises the final snapshots by their tag", func() {
+			dbSnapshotDetails, err := rdsDBInstance.DescribeSnapshots("cf-", "Broker Name", "mybroker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dbSnapshotDetails).To(HaveLen(2))
+			Expect(dbSnapshotDetails[0].FinalSnapshot).To(BeTrue())
+			Expect(dbSnapshotDetails[1].FinalSnapshot).To(BeFalse())
+		})
+
+		It("recognises the untagged final snapshots by their legacy name", func() {
+			describeDBSnapshots = append(describeDBSnapshots, &rds.DBSnapshot{
+				DBSnapshotIdentifier: aws.String(dbInstanceIdentifier + "-final-snapshot"),
+				DBSnapshotArn:        aws.String("arn:legacy"),
+				DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+				Status:               aws.String("available"),
+				SnapshotCreateTime:   aws.Time(createTime),
+			})
+			tags["arn:legacy"] = []*rds.Tag{
+				&rds.Tag{Key: aws.String("Broker Name"), Value: aws.String("mybroker")},
+			}
+
+			dbSnapshotDetails, err := rdsDBInstance.DescribeSnapshots("cf-", "Broker Name", "mybroker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dbSnapshotDetails).To(HaveLen(3))
+			Expect(dbSnapshotDetails[2].Identifier).To(Equal(dbInstanceIdentifier + "-final-snapshot"))
+			Expect(dbSnapshotDetails[2].FinalSnapshot).To(BeTrue())
+		})
+
+		It("returns the manual snapshots of the DB instance", func() {
+			dbSnapshotDetails, err := rdsDBInstance.DescribeInstanceSnapshots(dbInstanceIdentifier)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(describeDBSnapshotsInput).To(Equal(&rds.DescribeDBSnapshotsInput{
+				DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+				SnapshotType:         aws.String("manual"),
+			}))
+			Expect(dbSnapshotDetails).To(HaveLen(2))
+			Expect(dbSnapshotDetails[0].Tags).To(HaveKeyWithValue("Broker Name", "mybroker"))
+		})
+
+		Context("when describing the snapshots fails", func() {
+			BeforeEach(func() {
+				describeDBSnapshotsError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsDBInstance.DescribeSnapshots("cf-", "Broker Name", "mybroker")
+				Expect(err).To(MatchError("code: message"))
+
+				_, err = rdsDBInstance.DescribeInstanceSnapshots(dbInstanceIdentifier)
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("CreateSnapshot", func() {
+		var (
+			createDBSnapshotInput *rds.CreateDBSnapshotInput
+			createDBSnapshotError error
+		)
+
+		BeforeEach(func() {
+			createDBSnapshotInput = nil
+			createDBSnapshotError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("CreateDBSnapshot"))
+				createDBSnapshotInput = r.Params.(*rds.CreateDBSnapshotInput)
+				r.Error = createDBSnapshotError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("creates a uniquely named and tagged snapshot", func() {
+			snapshotID, err := rdsDBInstance.CreateSnapshot(dbInstanceIdentifier, map[string]string{"Owner": "Cloud Foundry"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshotID).To(MatchRegexp("^" + dbInstanceIdentifier + `-snapshot-\d{8}-\d{6}$`))
+			Expect(createDBSnapshotInput).To(Equal(&rds.CreateDBSnapshotInput{
+				DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+				DBSnapshotIdentifier: aws.String(snapshotID),
+				Tags: []*rds.Tag{
+					&rds.Tag{Key: aws.String("Owner"), Value: aws.String("Cloud Foundry")},
+				},
+			}))
+		})
+
+		Context("when creating the snapshot fails", func() {
+			BeforeEach(func() {
+				createDBSnapshotError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsDBInstance.CreateSnapshot(dbInstanceIdentifier, nil)
+				Expect(err).To(MatchError("code: message"))
+			})
+
+			Context("and it is a 404 error", func() {
+				BeforeEach(func() {
+					createDBSnapshotError = awserr.NewRequestFailure(createDBSnapshotError.(awserr.Error), 404, "request-id")
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsDBInstance.CreateSnapshot(dbInstanceIdentifier, nil)
+					Expect(err).To(Equal(ErrDBInstanceDoesNotExist))
+				})
+			})
+		})
+	})
+
+	var _ = Describe("DeleteSnapshot", func() {
+		var deleteDBSnapshotError error
+
+		BeforeEach(func() {
+			deleteDBSnapshotError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("DeleteDBSnapshot"))
+				Expect(r.Params).To(Equal(&rds.DeleteDBSnapshotInput{
+					DBSnapshotIdentifier: aws.String("snapshot-id"),
+				}))
+				r.Error = deleteDBSnapshotError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("does not return error", func() {
+			Expect(rdsDBInstance.DeleteSnapshot("snapshot-id")).To(Succeed())
+		})
+
+		Context("when deleting the snapshot fails", func() {
+			BeforeEach(func() {
+				deleteDBSnapshotError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				Expect(rdsDBInstance.DeleteSnapshot("snapshot-id")).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("CopySnapshot", func() {
+		var (
+			copyDBSnapshotInput *rds.CopyDBSnapshotInput
+			copyDBSnapshotError error
+		)
+
+		BeforeEach(func() {
+			copyDBSnapshotInput = nil
+			copyDBSnapshotError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("CopyDBSnapshot"))
+				copyDBSnapshotInput = r.Params.(*rds.CopyDBSnapshotInput)
+				r.Error = copyDBSnapshotError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("copies the snapshot with the KMS key and the tags", func() {
+			err := rdsDBInstance.CopySnapshot("snapshot-id", "snapshot-id-copy", "my-kms-key", map[string]string{"Owner": "Cloud Foundry"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(copyDBSnapshotInput).To(Equal(&rds.CopyDBSnapshotInput{
+				SourceDBSnapshotIdentifier: aws.String("snapshot-id"),
+				TargetDBSnapshotIdentifier: aws.String("snapshot-id-copy"),
+				KmsKeyId:                   aws.String("my-kms-key"),
+				Tags: []*rds.Tag{
+					&rds.Tag{Key: aws.String("Owner"), Value: aws.String("Cloud Foundry")},
+				},
+			}))
+		})
+
+		Context("when copying the snapshot fails", func() {
+			BeforeEach(func() {
+				copyDBSnapshotError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.CopySnapshot("snapshot-id", "snapshot-id-copy", "", nil)
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("RestoreFromSnapshot", func() {
+		var (
+			restoreDBInstanceInput *rds.RestoreDBInstanceFromDBSnapshotInput
+			restoreDBInstanceError error
+		)
+
+		BeforeEach(func() {
+			restoreDBInstanceInput = nil
+			restoreDBInstanceError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("RestoreDBInstanceFromDBSnapshot"))
+				restoreDBInstanceInput = r.Params.(*rds.RestoreDBInstanceFromDBSnapshotInput)
+				r.Error = restoreDBInstanceError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("restores the DB instance with the settings accepted on restore", func() {
+			err := rdsDBInstance.RestoreFromSnapshot(dbInstanceIdentifier, "snapshot-id", DBInstanceDetails{
+				DBInstanceClass:     "db.m3.small",
+				DBSubnetGroupName:   "test-subnet-group",
+				MultiAZ:             aws.Bool(true),
+				VpcSecurityGroupIds: []string{"test-security-group"},
+				Tags:                map[string]string{"Owner": "Cloud Foundry"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restoreDBInstanceInput).To(Equal(&rds.RestoreDBInstanceFromDBSnapshotInput{
+				DBInstanceIdentifier:    aws.String(dbInstanceIdentifier),
+				DBSnapshotIdentifier:    aws.String("snapshot-id"),
+				AutoMinorVersionUpgrade: aws.Bool(false),
+				CopyTagsToSnapshot:      aws.Bool(false),
+				DeletionProtection:      aws.Bool(false),
+				MultiAZ:                 aws.Bool(true),
+				PubliclyAccessible:      aws.Bool(false),
+				DBInstanceClass:         aws.String("db.m3.small"),
+
+				EnableIAMDatabaseAuthentication: aws.Bool(false),
+				DBSubnetGroupName:               aws.String("test-subnet-group"),
+				Tags: []*rds.Tag{
+					&rds.Tag{Key: aws.String("Owner"), Value: aws.String("Cloud Foundry")},
+				},
+			}))
+		})
+
+		Context("when restoring the DB instance fails", func() {
+			BeforeEach(func() {
+				restoreDBInstanceError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.RestoreFromSnapshot(dbInstanceIdentifier, "snapshot-id", DBInstanceDetails{})
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("Rename", func() {
+		var renameDBInstanceError error
+
+		BeforeEach(func() {
+			renameDBInstanceError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("ModifyDBInstance"))
+				Expect(r.Params).To(Equal(&rds.ModifyDBInstanceInput{
+					DBInstanceIdentifier:    aws.String(dbInstanceIdentifier),
+					NewDBInstanceIdentifier: aws.String(dbInstanceIdentifier + "-old"),
+					ApplyImmediately:        aws.Bool(true),
+				}))
+				r.Error = renameDBInstanceError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("does not return error", func() {
+			err := rdsDBInstance.Rename(dbInstanceIdentifier, dbInstanceIdentifier+"-old")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when renaming the DB instance fails", func() {
+			BeforeEach(func() {
+				renameDBInstanceError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.Rename(dbInstanceIdentifier, dbInstanceIdentifier+"-old")
+				Expect(err).To(MatchError("code: message"))
+			})
+
+			Context("and it is a 404 error", func() {
+				BeforeEach(func() {
+					renameDBInstanceError = awserr.NewRequestFailure(renameDBInstanceError.(awserr.Error), 404, "request-id")
+				})
+
+				It("returns the proper error", func() {
+					err := rdsDBInstance.Rename(dbInstanceIdentifier, dbInstanceIdentifier+"-old")
+					Expect(err).To(Equal(ErrDBInstanceDoesNotExist))
+				})
+			})
+		})
+	})
+
+	var _ = Describe("CopyParameterGroup", func() {
+		var (
+			copyDBParameterGroupInput *rds.CopyDBParameterGroupInput
+			copyDBParameterGroupError error
+		)
+
+		BeforeEach(func() {
+			copyDBParameterGroupInput = nil
+			copyDBParameterGroupError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("CopyDBParameterGroup"))
+				copyDBParameterGroupInput = r.Params.(*rds.CopyDBParameterGroupInput)
+				r.Error = copyDBParameterGroupError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("copies the DB parameter group with the tags", func() {
+			err := rdsDBInstance.CopyParameterGroup("plan-group", "cf-instance-id", map[string]string{"Owner": "Cloud Foundry"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(copyDBParameterGroupInput).To(Equal(&rds.CopyDBParameterGroupInput{
+				SourceDBParameterGroupIdentifier:  aws.String("plan-group"),
+				TargetDBParameterGroupIdentifier:  aws.String("cf-instance-id"),
+				TargetDBParameterGroupDescription: aws.String("Copy of plan-group for cf-instance-id"),
+				Tags: []*rds.Tag{
+					&rds.Tag{Key: aws.String("Owner"), Value: aws.String("Cloud Foundry")},
+				},
+			}))
+		})
+
+		Context("when the DB parameter group already exists", func() {
+			BeforeEach(func() {
+				copyDBParameterGroupError = awserr.New("DBParameterGroupAlreadyExists", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.CopyParameterGroup("plan-group", "cf-instance-id", nil)
+				Expect(err).To(Equal(ErrDBParameterGroupAlreadyExists))
+			})
+		})
+
+		Context("when copying the DB parameter group fails", func() {
+			BeforeEach(func() {
+				copyDBParameterGroupError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.CopyParameterGroup("plan-group", "cf-instance-id", nil)
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("ModifyParameterGroup", func() {
+		var (
+			modifyDBParameterGroupInputs []*rds.ModifyDBParameterGroupInput
+			modifyDBParameterGroupError  error
+			describeDBParametersError    error
+		)
+
+		BeforeEach(func() {
+			modifyDBParameterGroupInputs = nil
+			modifyDBParameterGroupError = nil
+			describeDBParametersError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				switch r.Operation.Name {
+				case "DescribeDBParameters":
+					Expect(r.Params).To(Equal(&rds.DescribeDBParametersInput{
+						DBParameterGroupName: aws.String("cf-instance-id"),
+					}))
+					data := r.Data.(*rds.DescribeDBParametersOutput)
+					data.Parameters = []*rds.Parameter{
+						&rds.Parameter{ParameterName: aws.String("work_mem"), ApplyType: aws.String("dynamic"), IsModifiable: aws.Bool(true)},
+						&rds.Parameter{ParameterName: aws.String("shared_buffers"), ApplyType: aws.String("static"), IsModifiable: aws.Bool(true)},
+						&rds.Parameter{ParameterName: aws.String("rds.extensions"), ApplyType: aws.String("static"), IsModifiable: aws.Bool(false)},
+					}
+					r.Error = describeDBParametersError
+				case "ModifyDBParameterGroup":
+					modifyDBParameterGroupInputs = append(modifyDBParameterGroupInputs, r.Params.(*rds.ModifyDBParameterGroupInput))
+					r.Error = modifyDBParameterGroupError
+				default:
+					Fail("unexpected operation " + r.Operation.Name)
+				}
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("applies dynamic parameters immediately and static ones on reboot", func() {
+			err := rdsDBInstance.ModifyParameterGroup("cf-instance-id", map[string]string{
+				"work_mem":       "8192",
+				"shared_buffers": "65536",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(modifyDBParameterGroupInputs).To(Equal([]*rds.ModifyDBParameterGroupInput{
+				&rds.ModifyDBParameterGroupInput{
+					DBParameterGroupName: aws.String("cf-instance-id"),
+					Parameters: []*rds.Parameter{
+						&rds.Parameter{ParameterName: aws.String("shared_buffers"), ParameterValue: aws.String("65536"), ApplyMethod: aws.String("pending-reboot")},
+						&rds.Parameter{ParameterName: aws.String("work_mem"), ParameterValue: aws.String("8192"), ApplyMethod: aws.String("immediate")},
+					},
+				},
+			}))
+		})
+
+		It("refuses parameters which cannot be modified", func() {
+			err := rdsDBInstance.ModifyParameterGroup("cf-instance-id", map[string]string{"rds.extensions": "postgis"})
+			Expect(err).To(MatchError("DB parameter 'rds.extensions' does not exist or cannot be modified in DB parameter group 'cf-instance-id'"))
+			Expect(modifyDBParameterGroupInputs).To(BeEmpty())
+		})
+
+		Context("when the DB parameter group does not exist", func() {
+			BeforeEach(func() {
+				describeDBParametersError = awserr.New("DBParameterGroupNotFound", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.ModifyParameterGroup("cf-instance-id", map[string]string{"work_mem": "8192"})
+				Expect(err).To(Equal(ErrDBParameterGroupDoesNotExist))
+			})
+		})
+
+		Context("when modifying the DB parameter group fails", func() {
+			BeforeEach(func() {
+				modifyDBParameterGroupError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.ModifyParameterGroup("cf-instance-id", map[string]string{"work_mem": "8192"})
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("DeleteParameterGroup", func() {
+		var (
+			deleteDBParameterGroupInput *rds.DeleteDBParameterGroupInput
+			deleteDBParameterGroupError error
+		)
+
+		BeforeEach(func() {
+			deleteDBParameterGroupInput = nil
+			deleteDBParameterGroupError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("DeleteDBParameterGroup"))
+				deleteDBParameterGroupInput = r.Params.(*rds.DeleteDBParameterGroupInput)
+				r.Error = deleteDBParameterGroupError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("deletes the DB parameter group", func() {
+			err := rdsDBInstance.DeleteParameterGroup("cf-instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleteDBParameterGroupInput).To(Equal(&rds.DeleteDBParameterGroupInput{
+				DBParameterGroupName: aws.String("cf-instance-id"),
+			}))
+		})
+
+		Context("when the DB parameter group does not exist", func() {
+			BeforeEach(func() {
+				deleteDBParameterGroupError = awserr.New("DBParameterGroupNotFound", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.DeleteParameterGroup("cf-instance-id")
+				Expect(err).To(Equal(ErrDBParameterGroupDoesNotExist))
+			})
+		})
+	})
+
+	var _ = Describe("CreateOptionGroup", func() {
+		var (
+			createOptionGroupInput *rds.CreateOptionGroupInput
+			createOptionGroupError error
+		)
+
+		BeforeEach(func() {
+			createOptionGroupInput = nil
+			createOptionGroupError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("CreateOptionGroup"))
+				createOptionGroupInput = r.Params.(*rds.CreateOptionGroupInput)
+				r.Error = createOptionGroupError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("creates the option group", func() {
+			err := rdsDBInstance.CreateOptionGroup("cf-instance-id", "mysql", "5.7", map[string]string{"Owner": "Cloud Foundry"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(createOptionGroupInput).To(Equal(&rds.CreateOptionGroupInput{
+				OptionGroupName:        aws.String("cf-instance-id"),
+				OptionGroupDescription: aws.String("Options of cf-instance-id"),
+				EngineName:             aws.String("mysql"),
+				MajorEngineVersion:     aws.String("5.7"),
+				Tags: []*rds.Tag{
+					&rds.Tag{Key: aws.String("Owner"), Value: aws.String("Cloud Foundry")},
+				},
+			}))
+		})
+
+		Context("when the option group already exists", func() {
+			BeforeEach(func() {
+				createOptionGroupError = awserr.New("OptionGroupAlreadyExistsFault", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.CreateOptionGroup("cf-instance-id", "mysql", "5.7", nil)
+				Expect(err).To(Equal(ErrOptionGroupAlreadyExists))
+			})
+		})
+	})
+
+	var _ = Describe("CopyOptionGroup", func() {
+		var (
+			copyOptionGroupInput *rds.CopyOptionGroupInput
+			copyOptionGroupError error
+		)
+
+		BeforeEach(func() {
+			copyOptionGroupInput = nil
+			copyOptionGroupError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("CopyOptionGroup"))
+				copyOptionGroupInput = r.Params.(*rds.CopyOptionGroupInput)
+				r.Error = copyOptionGroupError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("copies the option group with the tags", func() {
+			err := rdsDBInstance.CopyOptionGroup("plan-group", "cf-instance-id", map[string]string{"Owner": "Cloud Foundry"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(copyOptionGroupInput).To(Equal(&rds.CopyOptionGroupInput{
+				SourceOptionGroupIdentifier:  aws.String("plan-group"),
+				TargetOptionGroupIdentifier:  aws.String("cf-instance-id"),
+				TargetOptionGroupDescription: aws.String("Copy of plan-group for cf-instance-id"),
+				Tags: []*rds.Tag{
+					&rds.Tag{Key: aws.String("Owner"), Value: aws.String("Cloud Foundry")},
+				},
+			}))
+		})
+
+		Context("when the option group already exists", func() {
+			BeforeEach(func() {
+				copyOptionGroupError = awserr.New("OptionGroupAlreadyExistsFault", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.CopyOptionGroup("plan-group", "cf-instance-id", nil)
+				Expect(err).To(Equal(ErrOptionGroupAlreadyExists))
+			})
+		})
+
+		Context("when copying the option group fails", func() {
+			BeforeEach(func() {
+				copyOptionGroupError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.CopyOptionGroup("plan-group", "cf-instance-id", nil)
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("ModifyOptionGroup", func() {
+		var (
+			modifyOptionGroupInput    *rds.ModifyOptionGroupInput
+			modifyOptionGroupError    error
+			describeOptionGroupsError error
+		)
+
+		BeforeEach(func() {
+			modifyOptionGroupInput = nil
+			modifyOptionGroupError = nil
+			describeOptionGroupsError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				switch r.Operation.Name {
+				case "DescribeOptionGroups":
+					Expect(r.Params).To(Equal(&rds.DescribeOptionGroupsInput{
+						OptionGroupName: aws.String("cf-instance-id"),
+					}))
+					data := r.Data.(*rds.DescribeOptionGroupsOutput)
+					data.OptionGroupsList = []*rds.OptionGroup{
+						&rds.OptionGroup{
+							Options: []*rds.Option{
+								&rds.Option{OptionName: aws.String("MEMCACHED")},
+							},
+						},
+					}
+					r.Error = describeOptionGroupsError
+				case "ModifyOptionGroup":
+					modifyOptionGroupInput = r.Params.(*rds.ModifyOptionGroupInput)
+					r.Error = modifyOptionGroupError
+				default:
+					Fail("unexpected operation " + r.Operation.Name)
+				}
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("includes options and removes the ones in the group", func() {
+			err := rdsDBInstance.ModifyOptionGroup("cf-instance-id", []DBOption{
+				DBOption{
+					Name: "MARIADB_AUDIT_PLUGIN",
+					Settings: map[string]string{
+						"SERVER_AUDIT_EVENTS":  "CONNECT,QUERY",
+						"SERVER_AUDIT_LOGGING": "ON",
+					},
+				},
+			}, []string{"MEMCACHED", "SQLT"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(modifyOptionGroupInput).To(Equal(&rds.ModifyOptionGroupInput{
+				OptionGroupName:  aws.String("cf-instance-id"),
+				ApplyImmediately: aws.Bool(true),
+				OptionsToInclude: []*rds.OptionConfiguration{
+					&rds.OptionConfiguration{
+						OptionName: aws.String("MARIADB_AUDIT_PLUGIN"),
+						OptionSettings: []*rds.OptionSetting{
+							&rds.OptionSetting{Name: aws.String("SERVER_AUDIT_EVENTS"), Value: aws.String("CONNECT,QUERY")},
+							&rds.OptionSetting{Name: aws.String("SERVER_AUDIT_LOGGING"), Value: aws.String("ON")},
+						},
+					},
+				},
+				OptionsToRemove: []*string{aws.String("MEMCACHED")},
+			}))
+		})
+
+		It("sets the port and VPC security groups of options", func() {
+			err := rdsDBInstance.ModifyOptionGroup("cf-instance-id", []DBOption{
+				DBOption{Name: "MEMCACHED", Port: 11211, VpcSecurityGroupIds: []string{"sg-1"}},
+			}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(modifyOptionGroupInput.OptionsToInclude).To(Equal([]*rds.OptionConfiguration{
+				&rds.OptionConfiguration{
+					OptionName:                  aws.String("MEMCACHED"),
+					Port:                        aws.Int64(11211),
+					VpcSecurityGroupMemberships: []*string{aws.String("sg-1")},
+				},
+			}))
+		})
+
+		It("does not modify the option group when there is nothing to change", func() {
+			err := rdsDBInstance.ModifyOptionGroup("cf-instance-id", nil, []string{"SQLT"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(modifyOptionGroupInput).To(BeNil())
+		})
+
+		Context("when the option group does not exist", func() {
+			BeforeEach(func() {
+				describeOptionGroupsError = awserr.New("OptionGroupNotFoundFault", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.ModifyOptionGroup("cf-instance-id", nil, []string{"MEMCACHED"})
+				Expect(err).To(Equal(ErrOptionGroupDoesNotExist))
+			})
+		})
+
+		Context("when modifying the option group fails", func() {
+			BeforeEach(func() {
+				modifyOptionGroupError = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.ModifyOptionGroup("cf-instance-id", nil, []string{"MEMCACHED"})
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	var _ = Describe("DeleteOptionGroup", func() {
+		var (
+			deleteOptionGroupInput *rds.DeleteOptionGroupInput
+			deleteOptionGroupError error
+		)
+
+		BeforeEach(func() {
+			deleteOptionGroupInput = nil
+			deleteOptionGroupError = nil
+		})
+
+		JustBeforeEach(func() {
+			rdssvc.Handlers.Clear()
+
+			rdsCall = func(r *request.Request) {
+				Expect(r.Operation.Name).To(Equal("DeleteOptionGroup"))
+				deleteOptionGroupInput = r.Params.(*rds.DeleteOptionGroupInput)
+				r.Error = deleteOptionGroupError
+			}
+			rdssvc.Handlers.Send.PushBack(rdsCall)
+		})
+
+		It("deletes the option group", func() {
+			err := rdsDBInstance.DeleteOptionGroup("cf-instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleteOptionGroupInput).To(Equal(&rds.DeleteOptionGroupInput{
+				OptionGroupName: aws.String("cf-instance-id"),
+			}))
+		})
+
+		Context("when the option group does not exist", func() {
+			BeforeEach(func() {
+				deleteOptionGroupError = awserr.New("OptionGroupNotFoundFault", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := rdsDBInstance.DeleteOptionGroup("cf-instance-id")
+				Expect(err).To(Equal(ErrOptionGroupDoesNotExist))
+			})
+		})
+	})
 })
@@ -31,6 +31,16 @@ func BuilRDSTags(tags map[string]string) []*rds.Tag {
 	return rdsTags
 }
 
+func RDSTagsValues(rdsTags []*rds.Tag) map[string]string {
+	tags := make(map[string]string)
+
+	for _, t := range rdsTags {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	return tags
+}
+
 func AddTagsToResource(resourceARN string, tags []*rds.Tag, rdssvc *rds.RDS, logger lager.Logger) error {
 	addTagsToResourceInput := &rds.AddTagsToResourceInput{
 		ResourceName: aws.String(resourceARN),
@@ -8,14 +8,26 @@ import (
 	"os"
 
 	"github.com/alphagov/paas-rds-broker/rdsbroker"
+	"github.com/alphagov/paas-rds-broker/sqlengine"
 )
 
 type Config struct {
-	LogLevel           string            `json:"log_level"`
-	Username           string            `json:"username"`
-	Password           string            `json:"password"`
-	StateEncryptionKey string            `json:"state_encryption_key"`
-	RDSConfig          *rdsbroker.Config `json:"rds_config"`
+	LogLevel             string            `json:"log_level"`
+	Username             string            `json:"username"`
+	Password             string            `json:"password"`
+	StateEncryptionKey   string            `json:"state_encryption_key"`
+	StateEncryptionKeys  map[string]string `json:"state_encryption_keys"`
+	StateEncryptionKeyID string            `json:"state_encryption_key_id"`
+	StateStore           *StateStoreConfig `json:"state_store,omitempty"`
+	RDSConfig            *rdsbroker.Config `json:"rds_config"`
+}
+
+// StateStoreConfig sets the dedicated database the broker state is kept in,
+// instead of a broker_state database in each DB instance. Type is "postgres"
+// or "mysql", with the URL of the database.
+type StateStoreConfig struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
 }
 
 func LoadConfig(configFile string) (config *Config, err error) {
@@ -64,13 +76,69 @@ func (c Config) Validate() error {
 		return errors.New("Must provide a non-empty Password")
 	}
 
-	if c.StateEncryptionKey == "" {
+	if c.StateEncryptionKey == "" && len(c.StateEncryptionKeys) == 0 {
 		return errors.New("Must provide a non-empty StateEncryptionKey")
 	}
 
+	for keyID, key := range c.StateEncryptionKeys {
+		if keyID == "" || key == "" {
+			return errors.New("Must provide non-empty StateEncryptionKeys and key IDs")
+		}
+	}
+
+	if c.StateEncryptionKeyID != "" {
+		if _, ok := c.StateEncryptionKeys[c.StateEncryptionKeyID]; !ok {
+			return fmt.Errorf("StateEncryptionKeyID '%s' is not one of the StateEncryptionKeys", c.StateEncryptionKeyID)
+		}
+	} else if c.StateEncryptionKey == "" {
+		return errors.New("Must provide a StateEncryptionKeyID when the StateEncryptionKey is empty")
+	}
+
+	if c.StateStore != nil {
+		if err := c.StateStore.Validate(); err != nil {
+			return fmt.Errorf("Validating StateStore configuration: %s", err)
+		}
+	}
+
 	if err := c.RDSConfig.Validate(); err != nil {
 		return fmt.Errorf("Validating RDS configuration: %s", err)
 	}
 
+	// The users of the service keys are recorded in the state store
+	if c.RDSConfig.ServiceKeys != nil && c.StateStore == nil {
+		return errors.New("Must provide a StateStore when ServiceKeys are configured")
+	}
+
 	return nil
 }
+
+func (c StateStoreConfig) Validate() error {
+	if c.Type != "postgres" && c.Type != "mysql" {
+		return fmt.Errorf("Invalid Type '%s', must be 'postgres' or 'mysql'", c.Type)
+	}
+
+	if c.URL == "" {
+		return errors.New("Must provide a non-empty URL")
+	}
+
+	return nil
+}
+
+// StateEncryptionKeySet returns the keys secrets stored in the broker state
+// may be encrypted with. The StateEncryptionKey, used before key IDs were
+// recorded, has the empty key ID, which is the active one unless a
+// StateEncryptionKeyID is set.
+func (c Config) StateEncryptionKeySet() sqlengine.StateEncryptionKeys {
+	keys := map[string]string{}
+	for keyID, key := range c.StateEncryptionKeys {
+		keys[keyID] = key
+	}
+	if c.StateEncryptionKey != "" {
+		keys[""] = c.StateEncryptionKey
+	}
+
+	return sqlengine.StateEncryptionKeys{
+		ActiveKeyID: c.StateEncryptionKeyID,
+		Keys:        keys,
+	}
+}
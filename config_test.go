@@ -7,6 +7,7 @@ import (
 	. "github.com/alphagov/paas-rds-broker"
 
 	"github.com/alphagov/paas-rds-broker/rdsbroker"
+	"github.com/alphagov/paas-rds-broker/sqlengine"
 )
 
 var _ = Describe("Config", func() {
@@ -70,6 +71,58 @@ var _ = Describe("Config", func() {
 			Expect(err.Error()).To(ContainSubstring("Must provide a non-empty StateEncryptionKey"))
 		})
 
+		It("does not return error if there are StateEncryptionKeys and an active one", func() {
+			config.StateEncryptionKey = ""
+			config.StateEncryptionKeys = map[string]string{"2018-11": "new-key"}
+			config.StateEncryptionKeyID = "2018-11"
+
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("returns error if StateEncryptionKeyID is not one of the StateEncryptionKeys", func() {
+			config.StateEncryptionKeys = map[string]string{"2018-11": "new-key"}
+			config.StateEncryptionKeyID = "2019-01"
+
+			err := config.Validate()
+			Expect(err).To(MatchError("StateEncryptionKeyID '2019-01' is not one of the StateEncryptionKeys"))
+		})
+
+		It("returns error if there is no StateEncryptionKeyID without a StateEncryptionKey", func() {
+			config.StateEncryptionKey = ""
+			config.StateEncryptionKeys = map[string]string{"2018-11": "new-key"}
+
+			err := config.Validate()
+			Expect(err).To(MatchError("Must provide a StateEncryptionKeyID when the StateEncryptionKey is empty"))
+		})
+
+		It("does not return error if StateStore is valid", func() {
+			config.StateStore = &StateStoreConfig{Type: "postgres", URL: "postgres://localhost/broker"}
+
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("returns error if StateStore is not valid", func() {
+			config.StateStore = &StateStoreConfig{Type: "sqlite", URL: "broker.db"}
+
+			err := config.Validate()
+			Expect(err).To(MatchError("Validating StateStore configuration: Invalid Type 'sqlite', must be 'postgres' or 'mysql'"))
+
+			config.StateStore = &StateStoreConfig{Type: "postgres"}
+
+			err = config.Validate()
+			Expect(err).To(MatchError("Validating StateStore configuration: Must provide a non-empty URL"))
+		})
+
+		It("returns error if ServiceKeys are configured without a StateStore", func() {
+			config.RDSConfig.ServiceKeys = &rdsbroker.ServiceKeysConfig{ReadOnly: true}
+
+			err := config.Validate()
+			Expect(err).To(MatchError("Must provide a StateStore when ServiceKeys are configured"))
+
+			config.StateStore = &StateStoreConfig{Type: "postgres", URL: "postgres://localhost/broker"}
+			Expect(config.Validate()).To(Succeed())
+		})
+
 		It("returns error if RDS configuration is not valid", func() {
 			config.RDSConfig = &rdsbroker.Config{}
 
@@ -78,4 +131,20 @@ var _ = Describe("Config", func() {
 			Expect(err.Error()).To(ContainSubstring("Validating RDS configuration"))
 		})
 	})
+
+	Describe("StateEncryptionKeySet", func() {
+		It("gives the StateEncryptionKey the empty key ID", func() {
+			config = validConfig
+			config.StateEncryptionKeys = map[string]string{"2018-11": "new-key"}
+			config.StateEncryptionKeyID = "2018-11"
+
+			Expect(config.StateEncryptionKeySet()).To(Equal(sqlengine.StateEncryptionKeys{
+				ActiveKeyID: "2018-11",
+				Keys: map[string]string{
+					"":        "key",
+					"2018-11": "new-key",
+				},
+			}))
+		})
+	})
 })
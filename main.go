@@ -7,19 +7,28 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/frodenas/brokerapi"
 	"github.com/pivotal-golang/lager"
 
+	"github.com/alphagov/paas-rds-broker/admin"
 	"github.com/alphagov/paas-rds-broker/awsrds"
 	"github.com/alphagov/paas-rds-broker/rdsbroker"
+	"github.com/alphagov/paas-rds-broker/secretstore"
 	"github.com/alphagov/paas-rds-broker/sqlengine"
+	"github.com/alphagov/paas-rds-broker/workflow"
 )
 
+const finalSnapshotsCheckInterval = time.Hour
+const defaultWorkflowsCheckInterval = 30 * time.Second
+const expiredUsersCheckInterval = 5 * time.Minute
+
 var (
 	configFilePath string
 	port           string
@@ -55,15 +64,45 @@ func buildHTTPHandler(serviceBroker *rdsbroker.RDSBroker, logger lager.Logger, c
 		Password: config.Password,
 	}
 
-	brokerAPI := brokerapi.New(serviceBroker, logger, credentials)
 	mux := http.NewServeMux()
-	mux.Handle("/", brokerAPI)
+	mux.Handle("/", rdsbroker.NewHandler(serviceBroker, logger, credentials))
+	mux.Handle("/admin/", admin.New(serviceBroker, logger, credentials))
 	mux.HandleFunc("/healthcheck", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
 	return mux
 }
 
+func buildWorkflowStore(config *rdsbroker.WorkflowStoreConfig, logger lager.Logger) (workflow.Store, error) {
+	if config == nil {
+		logger.Info("workflow-store-not-configured", lager.Data{"warning": "operations in progress are lost when the broker restarts, post-provisions and encryption changes are refused"})
+		return workflow.NewMemoryStore(), nil
+	}
+
+	switch config.Type {
+	case "file":
+		return workflow.NewFileStore(config.Path)
+	default:
+		return workflow.NewSQLStore(config.Type, config.URL, logger)
+	}
+}
+
+func buildStateStore(config *StateStoreConfig, logger lager.Logger) (sqlengine.StateStore, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	return sqlengine.NewSQLStateStore(config.Type, config.URL, logger)
+}
+
+func buildSecretStore(config *rdsbroker.MasterPasswordStoreConfig, awsSession *session.Session, logger lager.Logger) secretstore.SecretStore {
+	if config == nil {
+		return nil
+	}
+
+	return secretstore.NewSecretsManagerStore(config.KMSKeyID, secretsmanager.New(awsSession), logger)
+}
+
 func main() {
 	flag.Parse()
 
@@ -82,14 +121,73 @@ func main() {
 
 	dbInstance := awsrds.NewRDSDBInstance(config.RDSConfig.Region, config.RDSConfig.AWSPartition, rdssvc, stssvc, logger)
 
-	sqlProvider := sqlengine.NewProviderService(logger, config.StateEncryptionKey)
+	stateEncryptionKeys := config.StateEncryptionKeySet()
+	stateStore, err := buildStateStore(config.StateStore, logger)
+	if err != nil {
+		log.Fatalf("Error creating the state store: %s", err)
+	}
+	sqlProvider := sqlengine.NewProviderService(logger, stateEncryptionKeys, stateStore)
 
-	serviceBroker := rdsbroker.New(*config.RDSConfig, dbInstance, sqlProvider, logger)
+	workflowStore, err := buildWorkflowStore(config.RDSConfig.WorkflowStore, logger)
+	if err != nil {
+		log.Fatalf("Error creating the workflow store: %s", err)
+	}
 
-	go serviceBroker.CheckAndRotateCredentials()
+	secretStore := buildSecretStore(config.RDSConfig.MasterPasswordStore, awsSession, logger)
+
+	serviceBroker := rdsbroker.New(*config.RDSConfig, dbInstance, sqlProvider, workflowStore, secretStore, logger)
+
+	if config.RDSConfig.MasterPasswordRotation != nil {
+		go runPeriodically(config.RDSConfig.MasterPasswordRotation.CheckIntervalDuration(), serviceBroker.CheckAndRotateCredentials)
+	} else {
+		go serviceBroker.CheckAndRotateCredentials()
+	}
+	go runPeriodically(finalSnapshotsCheckInterval, serviceBroker.DeleteExpiredFinalSnapshots)
+	go runPeriodically(expiredUsersCheckInterval, serviceBroker.DropExpiredUsers)
+
+	// The secrets of the broker state are moved to the active key, so that the
+	// other ones can be retired
+	if len(stateEncryptionKeys.Keys) > 1 {
+		go serviceBroker.ReencryptBrokerState()
+		if stateStore != nil {
+			go reencryptStateStore(stateStore, stateEncryptionKeys, logger)
+		}
+	}
+
+	// The broker state kept in the DB instances is copied to the state store,
+	// so that their broker_state databases can be dropped
+	if stateStore != nil {
+		go serviceBroker.MigrateBrokerState()
+	}
+
+	workflowsCheckInterval := defaultWorkflowsCheckInterval
+	if config.RDSConfig.WorkflowStore != nil {
+		workflowsCheckInterval = config.RDSConfig.WorkflowStore.CheckIntervalDuration()
+	}
+	go runPeriodically(workflowsCheckInterval, serviceBroker.RunWorkflows)
+
+	if config.RDSConfig.SoftDelete != nil {
+		go runPeriodically(config.RDSConfig.SoftDelete.CheckIntervalDuration(), serviceBroker.DeleteSoftDeletedInstances)
+	}
 
 	server := buildHTTPHandler(serviceBroker, logger, config)
 
 	fmt.Println("RDS Service Broker started on port " + port + "...")
 	http.ListenAndServe(":"+port, server)
 }
+
+func reencryptStateStore(stateStore sqlengine.StateStore, stateEncryptionKeys sqlengine.StateEncryptionKeys, logger lager.Logger) {
+	count, err := sqlengine.ReencryptStateStore(stateStore, stateEncryptionKeys)
+	if err != nil {
+		logger.Error("reencrypt-state-store", err, lager.Data{"reencrypted": count})
+		return
+	}
+	logger.Info("reencrypt-state-store-finished", lager.Data{"reencrypted": count})
+}
+
+func runPeriodically(interval time.Duration, job func()) {
+	for {
+		job()
+		time.Sleep(interval)
+	}
+}
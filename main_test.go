@@ -29,5 +29,20 @@ var _ = Describe("Main", func() {
 
 			Expect(w.Code).To(Equal(200))
 		})
+
+		It("serves the admin API with the broker credentials", func() {
+			handler := buildHTTPHandler(
+				&rdsbroker.RDSBroker{},
+				lager.NewLogger("main.test"),
+				&Config{Username: "username", Password: "password"},
+			)
+			req, err := http.NewRequest("POST", "http://example.com/admin/windows/rebalance", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
 	})
 })
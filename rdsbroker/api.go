@@ -0,0 +1,305 @@
+package rdsbroker
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/frodenas/brokerapi"
+	"github.com/frodenas/brokerapi/auth"
+	"github.com/gorilla/mux"
+	"github.com/pivotal-golang/lager"
+)
+
+const provisionLogKey = "provision"
+const updateLogKey = "update"
+const deprovisionLogKey = "deprovision"
+const bindLogKey = "bind"
+const unbindLogKey = "unbind"
+const lastOperationLogKey = "last-operation"
+
+const statusUnprocessableEntity = 422
+
+// CatalogResponse is the catalog of the service broker API with the parameter
+// schemas of the plans, which the vendored brokerapi does not know about.
+type CatalogResponse struct {
+	Services []CatalogService `json:"services"`
+}
+
+type CatalogService struct {
+	brokerapi.Service
+	Plans []CatalogServicePlan `json:"plans"`
+}
+
+type CatalogServicePlan struct {
+	brokerapi.ServicePlan
+	Schemas *ServiceSchemas `json:"schemas,omitempty"`
+}
+
+// ServiceBroker is the broker served by NewHandler. Unlike
+// brokerapi.ServiceBroker, its catalog has the parameter schemas of the plans,
+// and it may return FailureResponses.
+type ServiceBroker interface {
+	Services() CatalogResponse
+
+	Provision(instanceID string, details brokerapi.ProvisionDetails, acceptsIncomplete bool) (brokerapi.ProvisioningResponse, bool, error)
+	Update(instanceID string, details brokerapi.UpdateDetails, acceptsIncomplete bool) (bool, error)
+	Deprovision(instanceID string, details brokerapi.DeprovisionDetails, acceptsIncomplete bool) (bool, error)
+
+	Bind(instanceID, bindingID string, details brokerapi.BindDetails) (brokerapi.BindingResponse, error)
+	Unbind(instanceID, bindingID string, details brokerapi.UnbindDetails) error
+
+	LastOperation(instanceID string) (brokerapi.LastOperationResponse, error)
+}
+
+// NewHandler returns the handler of the service broker API. It serves the same
+// routes as brokerapi.New, and sends the FailureResponses of the broker with
+// their status code.
+func NewHandler(serviceBroker ServiceBroker, logger lager.Logger, credentials brokerapi.BrokerCredentials) http.Handler {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/v2/catalog", catalog(serviceBroker)).Methods("GET")
+
+	router.HandleFunc("/v2/service_instances/{instance_id}", provision(serviceBroker, logger)).Methods("PUT")
+	router.HandleFunc("/v2/service_instances/{instance_id}", update(serviceBroker, logger)).Methods("PATCH")
+	router.HandleFunc("/v2/service_instances/{instance_id}", deprovision(serviceBroker, logger)).Methods("DELETE")
+
+	router.HandleFunc("/v2/service_instances/{instance_id}/service_bindings/{binding_id}", bind(serviceBroker, logger)).Methods("PUT")
+	router.HandleFunc("/v2/service_instances/{instance_id}/service_bindings/{binding_id}", unbind(serviceBroker, logger)).Methods("DELETE")
+
+	router.HandleFunc("/v2/service_instances/{instance_id}/last_operation", lastOperation(serviceBroker, logger)).Methods("GET")
+
+	return auth.NewWrapper(credentials.Username, credentials.Password).Wrap(router)
+}
+
+func catalog(serviceBroker ServiceBroker) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		respond(w, http.StatusOK, serviceBroker.Services())
+	}
+}
+
+func provision(serviceBroker ServiceBroker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		instanceID := mux.Vars(req)["instance_id"]
+		acceptsIncomplete := req.URL.Query().Get("accepts_incomplete") == "true"
+
+		logger := logger.Session(provisionLogKey, lager.Data{instanceIDLogKey: instanceID})
+
+		var details brokerapi.ProvisionDetails
+		if err := json.NewDecoder(req.Body).Decode(&details); err != nil {
+			logger.Error("invalid-provision-details", err)
+			respond(w, http.StatusBadRequest, brokerapi.ErrorResponse{Description: err.Error()})
+			return
+		}
+
+		logger = logger.WithData(lager.Data{"provision-details": details})
+
+		provisioningResponse, async, err := serviceBroker.Provision(instanceID, details, acceptsIncomplete)
+		if err != nil {
+			switch err {
+			case brokerapi.ErrInstanceAlreadyExists:
+				logger.Error("instance-already-exists", err)
+				respond(w, http.StatusConflict, brokerapi.EmptyResponse{})
+			case brokerapi.ErrAsyncRequired:
+				respondAsyncRequired(w, logger, err)
+			default:
+				respondError(w, logger, err)
+			}
+			return
+		}
+
+		if async {
+			respond(w, http.StatusAccepted, provisioningResponse)
+			return
+		}
+
+		respond(w, http.StatusCreated, provisioningResponse)
+	}
+}
+
+func update(serviceBroker ServiceBroker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		instanceID := mux.Vars(req)["instance_id"]
+		acceptsIncomplete := req.URL.Query().Get("accepts_incomplete") == "true"
+
+		logger := logger.Session(updateLogKey, lager.Data{instanceIDLogKey: instanceID})
+
+		var details brokerapi.UpdateDetails
+		if err := json.NewDecoder(req.Body).Decode(&details); err != nil {
+			logger.Error("invalid-update-details", err)
+			respond(w, http.StatusBadRequest, brokerapi.ErrorResponse{Description: err.Error()})
+			return
+		}
+
+		logger = logger.WithData(lager.Data{"update-details": details})
+
+		async, err := serviceBroker.Update(instanceID, details, acceptsIncomplete)
+		if err != nil {
+			switch err {
+			case brokerapi.ErrInstanceDoesNotExist:
+				logger.Error("instance-missing", err)
+				respond(w, http.StatusInternalServerError, brokerapi.EmptyResponse{})
+			case brokerapi.ErrAsyncRequired:
+				respondAsyncRequired(w, logger, err)
+			default:
+				respondError(w, logger, err)
+			}
+			return
+		}
+
+		if async {
+			respond(w, http.StatusAccepted, brokerapi.EmptyResponse{})
+			return
+		}
+
+		respond(w, http.StatusOK, brokerapi.EmptyResponse{})
+	}
+}
+
+func deprovision(serviceBroker ServiceBroker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		instanceID := mux.Vars(req)["instance_id"]
+		acceptsIncomplete := req.URL.Query().Get("accepts_incomplete") == "true"
+
+		logger := logger.Session(deprovisionLogKey, lager.Data{instanceIDLogKey: instanceID})
+
+		details := brokerapi.DeprovisionDetails{
+			ServiceID: req.FormValue("service_id"),
+			PlanID:    req.FormValue("plan_id"),
+		}
+
+		logger = logger.WithData(lager.Data{"deprovision-details": details})
+
+		async, err := serviceBroker.Deprovision(instanceID, details, acceptsIncomplete)
+		if err != nil {
+			switch err {
+			case brokerapi.ErrInstanceDoesNotExist:
+				logger.Error("instance-missing", err)
+				respond(w, http.StatusGone, brokerapi.EmptyResponse{})
+			case brokerapi.ErrAsyncRequired:
+				respondAsyncRequired(w, logger, err)
+			default:
+				respondError(w, logger, err)
+			}
+			return
+		}
+
+		if async {
+			respond(w, http.StatusAccepted, brokerapi.EmptyResponse{})
+			return
+		}
+
+		respond(w, http.StatusOK, brokerapi.EmptyResponse{})
+	}
+}
+
+func bind(serviceBroker ServiceBroker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		vars := mux.Vars(req)
+		instanceID := vars["instance_id"]
+		bindingID := vars["binding_id"]
+
+		logger := logger.Session(bindLogKey, lager.Data{instanceIDLogKey: instanceID, bindingIDLogKey: bindingID})
+
+		var details brokerapi.BindDetails
+		if err := json.NewDecoder(req.Body).Decode(&details); err != nil {
+			logger.Error("invalid-bind-details", err)
+			respond(w, http.StatusBadRequest, brokerapi.ErrorResponse{Description: err.Error()})
+			return
+		}
+
+		logger = logger.WithData(lager.Data{"bind-details": details})
+
+		bindingResponse, err := serviceBroker.Bind(instanceID, bindingID, details)
+		if err != nil {
+			switch err {
+			case brokerapi.ErrBindingAlreadyExists:
+				logger.Error("binding-already-exists", err)
+				respond(w, http.StatusConflict, brokerapi.ErrorResponse{Description: err.Error()})
+			case brokerapi.ErrAppGUIDRequired:
+				logger.Error("binding-app-guid-required", err)
+				respond(w, statusUnprocessableEntity, brokerapi.ErrorResponse{Error: "RequiresApp", Description: err.Error()})
+			default:
+				respondError(w, logger, err)
+			}
+			return
+		}
+
+		respond(w, http.StatusCreated, bindingResponse)
+	}
+}
+
+func unbind(serviceBroker ServiceBroker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		vars := mux.Vars(req)
+		instanceID := vars["instance_id"]
+		bindingID := vars["binding_id"]
+
+		logger := logger.Session(unbindLogKey, lager.Data{instanceIDLogKey: instanceID, bindingIDLogKey: bindingID})
+
+		details := brokerapi.UnbindDetails{
+			ServiceID: req.FormValue("service_id"),
+			PlanID:    req.FormValue("plan_id"),
+		}
+
+		logger = logger.WithData(lager.Data{"unbind-details": details})
+
+		if err := serviceBroker.Unbind(instanceID, bindingID, details); err != nil {
+			switch err {
+			case brokerapi.ErrBindingDoesNotExist:
+				logger.Error("binding-missing", err)
+				respond(w, http.StatusGone, brokerapi.EmptyResponse{})
+			default:
+				respondError(w, logger, err)
+			}
+			return
+		}
+
+		respond(w, http.StatusOK, brokerapi.EmptyResponse{})
+	}
+}
+
+func lastOperation(serviceBroker ServiceBroker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		instanceID := mux.Vars(req)["instance_id"]
+
+		logger := logger.Session(lastOperationLogKey, lager.Data{instanceIDLogKey: instanceID})
+
+		lastOperationResponse, err := serviceBroker.LastOperation(instanceID)
+		if err != nil {
+			switch err {
+			case brokerapi.ErrInstanceDoesNotExist:
+				logger.Error("instance-missing", err)
+				respond(w, http.StatusGone, brokerapi.EmptyResponse{})
+			default:
+				respondError(w, logger, err)
+			}
+			return
+		}
+
+		respond(w, http.StatusOK, lastOperationResponse)
+	}
+}
+
+func respondAsyncRequired(w http.ResponseWriter, logger lager.Logger, err error) {
+	logger.Error("instance-async-required", err)
+	respond(w, statusUnprocessableEntity, brokerapi.ErrorResponse{Error: "AsyncRequired", Description: err.Error()})
+}
+
+// respondError sends a FailureResponse with its status code, and any other
+// error as 500 Internal Server Error.
+func respondError(w http.ResponseWriter, logger lager.Logger, err error) {
+	if failure, ok := err.(*FailureResponse); ok {
+		logger.Error(failure.LoggerAction(), err)
+		respond(w, failure.StatusCode(), brokerapi.ErrorResponse{Description: err.Error()})
+		return
+	}
+
+	logger.Error("unknown-error", err)
+	respond(w, http.StatusInternalServerError, brokerapi.ErrorResponse{Description: err.Error()})
+}
+
+func respond(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
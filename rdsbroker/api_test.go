@@ -0,0 +1,130 @@
+package rdsbroker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/frodenas/brokerapi"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	rdsfake "github.com/alphagov/paas-rds-broker/awsrds/fakes"
+	. "github.com/alphagov/paas-rds-broker/rdsbroker"
+	sqlfake "github.com/alphagov/paas-rds-broker/sqlengine/fakes"
+	"github.com/alphagov/paas-rds-broker/workflow"
+)
+
+var _ = Describe("Service broker API", func() {
+	var (
+		dbInstance *rdsfake.FakeDBInstance
+		handler    http.Handler
+	)
+
+	BeforeEach(func() {
+		dbInstance = &rdsfake.FakeDBInstance{}
+
+		config := Config{
+			Region:                       "rds-region",
+			DBPrefix:                     "cf",
+			BrokerName:                   "mybroker",
+			MasterPasswordSeed:           "something-secret",
+			AllowUserProvisionParameters: true,
+			Catalog: Catalog{
+				Services: []Service{
+					{
+						ID:       "Service-1",
+						Name:     "Service 1",
+						Bindable: true,
+						Plans: []ServicePlan{
+							{
+								ID:   "Plan-1",
+								Name: "Plan 1",
+								RDSProperties: RDSProperties{
+									DBInstanceClass: "db.m1.test",
+									Engine:          "postgres",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		logger := lager.NewLogger("api_test")
+		logger.RegisterSink(lagertest.NewTestSink())
+
+		broker := New(config, dbInstance, &sqlfake.FakeProvider{}, workflow.NewMemoryStore(), nil, logger)
+		handler = NewHandler(broker, logger, brokerapi.BrokerCredentials{
+			Username: "username",
+			Password: "password",
+		})
+	})
+
+	doRequest := func(method, path, body string, authenticated bool) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, "http://example.com"+path, strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		if authenticated {
+			req.SetBasicAuth("username", "password")
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	Describe("the catalog", func() {
+		It("requires the broker credentials", func() {
+			w := doRequest("GET", "/v2/catalog", "", false)
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("returns the parameter schemas of the plans", func() {
+			w := doRequest("GET", "/v2/catalog", "", true)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var catalog CatalogResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &catalog)).To(Succeed())
+			Expect(catalog.Services).To(HaveLen(1))
+			Expect(catalog.Services[0].ID).To(Equal("Service-1"))
+			Expect(catalog.Services[0].Plans).To(HaveLen(1))
+			Expect(catalog.Services[0].Plans[0].ID).To(Equal("Plan-1"))
+			Expect(catalog.Services[0].Plans[0].Schemas.Instance.Create.Parameters["properties"]).To(HaveKey("skip_final_snapshot"))
+		})
+	})
+
+	Describe("the errors of the broker", func() {
+		provisionPath := "/v2/service_instances/instance-id?accepts_incomplete=true"
+
+		It("sends the failure responses with their status code", func() {
+			w := doRequest("PUT", provisionPath, `{"service_id": "Service-1", "plan_id": "Plan-1", "parameters": {"skip_final_snapshot": "maybe"}}`, true)
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+			var errorResponse brokerapi.ErrorResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &errorResponse)).To(Succeed())
+			Expect(errorResponse.Description).To(ContainSubstring("skip_final_snapshot must be one of 'true', 'false'"))
+			Expect(dbInstance.CreateCalled).To(BeFalse())
+		})
+
+		It("sends the other errors as internal server errors", func() {
+			dbInstance.CreateError = errors.New("operation failed")
+
+			w := doRequest("PUT", provisionPath, `{"service_id": "Service-1", "plan_id": "Plan-1"}`, true)
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+
+			var errorResponse brokerapi.ErrorResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &errorResponse)).To(Succeed())
+			Expect(errorResponse.Description).To(Equal("operation failed"))
+		})
+
+		It("sends the errors known to brokerapi as they are", func() {
+			w := doRequest("PUT", "/v2/service_instances/instance-id", `{"service_id": "Service-1", "plan_id": "Plan-1"}`, true)
+			Expect(w.Code).To(Equal(422))
+		})
+	})
+})
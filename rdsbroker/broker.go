@@ -4,17 +4,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/frodenas/brokerapi"
-	"github.com/mitchellh/mapstructure"
 	"github.com/pivotal-golang/lager"
 
 	"github.com/alphagov/paas-rds-broker/awsrds"
+	"github.com/alphagov/paas-rds-broker/secretstore"
 	"github.com/alphagov/paas-rds-broker/sqlengine"
 	"github.com/alphagov/paas-rds-broker/utils"
+	"github.com/alphagov/paas-rds-broker/workflow"
 )
 
 const masterUsernameLength = 16
@@ -27,11 +31,73 @@ const acceptsIncompleteLogKey = "acceptsIncomplete"
 const updateParametersLogKey = "updateParameters"
 const servicePlanLogKey = "servicePlan"
 const dbInstanceDetailsLogKey = "dbInstanceDetails"
+const invalidParametersLogKey = "invalid-parameters"
+
+const userBackupWindowTag = "UserPreferredBackupWindow"
+const userMaintenanceWindowTag = "UserPreferredMaintenanceWindow"
+
+const deletedAtTag = "Deleted at"
+const deletedByTag = "Deleted by"
+
+const instanceIDTag = "Instance ID"
+const snapshotTypeTag = "Snapshot type"
+const manualSnapshotType = "manual"
+const preChangeSnapshotType = "pre-change"
 
 var (
 	ErrEncryptionNotUpdateable = errors.New("intance can not be updated to a plan with different encryption settings")
+
+	ErrEncryptionChangeNotConfirmed = errors.New("changing the encryption settings loses the writes made while the instance is copied, set confirm_encryption_change to go ahead")
+
+	ErrWindowSchedulerNotConfigured = errors.New("the window scheduler is not configured")
+
+	ErrWorkflowStoreNotConfigured = errors.New("the workflow store is not configured")
+
+	ErrInstanceNotSoftDeleted = errors.New("the instance has not been soft-deleted")
+
+	ErrServiceKeysNotSupportedWithIAM = errors.New("service keys cannot be given a user of their own on plans with IAM database authentication")
 )
 
+type WindowsAssignment struct {
+	InstanceID                 string `json:"instance_id"`
+	PreferredBackupWindow      string `json:"preferred_backup_window"`
+	PreferredMaintenanceWindow string `json:"preferred_maintenance_window"`
+	Error                      string `json:"error,omitempty"`
+}
+
+// FinalSnapshot is a snapshot taken when deleting the DB instance of a service
+// instance. ExpiresAt is not set when the snapshot is kept forever.
+type FinalSnapshot struct {
+	SnapshotID     string     `json:"snapshot_id"`
+	InstanceID     string     `json:"instance_id"`
+	OrganizationID string     `json:"organization_id,omitempty"`
+	SpaceID        string     `json:"space_id,omitempty"`
+	PlanID         string     `json:"plan_id,omitempty"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// IAMCredentialsHash are the credentials of the bindings of plans with IAM
+// database authentication. Applications generate short-lived authentication
+// tokens for the user from the region and the resource ID of the DB instance,
+// instead of using a password.
+type IAMCredentialsHash struct {
+	Host       string `json:"host,omitempty"`
+	Port       int64  `json:"port,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Region     string `json:"region,omitempty"`
+	ResourceID string `json:"resource_id,omitempty"`
+}
+
+// ExpiringCredentialsHash are the credentials of the bindings created with
+// the expires_in parameter, which can no longer be used after ExpiresAt.
+type ExpiringCredentialsHash struct {
+	brokerapi.CredentialsHash
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
 var rdsStatus2State = map[string]string{
 	"available":                    brokerapi.LastOperationSucceeded,
 	"backing-up":                   brokerapi.LastOperationInProgress,
@@ -46,8 +112,11 @@ var rdsStatus2State = map[string]string{
 }
 
 type RDSBroker struct {
+	region                       string
 	dbPrefix                     string
 	masterPasswordSeed           string
+	previousMasterPasswordSeed   string
+	masterPasswordRotationDelay  time.Duration
 	allowUserProvisionParameters bool
 	allowUserUpdateParameters    bool
 	allowUserBindParameters      bool
@@ -56,17 +125,43 @@ type RDSBroker struct {
 	sqlProvider                  sqlengine.Provider
 	logger                       lager.Logger
 	brokerName                   string
+	windowScheduler              *WindowScheduler
+	softDeleteGracePeriod        time.Duration
+	workflows                    *workflow.Engine
+	persistentWorkflows          bool
+	secretStore                  secretstore.SecretStore
+	serviceKeys                  *ServiceKeysConfig
 }
 
 func New(
 	config Config,
 	dbInstance awsrds.DBInstance,
 	sqlProvider sqlengine.Provider,
+	workflowStore workflow.Store,
+	secretStore secretstore.SecretStore,
 	logger lager.Logger,
 ) *RDSBroker {
-	return &RDSBroker{
+	var windowScheduler *WindowScheduler
+	if config.WindowScheduler != nil {
+		windowScheduler = NewWindowScheduler(*config.WindowScheduler)
+	}
+
+	var masterPasswordRotationDelay time.Duration
+	if config.MasterPasswordRotation != nil {
+		masterPasswordRotationDelay = config.MasterPasswordRotation.DelayDuration()
+	}
+
+	var softDeleteGracePeriod time.Duration
+	if config.SoftDelete != nil {
+		softDeleteGracePeriod = config.SoftDelete.GracePeriodDuration()
+	}
+
+	b := &RDSBroker{
+		region:                       config.Region,
 		dbPrefix:                     config.DBPrefix,
 		masterPasswordSeed:           config.MasterPasswordSeed,
+		previousMasterPasswordSeed:   config.PreviousMasterPasswordSeed,
+		masterPasswordRotationDelay:  masterPasswordRotationDelay,
 		allowUserProvisionParameters: config.AllowUserProvisionParameters,
 		allowUserUpdateParameters:    config.AllowUserUpdateParameters,
 		allowUserBindParameters:      config.AllowUserBindParameters,
@@ -75,11 +170,21 @@ func New(
 		dbInstance:                   dbInstance,
 		sqlProvider:                  sqlProvider,
 		logger:                       logger.Session("broker"),
+		windowScheduler:              windowScheduler,
+		softDeleteGracePeriod:        softDeleteGracePeriod,
+		workflows:                    workflow.NewEngine(workflowStore, logger),
+		persistentWorkflows:          config.WorkflowStore != nil,
+		secretStore:                  secretStore,
+		serviceKeys:                  config.ServiceKeys,
 	}
+	b.registerWorkflows()
+
+	return b
 }
 
-func (b *RDSBroker) Services() brokerapi.CatalogResponse {
-	catalogResponse := brokerapi.CatalogResponse{}
+// Services returns the catalog with the parameter schemas of the plans.
+func (b *RDSBroker) Services() CatalogResponse {
+	catalogResponse := CatalogResponse{}
 
 	brokerCatalog, err := json.Marshal(b.catalog)
 	if err != nil {
@@ -87,13 +192,18 @@ func (b *RDSBroker) Services() brokerapi.CatalogResponse {
 		return catalogResponse
 	}
 
-	apiCatalog := brokerapi.Catalog{}
-	if err = json.Unmarshal(brokerCatalog, &apiCatalog); err != nil {
+	if err = json.Unmarshal(brokerCatalog, &catalogResponse); err != nil {
 		b.logger.Error("unmarshal-error", err)
 		return catalogResponse
 	}
 
-	catalogResponse.Services = apiCatalog.Services
+	for i, service := range catalogResponse.Services {
+		for j, plan := range service.Plans {
+			if servicePlan, ok := b.catalog.FindServicePlan(plan.ID); ok {
+				catalogResponse.Services[i].Plans[j].Schemas = b.planSchemas(servicePlan)
+			}
+		}
+	}
 
 	return catalogResponse
 }
@@ -111,23 +221,60 @@ func (b *RDSBroker) Provision(instanceID string, details brokerapi.ProvisionDeta
 		return provisioningResponse, false, brokerapi.ErrAsyncRequired
 	}
 
+	servicePlan, ok := b.catalog.FindServicePlan(details.PlanID)
+	if !ok {
+		return provisioningResponse, false, fmt.Errorf("Service Plan '%s' not found", details.PlanID)
+	}
+
 	provisionParameters := ProvisionParameters{}
-	if b.allowUserProvisionParameters {
-		if err := mapstructure.Decode(details.Parameters, &provisionParameters); err != nil {
+	if b.userParametersAllowed(ProvisionParameters{}, servicePlan) {
+		if err := b.parseParameters(&provisionParameters, servicePlan, details.Parameters); err != nil {
 			return provisioningResponse, false, err
 		}
 		if err := provisionParameters.Validate(); err != nil {
+			return provisioningResponse, false, NewFailureResponse(err, http.StatusBadRequest, invalidParametersLogKey)
+		}
+		if err := b.validateProvisionWindows(instanceID, servicePlan, provisionParameters.PreferredBackupWindow, provisionParameters.PreferredMaintenanceWindow); err != nil {
 			return provisioningResponse, false, err
 		}
 	}
 
-	servicePlan, ok := b.catalog.FindServicePlan(details.PlanID)
-	if !ok {
-		return provisioningResponse, false, fmt.Errorf("Service Plan '%s' not found", details.PlanID)
+	// A post-provision lost by a restart would leave the DB instance half set up
+	postProvision := len(servicePlan.RDSProperties.PostProvisionSQL) > 0 || len(provisionParameters.Extensions) > 0
+	if postProvision && !b.persistentWorkflows {
+		return provisioningResponse, false, ErrWorkflowStoreNotConfigured
+	}
+
+	groupTags := b.dbTags("Created", details.ServiceID, details.PlanID, details.OrganizationGUID, details.SpaceGUID, "")
+	if hasOwnDBParameterGroup(servicePlan) {
+		if err := b.createDBParameterGroup(instanceID, servicePlan, provisionParameters.DBParameters, groupTags); err != nil {
+			b.deleteProvisionedGroups(instanceID, servicePlan)
+			return provisioningResponse, false, err
+		}
+	}
+	if hasOwnOptionGroup(servicePlan) {
+		if err := b.createOptionGroup(instanceID, servicePlan, provisionParameters.Options, groupTags); err != nil {
+			b.deleteProvisionedGroups(instanceID, servicePlan)
+			return provisioningResponse, false, err
+		}
 	}
 
 	createDBInstance := b.createDBInstance(instanceID, servicePlan, provisionParameters, details)
+	if err := b.setNewMasterPassword(instanceID, createDBInstance, groupTags); err != nil {
+		b.deleteProvisionedGroups(instanceID, servicePlan)
+		return provisioningResponse, false, err
+	}
+
+	if postProvision {
+		if err := b.startPostProvision(instanceID, servicePlan, provisionParameters.Extensions); err != nil {
+			b.deleteProvisionedGroups(instanceID, servicePlan)
+			return provisioningResponse, false, err
+		}
+	}
+
 	if err := b.dbInstance.Create(b.dbInstanceIdentifier(instanceID), *createDBInstance); err != nil {
+		b.workflows.Forget(instanceID)
+		b.deleteProvisionedGroups(instanceID, servicePlan)
 		return provisioningResponse, false, err
 	}
 
@@ -145,17 +292,6 @@ func (b *RDSBroker) Update(instanceID string, details brokerapi.UpdateDetails, a
 		return false, brokerapi.ErrAsyncRequired
 	}
 
-	updateParameters := UpdateParameters{}
-	if b.allowUserUpdateParameters {
-		if err := mapstructure.Decode(details.Parameters, &updateParameters); err != nil {
-			return false, err
-		}
-		if err := updateParameters.Validate(); err != nil {
-			return false, err
-		}
-		b.logger.Debug("update-parsed-params", lager.Data{updateParametersLogKey: updateParameters})
-	}
-
 	service, ok := b.catalog.FindService(details.ServiceID)
 	if !ok {
 		return false, fmt.Errorf("Service '%s' not found", details.ServiceID)
@@ -175,13 +311,98 @@ func (b *RDSBroker) Update(instanceID string, details brokerapi.UpdateDetails, a
 		return false, fmt.Errorf("Service Plan '%s' not found", details.PreviousValues.PlanID)
 	}
 
-	if servicePlan.RDSProperties.StorageEncrypted != previousServicePlan.RDSProperties.StorageEncrypted {
-		return false, ErrEncryptionNotUpdateable
+	updateParameters := UpdateParameters{}
+	if b.userParametersAllowed(UpdateParameters{}, servicePlan) {
+		if err := b.parseParameters(&updateParameters, servicePlan, details.Parameters); err != nil {
+			return false, err
+		}
+		if err := updateParameters.Validate(); err != nil {
+			return false, NewFailureResponse(err, http.StatusBadRequest, invalidParametersLogKey)
+		}
+		if err := b.validateUpdateWindows(instanceID, servicePlan, updateParameters.PreferredBackupWindow, updateParameters.PreferredMaintenanceWindow); err != nil {
+			return false, err
+		}
+		b.logger.Debug("update-parsed-params", lager.Data{updateParametersLogKey: updateParameters})
+	}
+
+	if err := b.checkNoWorkflowInProgress(instanceID); err != nil {
+		return false, err
 	}
 
-	if servicePlan.RDSProperties.KmsKeyID != previousServicePlan.RDSProperties.KmsKeyID {
+	// The whole update is validated before anything is changed
+	encryptionChange := servicePlan.RDSProperties.StorageEncrypted != previousServicePlan.RDSProperties.StorageEncrypted ||
+		servicePlan.RDSProperties.KmsKeyID != previousServicePlan.RDSProperties.KmsKeyID
+	// RDS can encrypt or re-encrypt a copy of a snapshot, but not decrypt it
+	if encryptionChange && !servicePlan.RDSProperties.StorageEncrypted {
 		return false, ErrEncryptionNotUpdateable
 	}
+	// An encryption change lost by a restart would leave two DB instances behind
+	if encryptionChange && !b.persistentWorkflows {
+		return false, ErrWorkflowStoreNotConfigured
+	}
+	// The writes made between the snapshot and the swap are not copied
+	if encryptionChange && !updateParameters.ConfirmEncryptionChange {
+		return false, NewFailureResponse(ErrEncryptionChangeNotConfirmed, http.StatusBadRequest, invalidParametersLogKey)
+	}
+	if updateParameters.TakeSnapshot {
+		if err := validateManualSnapshot(servicePlan, updateParameters, details); err != nil {
+			return false, err
+		}
+	}
+	if err := b.validateDBParameterGroupUpdate(instanceID, servicePlan, previousServicePlan); err != nil {
+		return false, err
+	}
+	if err := b.validateOptionGroupUpdate(instanceID, servicePlan, previousServicePlan); err != nil {
+		return false, err
+	}
+
+	// The snapshots are taken before the groups and extensions are changed, so
+	// that they can be restored to
+	if encryptionChange {
+		if err := b.startEncryptionChange(instanceID, servicePlan, details); err != nil {
+			if err == awsrds.ErrDBInstanceDoesNotExist {
+				return false, brokerapi.ErrInstanceDoesNotExist
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	if updateParameters.TakeSnapshot {
+		if err := b.takeManualSnapshot(instanceID, servicePlan, details); err != nil {
+			if err == awsrds.ErrDBInstanceDoesNotExist {
+				return false, brokerapi.ErrInstanceDoesNotExist
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	if servicePlan.RDSProperties.PreChangeSnapshot {
+		taken, err := b.takePreChangeSnapshot(instanceID, servicePlan, details)
+		if err != nil {
+			if err == awsrds.ErrDBInstanceDoesNotExist {
+				return false, brokerapi.ErrInstanceDoesNotExist
+			}
+			return false, err
+		}
+		if taken {
+			return true, nil
+		}
+	}
+
+	if err := b.updateGroups(instanceID, servicePlan, previousServicePlan, updateParameters, details); err != nil {
+		return false, err
+	}
+
+	if updateParameters.Extensions != nil {
+		if err := b.updateExtensions(instanceID, b.dbInstanceIdentifier(instanceID), servicePlan, updateParameters.Extensions); err != nil {
+			if err == awsrds.ErrDBInstanceDoesNotExist {
+				return false, brokerapi.ErrInstanceDoesNotExist
+			}
+			return false, err
+		}
+	}
 
 	modifyDBInstance := b.modifyDBInstance(instanceID, servicePlan, updateParameters, details)
 	if err := b.dbInstance.Modify(b.dbInstanceIdentifier(instanceID), *modifyDBInstance, updateParameters.ApplyImmediately); err != nil {
@@ -191,9 +412,24 @@ func (b *RDSBroker) Update(instanceID string, details brokerapi.UpdateDetails, a
 		return false, err
 	}
 
+	if err := b.startLeftGroupsDeletion(instanceID, servicePlan, previousServicePlan); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
+// updateGroups applies an update to the DB parameter group and option group of
+// the DB instance. The update must have been validated.
+func (b *RDSBroker) updateGroups(instanceID string, servicePlan, previousServicePlan ServicePlan, updateParameters UpdateParameters, details brokerapi.UpdateDetails) error {
+	groupTags := b.dbTags("Updated", details.ServiceID, details.PlanID, "", "", "")
+	if err := b.updateDBParameterGroup(instanceID, servicePlan, previousServicePlan, updateParameters.DBParameters, groupTags); err != nil {
+		return err
+	}
+
+	return b.updateOptionGroup(instanceID, servicePlan, previousServicePlan, updateParameters.Options, groupTags)
+}
+
 func (b *RDSBroker) Deprovision(instanceID string, details brokerapi.DeprovisionDetails, acceptsIncomplete bool) (bool, error) {
 	b.logger.Debug("deprovision", lager.Data{
 		instanceIDLogKey:        instanceID,
@@ -210,6 +446,27 @@ func (b *RDSBroker) Deprovision(instanceID string, details brokerapi.Deprovision
 		return false, fmt.Errorf("Service Plan '%s' not found", details.PlanID)
 	}
 
+	// Operations waiting for the DB instance are abandoned, but not the ones
+	// which have created other DB instances
+	if err := b.checkNoWorkflowInProgress(instanceID, postProvisionWorkflow, preChangeSnapshotWorkflow, manualSnapshotWorkflow, deleteGroupsWorkflow); err != nil {
+		return false, err
+	}
+
+	if b.softDeleteGracePeriod > 0 {
+		if err := b.softDeleteDBInstance(instanceID); err != nil {
+			if err == awsrds.ErrDBInstanceDoesNotExist {
+				return false, brokerapi.ErrInstanceDoesNotExist
+			}
+			return false, err
+		}
+		// The groups left behind by a plan change are deleted with the DB
+		// instance
+		if w, err := b.workflows.Get(instanceID); err == nil && w.Kind == deleteGroupsWorkflow && w.State == workflow.StateInProgress {
+			return false, nil
+		}
+		return false, b.workflows.Forget(instanceID)
+	}
+
 	skipDBInstanceFinalSnapshot := servicePlan.RDSProperties.SkipFinalSnapshot
 
 	skipFinalSnapshot, err := b.dbInstance.GetTag(b.dbInstanceIdentifier(instanceID), "SkipFinalSnapshot")
@@ -231,6 +488,17 @@ func (b *RDSBroker) Deprovision(instanceID string, details brokerapi.Deprovision
 		return false, err
 	}
 
+	if err := b.startGroupsDeletion(instanceID, servicePlan); err != nil {
+		return false, err
+	}
+	// The master password is needed to use a restored final snapshot, and is
+	// deleted once it expires
+	if skipDBInstanceFinalSnapshot {
+		if err := b.deleteMasterPassword(instanceID); err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
 
@@ -243,13 +511,6 @@ func (b *RDSBroker) Bind(instanceID, bindingID string, details brokerapi.BindDet
 
 	bindingResponse := brokerapi.BindingResponse{}
 
-	bindParameters := BindParameters{}
-	if b.allowUserBindParameters {
-		if err := mapstructure.Decode(details.Parameters, &bindParameters); err != nil {
-			return bindingResponse, err
-		}
-	}
-
 	service, ok := b.catalog.FindService(details.ServiceID)
 	if !ok {
 		return bindingResponse, fmt.Errorf("Service '%s' not found", details.ServiceID)
@@ -264,6 +525,21 @@ func (b *RDSBroker) Bind(instanceID, bindingID string, details brokerapi.BindDet
 		return bindingResponse, fmt.Errorf("Service Plan '%s' not found", details.PlanID)
 	}
 
+	bindParameters := BindParameters{}
+	if b.userParametersAllowed(BindParameters{}, servicePlan) {
+		if err := b.parseParameters(&bindParameters, servicePlan, details.Parameters); err != nil {
+			return bindingResponse, err
+		}
+	}
+
+	// Service keys are bindings without an app, handed out to people
+	serviceKey := details.AppGUID == "" && b.serviceKeys != nil
+	// IAM users cannot be made read-only or expire like the users of the
+	// service keys policy
+	if serviceKey && servicePlan.RDSProperties.IAMDatabaseAuthentication {
+		return bindingResponse, NewFailureResponse(ErrServiceKeysNotSupportedWithIAM, http.StatusBadRequest, invalidParametersLogKey)
+	}
+
 	var dbAddress, dbName, masterUsername string
 	var dbPort int64
 	dbInstanceDetails, err := b.dbInstance.Describe(b.dbInstanceIdentifier(instanceID))
@@ -284,17 +560,42 @@ func (b *RDSBroker) Bind(instanceID, bindingID string, details brokerapi.BindDet
 		return bindingResponse, err
 	}
 
-	if err = sqlEngine.Open(dbAddress, dbPort, dbName, masterUsername, b.masterPassword(instanceID)); err != nil {
+	if err = b.openAsMaster(sqlEngine, instanceID, dbAddress, dbPort, dbName, masterUsername); err != nil {
 		return bindingResponse, err
 	}
 	defer sqlEngine.Close()
 
-	dbUsername, dbPassword, err := sqlEngine.CreateUser(bindingID, dbName)
+	if servicePlan.RDSProperties.IAMDatabaseAuthentication {
+		dbUsername, err := sqlEngine.CreateIAMUser(bindingID, dbName)
+		if err != nil {
+			return bindingResponse, err
+		}
+
+		bindingResponse.Credentials = &IAMCredentialsHash{
+			Host:       dbAddress,
+			Port:       dbPort,
+			Name:       dbName,
+			Username:   dbUsername,
+			Region:     b.region,
+			ResourceID: dbInstanceDetails.DbiResourceId,
+		}
+
+		return bindingResponse, nil
+	}
+
+	userOptions := bindUserOptions(servicePlan, bindParameters)
+	if serviceKey {
+		userOptions = serviceKeyUserOptions(userOptions, *b.serviceKeys)
+	}
+	dbUsername, dbPassword, err := sqlEngine.CreateUser(bindingID, dbName, userOptions)
 	if err != nil {
+		if err == sqlengine.ErrExpiringUsersNotSupported || err == sqlengine.ErrUserSettingsNotSupported {
+			return bindingResponse, NewFailureResponse(err, http.StatusBadRequest, invalidParametersLogKey)
+		}
 		return bindingResponse, err
 	}
 
-	bindingResponse.Credentials = &brokerapi.CredentialsHash{
+	credentials := brokerapi.CredentialsHash{
 		Host:     dbAddress,
 		Port:     dbPort,
 		Name:     dbName,
@@ -304,9 +605,43 @@ func (b *RDSBroker) Bind(instanceID, bindingID string, details brokerapi.BindDet
 		JDBCURI:  sqlEngine.JDBCURI(dbAddress, dbPort, dbName, dbUsername, dbPassword),
 	}
 
+	if userOptions.ValidUntil.IsZero() {
+		bindingResponse.Credentials = &credentials
+	} else {
+		bindingResponse.Credentials = &ExpiringCredentialsHash{
+			CredentialsHash: credentials,
+			ExpiresAt:       &userOptions.ValidUntil,
+		}
+	}
+
 	return bindingResponse, nil
 }
 
+// bindUserOptions returns the limits of the user of a binding: the bind
+// parameters, or else the defaults of the plan.
+func bindUserOptions(servicePlan ServicePlan, bindParameters BindParameters) sqlengine.UserOptions {
+	options := sqlengine.UserOptions{
+		ConnectionLimit:                 servicePlan.RDSProperties.BindingConnectionLimit,
+		StatementTimeout:                servicePlan.RDSProperties.BindingStatementTimeout,
+		IdleInTransactionSessionTimeout: servicePlan.RDSProperties.BindingIdleInTransactionSessionTimeout,
+	}
+
+	if bindParameters.ExpiresIn > 0 {
+		options.ValidUntil = time.Now().UTC().Truncate(time.Second).Add(time.Duration(bindParameters.ExpiresIn) * time.Second)
+	}
+	if bindParameters.ConnectionLimit > 0 {
+		options.ConnectionLimit = bindParameters.ConnectionLimit
+	}
+	if bindParameters.StatementTimeout > 0 {
+		options.StatementTimeout = bindParameters.StatementTimeout
+	}
+	if bindParameters.IdleInTransactionSessionTimeout > 0 {
+		options.IdleInTransactionSessionTimeout = bindParameters.IdleInTransactionSessionTimeout
+	}
+
+	return options
+}
+
 func (b *RDSBroker) Unbind(instanceID, bindingID string, details brokerapi.UnbindDetails) error {
 	b.logger.Debug("unbind", lager.Data{
 		instanceIDLogKey: instanceID,
@@ -339,11 +674,15 @@ func (b *RDSBroker) Unbind(instanceID, bindingID string, details brokerapi.Unbin
 		return err
 	}
 
-	if err = sqlEngine.Open(dbAddress, dbPort, dbName, masterUsername, b.masterPassword(instanceID)); err != nil {
+	if err = b.openAsMaster(sqlEngine, instanceID, dbAddress, dbPort, dbName, masterUsername); err != nil {
 		return err
 	}
 	defer sqlEngine.Close()
 
+	if servicePlan.RDSProperties.IAMDatabaseAuthentication {
+		return sqlEngine.DropIAMUser(bindingID)
+	}
+
 	if err = sqlEngine.DropUser(bindingID); err != nil {
 		return err
 	}
@@ -356,7 +695,10 @@ func (b *RDSBroker) LastOperation(instanceID string) (brokerapi.LastOperationRes
 		instanceIDLogKey: instanceID,
 	})
 
-	lastOperationResponse := brokerapi.LastOperationResponse{State: brokerapi.LastOperationFailed}
+	lastOperationResponse, reported, err := b.workflowLastOperation(instanceID)
+	if reported || err != nil {
+		return lastOperationResponse, err
+	}
 
 	dbInstanceDetails, err := b.dbInstance.Describe(b.dbInstanceIdentifier(instanceID))
 	if err != nil {
@@ -377,6 +719,24 @@ func (b *RDSBroker) LastOperation(instanceID string) (brokerapi.LastOperationRes
 		lastOperationResponse.Description = fmt.Sprintf("DB Instance '%s' has pending modifications", b.dbInstanceIdentifier(instanceID))
 	}
 
+	if lastOperationResponse.State == brokerapi.LastOperationSucceeded {
+		dbSnapshots, err := b.dbInstance.DescribeInstanceSnapshots(b.dbInstanceIdentifier(instanceID))
+		if err != nil {
+			return lastOperationResponse, err
+		}
+		for _, dbSnapshot := range dbSnapshots {
+			if dbSnapshot.Status == "creating" {
+				lastOperationResponse.State = brokerapi.LastOperationInProgress
+				lastOperationResponse.Description = fmt.Sprintf("DB Snapshot '%s' is being created", dbSnapshot.Identifier)
+				break
+			}
+		}
+	}
+
+	if lastOperationResponse.State == brokerapi.LastOperationSucceeded && dbInstanceDetails.PendingReboot {
+		lastOperationResponse.Description = fmt.Sprintf("DB Instance '%s' status is '%s', but it must be rebooted to apply the static parameters of its DB parameter group", b.dbInstanceIdentifier(instanceID), dbInstanceDetails.Status)
+	}
+
 	return lastOperationResponse, nil
 }
 
@@ -391,41 +751,594 @@ func (b *RDSBroker) CheckAndRotateCredentials() {
 
 	b.logger.Debug(fmt.Sprintf("Found %v RDS instances managed by the broker", len(dbInstanceDetailsList)))
 
+	// Instances whose master password is derived from the previous seed, and
+	// instances which could not be checked and may still use it
+	previousSeedRotated, previousSeedRemaining, unchecked := 0, 0, 0
+
 	for _, dbDetails := range dbInstanceDetailsList {
+		if _, ok := dbDetails.Tags[deletedAtTag]; ok {
+			continue
+		}
+		// DB instances changing encryption may be renamed
+		if _, ok := dbDetails.Tags[encryptionChangeTag]; ok {
+			continue
+		}
+
 		b.logger.Debug(fmt.Sprintf("Checking credentials for instance %v", dbDetails.Identifier))
 		serviceInstanceID := b.dbInstanceIdentifierToServiceInstanceID(dbDetails.Identifier)
-		masterPassword := b.masterPassword(serviceInstanceID)
+		candidates, target, err := b.masterPasswordCandidates(serviceInstanceID, dbDetails.Tags)
+		if err != nil {
+			b.logger.Error(fmt.Sprintf("Could not obtain the master password of instance %v", dbDetails.Identifier), err)
+			unchecked++
+			continue
+		}
 		dbName := b.dbNameFromDetails(dbDetails.Identifier, *dbDetails)
 
 		sqlEngine, err := b.sqlProvider.GetSQLEngine(dbDetails.Engine)
 		if err != nil {
 			b.logger.Error(fmt.Sprintf("Could not determine SQL Engine of instance %v", dbDetails.Identifier), err)
+			unchecked++
 			continue
 		}
 
-		err = sqlEngine.Open(dbDetails.Address, dbDetails.Port, dbName, dbDetails.MasterUsername, masterPassword)
+		// Older versions and the previous seed are tried when login fails, in
+		// case the master password has not been moved to the latest ones yet
+		var current masterPasswordCandidate
+		for _, candidate := range candidates {
+			err = sqlEngine.Open(dbDetails.Address, dbDetails.Port, dbName, dbDetails.MasterUsername, candidate.password)
+			if err != sqlengine.LoginFailedError {
+				current = candidate
+				break
+			}
+		}
 
-		if err != nil {
-			if err == sqlengine.LoginFailedError {
-				b.logger.Info(fmt.Sprintf(
-					"Login failed when connecting to DB %v at %v. Will attempt to reset the password.",
-					dbName, dbDetails.Address))
-				dbDetails.MasterUserPassword = masterPassword
-				err = b.dbInstance.Modify(dbDetails.Identifier, *dbDetails, true)
-				if err != nil {
-					b.logger.Error(fmt.Sprintf("Could not reset the master password of instance %v", dbDetails.Identifier), err)
-					continue
+		if err == nil {
+			sqlEngine.Close()
+			if current == target {
+				if target.version != "" && dbDetails.Tags[masterPasswordVersionTag] != target.version {
+					b.setMasterPasswordVersion(dbDetails.Identifier, target.version)
 				}
-			} else {
-				b.logger.Error(fmt.Sprintf("Unknown error when connecting to DB %v at %v", dbName, dbDetails.Address), err)
 				continue
 			}
+			b.logger.Info(fmt.Sprintf(
+				"Master password of DB %v at %v uses version %v. Will attempt to move it to version %v.",
+				dbName, dbDetails.Address, current.version, target.version))
+		} else if err == sqlengine.LoginFailedError {
+			b.logger.Info(fmt.Sprintf(
+				"Login failed when connecting to DB %v at %v. Will attempt to reset the password.",
+				dbName, dbDetails.Address))
+		} else {
+			b.logger.Error(fmt.Sprintf("Unknown error when connecting to DB %v at %v", dbName, dbDetails.Address), err)
+			unchecked++
+			continue
+		}
+
+		// Only the password is sent, the other settings of the instance are kept
+		err = b.dbInstance.Modify(dbDetails.Identifier, awsrds.DBInstanceDetails{MasterUserPassword: target.password}, true)
+		if err != nil {
+			b.logger.Error(fmt.Sprintf("Could not reset the master password of instance %v", dbDetails.Identifier), err)
+			if current.previousSeed {
+				previousSeedRemaining++
+			}
+			continue
+		}
+		if target.version != "" {
+			b.setMasterPasswordVersion(dbDetails.Identifier, target.version)
+		}
+		if current.previousSeed {
+			previousSeedRotated++
+			b.logger.Info("master-password-seed-rotation", lager.Data{
+				"db-instance": dbDetails.Identifier,
+				"rotated":     previousSeedRotated,
+			})
+		}
+
+		// Changing master passwords briefly disrupts the connections to the
+		// DB instances, so they are changed at a controlled rate
+		time.Sleep(b.masterPasswordRotationDelay)
+	}
+
+	if b.previousMasterPasswordSeed != "" {
+		b.logger.Info("master-password-seed-rotation-progress", lager.Data{
+			"rotated":   previousSeedRotated,
+			"remaining": previousSeedRemaining,
+			"unchecked": unchecked,
+		})
+		if previousSeedRemaining == 0 && unchecked == 0 {
+			b.logger.Info("previous-master-password-seed-unused", lager.Data{
+				"message": "no DB instance uses the previous master password seed anymore, it can be removed from the configuration",
+			})
 		}
-		defer sqlEngine.Close()
 	}
 	b.logger.Info(fmt.Sprintf("Instances credentials check has ended"))
 }
 
+func (b *RDSBroker) setMasterPasswordVersion(dbInstanceIdentifier string, version string) {
+	err := b.dbInstance.AddTags(dbInstanceIdentifier, map[string]string{masterPasswordVersionTag: version})
+	if err != nil {
+		b.logger.Error(fmt.Sprintf("Could not tag the master password version of instance %v", dbInstanceIdentifier), err)
+	}
+}
+
+// parseParameters validates the user parameters against the plan and decodes
+// them into the given pointer to a parameters struct. Parameters still using
+// the deprecated casing are accepted, but a warning is logged.
+func (b *RDSBroker) parseParameters(parameters interface{}, servicePlan ServicePlan, userParameters map[string]interface{}) error {
+	emptyParameters := reflect.Indirect(reflect.ValueOf(parameters)).Interface()
+
+	userParameters, deprecated, err := NormalizeParameters(emptyParameters, userParameters)
+	if err != nil {
+		return b.invalidParameters(emptyParameters, err)
+	}
+	for _, name := range deprecated {
+		b.logger.Info("deprecated-parameter-name", lager.Data{
+			"parameter": name,
+			"accepted":  AcceptedParameters(emptyParameters),
+		})
+	}
+
+	if err := ValidateParameters(ParametersSchema(emptyParameters, servicePlan), userParameters); err != nil {
+		return NewFailureResponse(err, http.StatusBadRequest, invalidParametersLogKey)
+	}
+
+	if err := DecodeParameters(userParameters, parameters); err != nil {
+		return b.invalidParameters(emptyParameters, err)
+	}
+
+	return nil
+}
+
+// RebalanceWindows moves the backup and maintenance windows of the existing
+// instances to the ones given by the window scheduler, leaving alone the
+// windows explicitly chosen by the users. It returns the instances whose
+// windows have been changed.
+func (b *RDSBroker) RebalanceWindows() ([]WindowsAssignment, error) {
+	if b.windowScheduler == nil {
+		return nil, ErrWindowSchedulerNotConfigured
+	}
+
+	dbInstanceDetailsList, err := b.dbInstance.DescribeByTag("Broker Name", b.brokerName)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := []WindowsAssignment{}
+	for _, dbDetails := range dbInstanceDetailsList {
+		if _, ok := dbDetails.Tags[deletedAtTag]; ok {
+			continue
+		}
+		// DB instances changing encryption may be renamed
+		if _, ok := dbDetails.Tags[encryptionChangeTag]; ok {
+			continue
+		}
+
+		instanceID := b.dbInstanceIdentifierToServiceInstanceID(dbDetails.Identifier)
+
+		var backupWindow, maintenanceWindow string
+		if dbDetails.Tags[userBackupWindowTag] == "true" {
+			backupWindow = dbDetails.PreferredBackupWindow
+		}
+		if dbDetails.Tags[userMaintenanceWindowTag] == "true" {
+			maintenanceWindow = dbDetails.PreferredMaintenanceWindow
+		}
+
+		backupWindow, maintenanceWindow = b.windowScheduler.Schedule(instanceID, backupWindow, maintenanceWindow)
+		if backupWindow == "" {
+			backupWindow = dbDetails.PreferredBackupWindow
+		}
+		if maintenanceWindow == "" {
+			maintenanceWindow = dbDetails.PreferredMaintenanceWindow
+		}
+
+		if backupWindow == dbDetails.PreferredBackupWindow && maintenanceWindow == dbDetails.PreferredMaintenanceWindow {
+			continue
+		}
+
+		assignment := WindowsAssignment{
+			InstanceID:                 instanceID,
+			PreferredBackupWindow:      backupWindow,
+			PreferredMaintenanceWindow: maintenanceWindow,
+		}
+
+		// Only the windows are sent, the other settings of the instance,
+		// which may differ from its plan, are kept
+		modifyDBInstance := awsrds.DBInstanceDetails{
+			PreferredBackupWindow:      backupWindow,
+			PreferredMaintenanceWindow: maintenanceWindow,
+		}
+
+		b.logger.Info("rebalance-windows", lager.Data{
+			instanceIDLogKey:               instanceID,
+			"preferred-backup-window":      backupWindow,
+			"preferred-maintenance-window": maintenanceWindow,
+		})
+
+		if err := b.dbInstance.Modify(dbDetails.Identifier, modifyDBInstance, false); err != nil {
+			b.logger.Error("rebalance-windows", err, lager.Data{instanceIDLogKey: instanceID})
+			assignment.Error = err.Error()
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	return assignments, nil
+}
+
+// DeleteSoftDeletedInstances deletes the instances soft-deleted for longer than
+// the grace period. Instances still within it are stopped again if they are
+// running, as RDS automatically starts stopped instances after seven days.
+func (b *RDSBroker) DeleteSoftDeletedInstances() {
+	if b.softDeleteGracePeriod == 0 {
+		return
+	}
+
+	dbInstanceDetailsList, err := b.dbInstance.DescribeByTag("Broker Name", b.brokerName)
+	if err != nil {
+		b.logger.Error("delete-soft-deleted-instances", err)
+		return
+	}
+
+	for _, dbDetails := range dbInstanceDetailsList {
+		deletedAt, ok := dbDetails.Tags[deletedAtTag]
+		if !ok {
+			continue
+		}
+
+		logData := lager.Data{"db-instance": dbDetails.Identifier, "deleted-at": deletedAt}
+
+		deletedAtTime, err := time.Parse(time.RFC822Z, deletedAt)
+		if err != nil {
+			b.logger.Error("delete-soft-deleted-instances", err, logData)
+			continue
+		}
+
+		if time.Since(deletedAtTime) < b.softDeleteGracePeriod {
+			if dbDetails.Status == "available" {
+				b.logger.Info("stop-soft-deleted-instance", logData)
+				if err := b.dbInstance.Stop(dbDetails.Identifier); err != nil {
+					b.logger.Error("stop-soft-deleted-instance", err, logData)
+				}
+			}
+			continue
+		}
+
+		if dbDetails.Status == "deleting" {
+			continue
+		}
+
+		skipFinalSnapshot := false
+		servicePlan, planFound := b.catalog.FindServicePlan(dbDetails.Tags["Plan ID"])
+		if planFound {
+			skipFinalSnapshot = servicePlan.RDSProperties.SkipFinalSnapshot
+		}
+		if value, ok := dbDetails.Tags["SkipFinalSnapshot"]; ok {
+			if skipFinalSnapshot, err = strconv.ParseBool(value); err != nil {
+				b.logger.Error("delete-soft-deleted-instance", err, logData)
+				continue
+			}
+		}
+
+		b.logger.Info("delete-soft-deleted-instance", logData)
+		if err := b.dbInstance.Delete(dbDetails.Identifier, skipFinalSnapshot); err != nil {
+			b.logger.Error("delete-soft-deleted-instance", err, logData)
+			continue
+		}
+
+		instanceID := b.dbInstanceIdentifierToServiceInstanceID(dbDetails.Identifier)
+		if planFound {
+			if err := b.startGroupsDeletion(instanceID, servicePlan); err != nil {
+				b.logger.Error("delete-soft-deleted-instance", err, logData)
+			}
+		}
+		if skipFinalSnapshot {
+			if err := b.deleteMasterPassword(instanceID); err != nil {
+				b.logger.Error("delete-soft-deleted-instance", err, logData)
+			}
+		}
+	}
+}
+
+// UndeleteInstance restarts a soft-deleted instance and removes its deletion
+// tags, so that it is no longer deleted once the grace period is over.
+func (b *RDSBroker) UndeleteInstance(instanceID string) error {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(instanceID)
+
+	deletedAt, err := b.dbInstance.GetTag(dbInstanceIdentifier, deletedAtTag)
+	if err != nil {
+		if err == awsrds.ErrDBInstanceDoesNotExist {
+			return brokerapi.ErrInstanceDoesNotExist
+		}
+		return err
+	}
+	if deletedAt == "" {
+		return ErrInstanceNotSoftDeleted
+	}
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if dbInstanceDetails.Status == "stopped" {
+		if err := b.dbInstance.Start(dbInstanceIdentifier); err != nil {
+			return err
+		}
+	}
+
+	b.logger.Info("undelete-instance", lager.Data{instanceIDLogKey: instanceID})
+
+	return b.dbInstance.RemoveTags(dbInstanceIdentifier, []string{deletedAtTag, deletedByTag})
+}
+
+// validateManualSnapshot checks that the plan allows manual snapshots, and
+// that the snapshot is the only change requested.
+func validateManualSnapshot(servicePlan ServicePlan, updateParameters UpdateParameters, details brokerapi.UpdateDetails) error {
+	if servicePlan.RDSProperties.MaxManualSnapshots <= 0 {
+		err := fmt.Errorf("Manual snapshots are not enabled for Service Plan '%s'", servicePlan.ID)
+		return NewFailureResponse(err, http.StatusBadRequest, invalidParametersLogKey)
+	}
+
+	if details.PlanID != details.PreviousValues.PlanID || !reflect.DeepEqual(updateParameters, UpdateParameters{TakeSnapshot: true}) {
+		err := errors.New("take_snapshot cannot be combined with a plan change or other parameters")
+		return NewFailureResponse(err, http.StatusBadRequest, invalidParametersLogKey)
+	}
+
+	return nil
+}
+
+// takeManualSnapshot takes a snapshot requested by the user, and starts a
+// workflow deleting the oldest manual snapshots of the instance past the limit
+// of the plan once the new snapshot is available.
+func (b *RDSBroker) takeManualSnapshot(instanceID string, servicePlan ServicePlan, details brokerapi.UpdateDetails) error {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(instanceID)
+
+	tags := b.dbTags("Created", details.ServiceID, details.PlanID, details.PreviousValues.OrganizationID, details.PreviousValues.SpaceID, "")
+	tags[instanceIDTag] = instanceID
+	tags[snapshotTypeTag] = manualSnapshotType
+
+	snapshotID, err := b.dbInstance.CreateSnapshot(dbInstanceIdentifier, tags)
+	if err != nil {
+		return err
+	}
+	b.logger.Info("take-manual-snapshot", lager.Data{instanceIDLogKey: instanceID, "snapshot-id": snapshotID})
+
+	data := map[string]string{
+		snapshotIDData: snapshotID,
+		planIDData:     servicePlan.ID,
+	}
+	description := fmt.Sprintf("Taking manual DB Snapshot '%s' of DB Instance '%s'", snapshotID, dbInstanceIdentifier)
+	return b.workflows.Start(instanceID, manualSnapshotWorkflow, manualSnapshotStep, description, data)
+}
+
+// takePreChangeSnapshot takes a snapshot of the DB instance if the update
+// upgrades its major engine version or changes its instance class, and starts
+// a workflow applying the modification once the snapshot is available.
+func (b *RDSBroker) takePreChangeSnapshot(instanceID string, servicePlan ServicePlan, details brokerapi.UpdateDetails) (bool, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(instanceID)
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err != nil {
+		return false, err
+	}
+
+	classChange := servicePlan.RDSProperties.DBInstanceClass != "" && servicePlan.RDSProperties.DBInstanceClass != dbInstanceDetails.DBInstanceClass
+	majorVersionChange := servicePlan.RDSProperties.EngineVersion != "" && majorEngineVersion(servicePlan.RDSProperties.EngineVersion) != majorEngineVersion(dbInstanceDetails.EngineVersion)
+	if !classChange && !majorVersionChange {
+		return false, nil
+	}
+
+	tags := b.dbTags("Created", details.ServiceID, details.PreviousValues.PlanID, details.PreviousValues.OrganizationID, details.PreviousValues.SpaceID, "")
+	tags[instanceIDTag] = instanceID
+	tags[snapshotTypeTag] = preChangeSnapshotType
+
+	data, err := b.updateWorkflowData("", servicePlan, details)
+	if err != nil {
+		return false, err
+	}
+
+	snapshotID, err := b.dbInstance.CreateSnapshot(dbInstanceIdentifier, tags)
+	if err != nil {
+		return false, err
+	}
+	b.logger.Info("take-pre-change-snapshot", lager.Data{instanceIDLogKey: instanceID, "snapshot-id": snapshotID})
+
+	data[snapshotIDData] = snapshotID
+	description := fmt.Sprintf("Taking pre-change DB Snapshot '%s' before modifying DB Instance '%s'", snapshotID, dbInstanceIdentifier)
+	if err := b.workflows.Start(instanceID, preChangeSnapshotWorkflow, preChangeSnapshotStep, description, data); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// majorEngineVersion returns the first two components of an engine version,
+// which is how RDS tells major version upgrades apart.
+func majorEngineVersion(engineVersion string) string {
+	parts := strings.SplitN(engineVersion, ".", 3)
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, ".")
+}
+
+type byCreateTime []*awsrds.DBSnapshotDetails
+
+func (s byCreateTime) Len() int           { return len(s) }
+func (s byCreateTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byCreateTime) Less(i, j int) bool { return s[i].CreateTime.Before(s[j].CreateTime) }
+
+func (b *RDSBroker) softDeleteDBInstance(instanceID string) error {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(instanceID)
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err != nil {
+		return err
+	}
+
+	// Stopping an instance that is already stopping or stopped fails, which
+	// would prevent retrying a deprovision whose tagging failed.
+	if dbInstanceDetails.Status != "stopping" && dbInstanceDetails.Status != "stopped" {
+		if err := b.dbInstance.Stop(dbInstanceIdentifier); err != nil {
+			return err
+		}
+	}
+
+	return b.dbInstance.AddTags(dbInstanceIdentifier, map[string]string{
+		deletedByTag: "AWS RDS Service Broker",
+		deletedAtTag: time.Now().Format(time.RFC822Z),
+	})
+}
+
+// FinalSnapshots returns the final snapshots of the instances deleted by this
+// broker, with their expiry date.
+func (b *RDSBroker) FinalSnapshots() ([]FinalSnapshot, error) {
+	dbSnapshotDetailsList, err := b.dbInstance.DescribeSnapshots(b.dbInstanceIdentifier(""), "Broker Name", b.brokerName)
+	if err != nil {
+		return nil, err
+	}
+
+	finalSnapshots := []FinalSnapshot{}
+	for _, dbSnapshot := range dbSnapshotDetailsList {
+		if !dbSnapshot.FinalSnapshot {
+			continue
+		}
+
+		finalSnapshot := FinalSnapshot{
+			SnapshotID:     dbSnapshot.Identifier,
+			InstanceID:     b.dbInstanceIdentifierToServiceInstanceID(dbSnapshot.DBInstanceIdentifier),
+			OrganizationID: dbSnapshot.Tags["Organization ID"],
+			SpaceID:        dbSnapshot.Tags["Space ID"],
+			PlanID:         dbSnapshot.Tags["Plan ID"],
+			Status:         dbSnapshot.Status,
+			CreatedAt:      dbSnapshot.CreateTime,
+		}
+
+		if servicePlan, ok := b.catalog.FindServicePlan(finalSnapshot.PlanID); ok {
+			if retentionPeriod := servicePlan.RDSProperties.FinalSnapshotRetentionDays; retentionPeriod > 0 {
+				expiresAt := finalSnapshot.CreatedAt.AddDate(0, 0, int(retentionPeriod))
+				finalSnapshot.ExpiresAt = &expiresAt
+			}
+		}
+
+		finalSnapshots = append(finalSnapshots, finalSnapshot)
+	}
+
+	return finalSnapshots, nil
+}
+
+// DeleteExpiredFinalSnapshots deletes the final snapshots kept for longer than
+// the retention period of their plan, with the master password of their DB
+// instance.
+func (b *RDSBroker) DeleteExpiredFinalSnapshots() {
+	finalSnapshots, err := b.FinalSnapshots()
+	if err != nil {
+		b.logger.Error("delete-expired-final-snapshots", err)
+		return
+	}
+
+	for _, finalSnapshot := range finalSnapshots {
+		if finalSnapshot.ExpiresAt == nil || finalSnapshot.ExpiresAt.After(time.Now()) {
+			continue
+		}
+
+		if finalSnapshot.Status != "available" {
+			continue
+		}
+
+		logData := lager.Data{"snapshot-id": finalSnapshot.SnapshotID, "expires-at": finalSnapshot.ExpiresAt}
+		b.logger.Info("delete-expired-final-snapshot", logData)
+		if err := b.dbInstance.DeleteSnapshot(finalSnapshot.SnapshotID); err != nil {
+			b.logger.Error("delete-expired-final-snapshot", err, logData)
+			continue
+		}
+		if err := b.deleteMasterPassword(finalSnapshot.InstanceID); err != nil {
+			b.logger.Error("delete-expired-final-snapshot", err, logData)
+		}
+	}
+}
+
+// validateProvisionWindows checks that a backup or maintenance window set by
+// the user does not overlap with the other window of the new DB instance, as
+// scheduled or set by the plan.
+func (b *RDSBroker) validateProvisionWindows(instanceID string, servicePlan ServicePlan, backupWindow string, maintenanceWindow string) error {
+	if backupWindow == "" && maintenanceWindow == "" {
+		return nil
+	}
+
+	otherBackupWindow := servicePlan.RDSProperties.PreferredBackupWindow
+	otherMaintenanceWindow := servicePlan.RDSProperties.PreferredMaintenanceWindow
+	if b.windowScheduler != nil {
+		scheduledBackupWindow, scheduledMaintenanceWindow := b.windowScheduler.Schedule(instanceID, backupWindow, maintenanceWindow)
+		if scheduledBackupWindow != "" {
+			otherBackupWindow = scheduledBackupWindow
+		}
+		if scheduledMaintenanceWindow != "" {
+			otherMaintenanceWindow = scheduledMaintenanceWindow
+		}
+	}
+
+	return validateUserWindows(backupWindow, maintenanceWindow, otherBackupWindow, otherMaintenanceWindow)
+}
+
+// validateUpdateWindows checks that a backup or maintenance window set by the
+// user does not overlap with the other window of the DB instance, as it is or
+// as set by the new plan when there is no window scheduler.
+func (b *RDSBroker) validateUpdateWindows(instanceID string, servicePlan ServicePlan, backupWindow string, maintenanceWindow string) error {
+	if backupWindow == "" && maintenanceWindow == "" {
+		return nil
+	}
+
+	dbInstanceDetails, err := b.dbInstance.Describe(b.dbInstanceIdentifier(instanceID))
+	if err != nil {
+		if err == awsrds.ErrDBInstanceDoesNotExist {
+			return brokerapi.ErrInstanceDoesNotExist
+		}
+		return err
+	}
+
+	otherBackupWindow := dbInstanceDetails.PreferredBackupWindow
+	otherMaintenanceWindow := dbInstanceDetails.PreferredMaintenanceWindow
+	if b.windowScheduler == nil {
+		if servicePlan.RDSProperties.PreferredBackupWindow != "" {
+			otherBackupWindow = servicePlan.RDSProperties.PreferredBackupWindow
+		}
+		if servicePlan.RDSProperties.PreferredMaintenanceWindow != "" {
+			otherMaintenanceWindow = servicePlan.RDSProperties.PreferredMaintenanceWindow
+		}
+	}
+
+	return validateUserWindows(backupWindow, maintenanceWindow, otherBackupWindow, otherMaintenanceWindow)
+}
+
+// validateUserWindows checks the windows set by the user, completed with the
+// other windows the DB instance gets when they are valid.
+func validateUserWindows(backupWindow string, maintenanceWindow string, otherBackupWindow string, otherMaintenanceWindow string) error {
+	if backupWindow == "" {
+		if _, err := parseBackupWindow(otherBackupWindow); err == nil {
+			backupWindow = otherBackupWindow
+		}
+	}
+
+	if maintenanceWindow == "" {
+		if _, err := parseMaintenanceWindow(otherMaintenanceWindow); err == nil {
+			maintenanceWindow = otherMaintenanceWindow
+		}
+	}
+
+	if err := ValidateWindows(backupWindow, maintenanceWindow); err != nil {
+		return NewFailureResponse(err, http.StatusBadRequest, invalidParametersLogKey)
+	}
+
+	return nil
+}
+
+func (b *RDSBroker) invalidParameters(parameters interface{}, err error) error {
+	parametersError := ParametersError{
+		Errors:   []string{err.Error()},
+		Accepted: AcceptedParameters(parameters),
+	}
+	return NewFailureResponse(parametersError, http.StatusBadRequest, invalidParametersLogKey)
+}
+
 func (b *RDSBroker) dbInstanceIdentifier(instanceID string) string {
 	return fmt.Sprintf("%s-%s", strings.Replace(b.dbPrefix, "_", "-", -1), strings.Replace(instanceID, "_", "-", -1))
 }
@@ -438,10 +1351,6 @@ func (b *RDSBroker) masterUsername() string {
 	return utils.RandomAlphaNum(masterUsernameLength)
 }
 
-func (b *RDSBroker) masterPassword(instanceID string) string {
-	return utils.GetMD5B64(b.masterPasswordSeed+instanceID, masterPasswordLength)
-}
-
 func (b *RDSBroker) dbName(instanceID string) string {
 	return fmt.Sprintf("%s_%s", strings.Replace(b.dbPrefix, "-", "_", -1), strings.Replace(instanceID, "-", "_", -1))
 }
@@ -460,8 +1369,9 @@ func (b *RDSBroker) createDBInstance(instanceID string, servicePlan ServicePlan,
 	dbInstanceDetails := b.dbInstanceFromPlan(servicePlan)
 
 	dbInstanceDetails.DBName = b.dbName(instanceID)
+	dbInstanceDetails.DBParameterGroupName = b.dbParameterGroupName(instanceID, servicePlan)
+	dbInstanceDetails.OptionGroupName = b.optionGroupName(instanceID, servicePlan)
 	dbInstanceDetails.MasterUsername = b.masterUsername()
-	dbInstanceDetails.MasterUserPassword = b.masterPassword(instanceID)
 
 	if provisionParameters.BackupRetentionPeriod > 0 {
 		dbInstanceDetails.BackupRetentionPeriod = provisionParameters.BackupRetentionPeriod
@@ -483,12 +1393,23 @@ func (b *RDSBroker) createDBInstance(instanceID string, servicePlan ServicePlan,
 		dbInstanceDetails.PreferredMaintenanceWindow = provisionParameters.PreferredMaintenanceWindow
 	}
 
+	if b.windowScheduler != nil {
+		backupWindow, maintenanceWindow := b.windowScheduler.Schedule(instanceID, provisionParameters.PreferredBackupWindow, provisionParameters.PreferredMaintenanceWindow)
+		if backupWindow != "" {
+			dbInstanceDetails.PreferredBackupWindow = backupWindow
+		}
+		if maintenanceWindow != "" {
+			dbInstanceDetails.PreferredMaintenanceWindow = maintenanceWindow
+		}
+	}
+
 	skipFinalSnapshot := strconv.FormatBool(servicePlan.RDSProperties.SkipFinalSnapshot)
 	if provisionParameters.SkipFinalSnapshot != "" {
 		skipFinalSnapshot = provisionParameters.SkipFinalSnapshot
 	}
 
 	dbInstanceDetails.Tags = b.dbTags("Created", details.ServiceID, details.PlanID, details.OrganizationGUID, details.SpaceGUID, skipFinalSnapshot)
+	addUserWindowsTags(dbInstanceDetails.Tags, provisionParameters.PreferredBackupWindow, provisionParameters.PreferredMaintenanceWindow)
 
 	return dbInstanceDetails
 }
@@ -504,10 +1425,19 @@ func (b *RDSBroker) modifyDBInstance(instanceID string, servicePlan ServicePlan,
 		dbInstanceDetailsLogKey: dbInstanceDetails,
 	})
 
+	dbInstanceDetails.DBParameterGroupName = b.dbParameterGroupName(instanceID, servicePlan)
+	dbInstanceDetails.OptionGroupName = b.optionGroupName(instanceID, servicePlan)
+
 	if updateParameters.BackupRetentionPeriod > 0 {
 		dbInstanceDetails.BackupRetentionPeriod = updateParameters.BackupRetentionPeriod
 	}
 
+	if b.windowScheduler != nil {
+		// Keep the windows given by the scheduler instead of the plan ones
+		dbInstanceDetails.PreferredBackupWindow = ""
+		dbInstanceDetails.PreferredMaintenanceWindow = ""
+	}
+
 	if updateParameters.PreferredBackupWindow != "" {
 		dbInstanceDetails.PreferredBackupWindow = updateParameters.PreferredBackupWindow
 	}
@@ -522,6 +1452,7 @@ func (b *RDSBroker) modifyDBInstance(instanceID string, servicePlan ServicePlan,
 	}
 
 	dbInstanceDetails.Tags = b.dbTags("Updated", details.ServiceID, details.PlanID, "", "", skipFinalSnapshot)
+	addUserWindowsTags(dbInstanceDetails.Tags, updateParameters.PreferredBackupWindow, updateParameters.PreferredMaintenanceWindow)
 
 	return dbInstanceDetails
 }
@@ -532,13 +1463,17 @@ func (b *RDSBroker) dbInstanceFromPlan(servicePlan ServicePlan) *awsrds.DBInstan
 		Engine:          servicePlan.RDSProperties.Engine,
 	}
 
-	dbInstanceDetails.AutoMinorVersionUpgrade = servicePlan.RDSProperties.AutoMinorVersionUpgrade
+	dbInstanceDetails.AutoMinorVersionUpgrade = aws.Bool(servicePlan.RDSProperties.AutoMinorVersionUpgrade)
 
 	if servicePlan.RDSProperties.AvailabilityZone != "" {
 		dbInstanceDetails.AvailabilityZone = servicePlan.RDSProperties.AvailabilityZone
 	}
 
-	dbInstanceDetails.CopyTagsToSnapshot = servicePlan.RDSProperties.CopyTagsToSnapshot
+	dbInstanceDetails.CopyTagsToSnapshot = aws.Bool(servicePlan.RDSProperties.CopyTagsToSnapshot)
+
+	dbInstanceDetails.DeletionProtection = aws.Bool(servicePlan.RDSProperties.DeletionProtection)
+
+	dbInstanceDetails.IAMDatabaseAuthentication = aws.Bool(servicePlan.RDSProperties.IAMDatabaseAuthentication)
 
 	if servicePlan.RDSProperties.DBParameterGroupName != "" {
 		dbInstanceDetails.DBParameterGroupName = servicePlan.RDSProperties.DBParameterGroupName
@@ -588,7 +1523,7 @@ func (b *RDSBroker) dbInstanceFromPlan(servicePlan ServicePlan) *awsrds.DBInstan
 		dbInstanceDetails.LicenseModel = servicePlan.RDSProperties.LicenseModel
 	}
 
-	dbInstanceDetails.MultiAZ = servicePlan.RDSProperties.MultiAZ
+	dbInstanceDetails.MultiAZ = aws.Bool(servicePlan.RDSProperties.MultiAZ)
 
 	if servicePlan.RDSProperties.Port > 0 {
 		dbInstanceDetails.Port = servicePlan.RDSProperties.Port
@@ -644,3 +1579,15 @@ func (b *RDSBroker) dbTags(action, serviceID, planID, organizationID, spaceID, s
 
 	return tags
 }
+
+// addUserWindowsTags records the windows explicitly chosen by the user, so that
+// they are left untouched when rebalancing the windows.
+func addUserWindowsTags(tags map[string]string, backupWindow string, maintenanceWindow string) {
+	if backupWindow != "" {
+		tags[userBackupWindowTag] = "true"
+	}
+
+	if maintenanceWindow != "" {
+		tags[userMaintenanceWindowTag] = "true"
+	}
+}
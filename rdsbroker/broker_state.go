@@ -0,0 +1,77 @@
+package rdsbroker
+
+import (
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/sqlengine"
+)
+
+// ReencryptBrokerState encrypts the secrets kept in the broker state of every
+// DB instance with the active state encryption key. Once it reports no
+// failure, the other keys are no longer used and can be retired.
+func (b *RDSBroker) ReencryptBrokerState() {
+	b.updateDBInstances("reencrypt-broker-state", "reencrypted", sqlengine.SQLEngine.ReencryptState)
+}
+
+// MigrateBrokerState copies the broker state kept in every DB instance to the
+// state store. Once it reports no failure, the broker_state databases of the
+// DB instances are no longer used and can be dropped.
+func (b *RDSBroker) MigrateBrokerState() {
+	b.updateDBInstances("migrate-broker-state", "migrated", sqlengine.SQLEngine.MigrateState)
+}
+
+// updateDBInstances connects to every DB instance as master to update it, and
+// logs how many objects were updated and how many DB instances failed.
+func (b *RDSBroker) updateDBInstances(action string, countKey string, update func(sqlengine.SQLEngine) (int, error)) {
+	dbInstanceDetailsList, err := b.dbInstance.DescribeByTag("Broker Name", b.brokerName)
+	if err != nil {
+		b.logger.Error(action, err)
+		return
+	}
+
+	updated, failed := 0, 0
+	for _, dbDetails := range dbInstanceDetailsList {
+		if _, ok := dbDetails.Tags[deletedAtTag]; ok {
+			continue
+		}
+		// DB instances changing encryption may be renamed
+		if _, ok := dbDetails.Tags[encryptionChangeTag]; ok {
+			continue
+		}
+
+		logData := lager.Data{"db-instance": dbDetails.Identifier}
+		instanceID := b.dbInstanceIdentifierToServiceInstanceID(dbDetails.Identifier)
+
+		sqlEngine, err := b.sqlProvider.GetSQLEngine(dbDetails.Engine)
+		if err != nil {
+			b.logger.Error(action, err, logData)
+			failed++
+			continue
+		}
+
+		dbName := b.dbNameFromDetails(instanceID, *dbDetails)
+		if err := b.openAsMaster(sqlEngine, instanceID, dbDetails.Address, dbDetails.Port, dbName, dbDetails.MasterUsername); err != nil {
+			b.logger.Error(action, err, logData)
+			failed++
+			continue
+		}
+
+		count, err := update(sqlEngine)
+		sqlEngine.Close()
+		if err != nil {
+			b.logger.Error(action, err, logData)
+			failed++
+			continue
+		}
+		if count > 0 {
+			logData[countKey] = count
+			b.logger.Info(action, logData)
+		}
+		updated += count
+	}
+
+	b.logger.Info(action+"-finished", lager.Data{
+		countKey: updated,
+		"failed": failed,
+	})
+}
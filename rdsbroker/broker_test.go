@@ -2,7 +2,9 @@ package rdsbroker_test
 
 import (
 	"errors"
+	"net/http"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -15,22 +17,29 @@ import (
 
 	"github.com/alphagov/paas-rds-broker/awsrds"
 	rdsfake "github.com/alphagov/paas-rds-broker/awsrds/fakes"
+	"github.com/alphagov/paas-rds-broker/secretstore"
+	secretfake "github.com/alphagov/paas-rds-broker/secretstore/fakes"
 	"github.com/alphagov/paas-rds-broker/sqlengine"
 	sqlfake "github.com/alphagov/paas-rds-broker/sqlengine/fakes"
+	"github.com/alphagov/paas-rds-broker/workflow"
 )
 
 var _ = Describe("RDS Broker", func() {
 	var (
-		rdsProperties1 RDSProperties
-		rdsProperties2 RDSProperties
-		rdsProperties3 RDSProperties
-		plan1          ServicePlan
-		plan2          ServicePlan
-		plan3          ServicePlan
-		service1       Service
-		service2       Service
-		service3       Service
-		catalog        Catalog
+		rdsProperties1  RDSProperties
+		rdsProperties2  RDSProperties
+		rdsProperties3  RDSProperties
+		userParameters  *UserParameters
+		windowScheduler *WindowSchedulerConfig
+		softDelete      *SoftDeleteConfig
+		serviceKeys     *ServiceKeysConfig
+		plan1           ServicePlan
+		plan2           ServicePlan
+		plan3           ServicePlan
+		service1        Service
+		service2        Service
+		service3        Service
+		catalog         Catalog
 
 		config Config
 
@@ -39,6 +48,12 @@ var _ = Describe("RDS Broker", func() {
 		sqlProvider *sqlfake.FakeProvider
 		sqlEngine   *sqlfake.FakeSQLEngine
 
+		workflowStore       *workflow.MemoryStore
+		workflowStoreConfig *WorkflowStoreConfig
+
+		secretStore     secretstore.SecretStore
+		fakeSecretStore *secretfake.FakeSecretStore
+
 		testSink *lagertest.TestSink
 		logger   lager.Logger
 
@@ -52,6 +67,7 @@ var _ = Describe("RDS Broker", func() {
 		skipFinalSnapshot            bool
 		dbPrefix                     string
 		brokerName                   string
+		previousMasterPasswordSeed   string
 	)
 
 	const (
@@ -62,6 +78,10 @@ var _ = Describe("RDS Broker", func() {
 		dbName               = "cf_instance_id"
 		dbUsername           = "uvMSB820K_t3WvCX"
 		masterUserPassword   = "qOeiJ6AstR_mUQJxn6jyew=="
+		// Derived with the latest version, masterUserPassword with the first
+		latestMasterPassword = "nG19HrCrJrZBs2jngyajrcBJYk5c88Id"
+		// Derived from "old-secret" with the first version
+		previousMasterPassword = "XMqK1sB4X_sudgV6V5S9Fg=="
 	)
 
 	BeforeEach(func() {
@@ -73,9 +93,16 @@ var _ = Describe("RDS Broker", func() {
 		skipFinalSnapshot = true
 		dbPrefix = "cf"
 		brokerName = "mybroker"
+		previousMasterPasswordSeed = ""
 
 		dbInstance = &rdsfake.FakeDBInstance{}
 
+		workflowStore = workflow.NewMemoryStore()
+		workflowStoreConfig = &WorkflowStoreConfig{Type: "file", Path: "workflows"}
+
+		secretStore = nil
+		fakeSecretStore = &secretfake.FakeSecretStore{}
+
 		sqlProvider = &sqlfake.FakeProvider{}
 		sqlEngine = &sqlfake.FakeSQLEngine{}
 		sqlProvider.GetSQLEngineSQLEngine = sqlEngine
@@ -103,14 +130,20 @@ var _ = Describe("RDS Broker", func() {
 			AllocatedStorage:  300,
 			SkipFinalSnapshot: false,
 		}
+
+		userParameters = nil
+		windowScheduler = nil
+		softDelete = nil
+		serviceKeys = nil
 	})
 
 	JustBeforeEach(func() {
 		plan1 = ServicePlan{
-			ID:            "Plan-1",
-			Name:          "Plan 1",
-			Description:   "This is the Plan 1",
-			RDSProperties: rdsProperties1,
+			ID:             "Plan-1",
+			Name:           "Plan 1",
+			Description:    "This is the Plan 1",
+			RDSProperties:  rdsProperties1,
+			UserParameters: userParameters,
 		}
 		plan2 = ServicePlan{
 			ID:            "Plan-2",
@@ -159,66 +192,83 @@ var _ = Describe("RDS Broker", func() {
 			DBPrefix:                     dbPrefix,
 			BrokerName:                   brokerName,
 			MasterPasswordSeed:           masterPasswordSeed,
+			PreviousMasterPasswordSeed:   previousMasterPasswordSeed,
 			AllowUserProvisionParameters: allowUserProvisionParameters,
 			AllowUserUpdateParameters:    allowUserUpdateParameters,
 			AllowUserBindParameters:      allowUserBindParameters,
 			Catalog:                      catalog,
+			WindowScheduler:              windowScheduler,
+			SoftDelete:                   softDelete,
+			ServiceKeys:                  serviceKeys,
+			WorkflowStore:                workflowStoreConfig,
 		}
 
 		logger = lager.NewLogger("rdsbroker_test")
 		testSink = lagertest.NewTestSink()
 		logger.RegisterSink(testSink)
 
-		rdsBroker = New(config, dbInstance, sqlProvider, logger)
+		rdsBroker = New(config, dbInstance, sqlProvider, workflowStore, secretStore, logger)
 	})
 
 	var _ = Describe("Services", func() {
 		var (
-			properCatalogResponse brokerapi.CatalogResponse
+			properCatalogResponse CatalogResponse
 		)
 
 		BeforeEach(func() {
-			properCatalogResponse = brokerapi.CatalogResponse{
-				Services: []brokerapi.Service{
-					brokerapi.Service{
-						ID:             "Service-1",
-						Name:           "Service 1",
-						Description:    "This is the Service 1",
-						Bindable:       serviceBindable,
-						PlanUpdateable: planUpdateable,
-						Plans: []brokerapi.ServicePlan{
-							brokerapi.ServicePlan{
-								ID:          "Plan-1",
-								Name:        "Plan 1",
-								Description: "This is the Plan 1",
+			properCatalogResponse = CatalogResponse{
+				Services: []CatalogService{
+					CatalogService{
+						Service: brokerapi.Service{
+							ID:             "Service-1",
+							Name:           "Service 1",
+							Description:    "This is the Service 1",
+							Bindable:       serviceBindable,
+							PlanUpdateable: planUpdateable,
+						},
+						Plans: []CatalogServicePlan{
+							CatalogServicePlan{
+								ServicePlan: brokerapi.ServicePlan{
+									ID:          "Plan-1",
+									Name:        "Plan 1",
+									Description: "This is the Plan 1",
+								},
 							},
 						},
 					},
-					brokerapi.Service{
-						ID:             "Service-2",
-						Name:           "Service 2",
-						Description:    "This is the Service 2",
-						Bindable:       serviceBindable,
-						PlanUpdateable: planUpdateable,
-						Plans: []brokerapi.ServicePlan{
-							brokerapi.ServicePlan{
-								ID:          "Plan-2",
-								Name:        "Plan 2",
-								Description: "This is the Plan 2",
+					CatalogService{
+						Service: brokerapi.Service{
+							ID:             "Service-2",
+							Name:           "Service 2",
+							Description:    "This is the Service 2",
+							Bindable:       serviceBindable,
+							PlanUpdateable: planUpdateable,
+						},
+						Plans: []CatalogServicePlan{
+							CatalogServicePlan{
+								ServicePlan: brokerapi.ServicePlan{
+									ID:          "Plan-2",
+									Name:        "Plan 2",
+									Description: "This is the Plan 2",
+								},
 							},
 						},
 					},
-					brokerapi.Service{
-						ID:             "Service-3",
-						Name:           "Service 3",
-						Description:    "This is the Service 3",
-						Bindable:       serviceBindable,
-						PlanUpdateable: planUpdateable,
-						Plans: []brokerapi.ServicePlan{
-							brokerapi.ServicePlan{
-								ID:          "Plan-3",
-								Name:        "Plan 3",
-								Description: "This is the Plan 3",
+					CatalogService{
+						Service: brokerapi.Service{
+							ID:             "Service-3",
+							Name:           "Service 3",
+							Description:    "This is the Service 3",
+							Bindable:       serviceBindable,
+							PlanUpdateable: planUpdateable,
+						},
+						Plans: []CatalogServicePlan{
+							CatalogServicePlan{
+								ServicePlan: brokerapi.ServicePlan{
+									ID:          "Plan-3",
+									Name:        "Plan 3",
+									Description: "This is the Plan 3",
+								},
 							},
 						},
 					},
@@ -226,11 +276,47 @@ var _ = Describe("RDS Broker", func() {
 			}
 		})
 
-		It("returns the proper CatalogResponse", func() {
-			brokerCatalog := rdsBroker.Services()
-			Expect(brokerCatalog).To(Equal(properCatalogResponse))
+		Context("when user parameters are not allowed", func() {
+			BeforeEach(func() {
+				allowUserProvisionParameters = false
+				allowUserUpdateParameters = false
+				allowUserBindParameters = false
+			})
+
+			It("returns the proper CatalogResponse with no parameter schemas", func() {
+				brokerCatalog := rdsBroker.Services()
+				Expect(brokerCatalog).To(Equal(properCatalogResponse))
+			})
 		})
 
+		Context("when user parameters are allowed", func() {
+			It("returns the parameter schemas of every plan", func() {
+				brokerCatalog := rdsBroker.Services()
+				Expect(brokerCatalog.Services).To(HaveLen(3))
+				for _, service := range brokerCatalog.Services {
+					schemas := service.Plans[0].Schemas
+					Expect(schemas).ToNot(BeNil())
+					Expect(schemas.Instance.Create.Parameters["properties"]).To(HaveKey("skip_final_snapshot"))
+					Expect(schemas.Instance.Update.Parameters["properties"]).To(HaveKey("apply_immediately"))
+					Expect(schemas.Binding.Create.Parameters["properties"]).To(HaveKey("expires_in"))
+				}
+			})
+
+			Context("but only provision parameters", func() {
+				BeforeEach(func() {
+					allowUserUpdateParameters = false
+					allowUserBindParameters = false
+				})
+
+				It("only returns the provision schema", func() {
+					brokerCatalog := rdsBroker.Services()
+					schemas := brokerCatalog.Services[0].Plans[0].Schemas
+					Expect(schemas.Instance.Create).ToNot(BeNil())
+					Expect(schemas.Instance.Update).To(BeNil())
+					Expect(schemas.Binding.Create).To(BeNil())
+				})
+			})
+		})
 	})
 
 	var _ = Describe("Provision", func() {
@@ -269,7 +355,8 @@ var _ = Describe("RDS Broker", func() {
 			Expect(dbInstance.CreateDBInstanceDetails.Engine).To(Equal("test-engine-1"))
 			Expect(dbInstance.CreateDBInstanceDetails.DBName).To(Equal(dbName))
 			Expect(dbInstance.CreateDBInstanceDetails.MasterUsername).ToNot(BeEmpty())
-			Expect(dbInstance.CreateDBInstanceDetails.MasterUserPassword).To(Equal(masterUserPassword))
+			Expect(dbInstance.CreateDBInstanceDetails.MasterUserPassword).To(Equal(latestMasterPassword))
+			Expect(dbInstance.CreateDBInstanceDetails.Tags["Master password version"]).To(Equal("2"))
 			Expect(dbInstance.CreateDBInstanceDetails.Tags["Owner"]).To(Equal("Cloud Foundry"))
 			Expect(dbInstance.CreateDBInstanceDetails.Tags["Created by"]).To(Equal("AWS RDS Service Broker"))
 			Expect(dbInstance.CreateDBInstanceDetails.Tags).To(HaveKey("Created at"))
@@ -352,7 +439,7 @@ var _ = Describe("RDS Broker", func() {
 
 			It("makes the proper calls", func() {
 				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
-				Expect(dbInstance.CreateDBInstanceDetails.AutoMinorVersionUpgrade).To(BeTrue())
+				Expect(*dbInstance.CreateDBInstanceDetails.AutoMinorVersionUpgrade).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
@@ -434,7 +521,31 @@ var _ = Describe("RDS Broker", func() {
 
 			It("makes the proper calls", func() {
 				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
-				Expect(dbInstance.CreateDBInstanceDetails.CopyTagsToSnapshot).To(BeTrue())
+				Expect(*dbInstance.CreateDBInstanceDetails.CopyTagsToSnapshot).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when has DeletionProtection", func() {
+			BeforeEach(func() {
+				rdsProperties1.DeletionProtection = true
+			})
+
+			It("makes the proper calls", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(*dbInstance.CreateDBInstanceDetails.DeletionProtection).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when has IAMDatabaseAuthentication", func() {
+			BeforeEach(func() {
+				rdsProperties1.IAMDatabaseAuthentication = true
+			})
+
+			It("makes the proper calls", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(*dbInstance.CreateDBInstanceDetails.IAMDatabaseAuthentication).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
@@ -543,7 +654,7 @@ var _ = Describe("RDS Broker", func() {
 
 			It("makes the proper calls", func() {
 				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
-				Expect(dbInstance.CreateDBInstanceDetails.MultiAZ).To(BeTrue())
+				Expect(*dbInstance.CreateDBInstanceDetails.MultiAZ).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
@@ -586,12 +697,12 @@ var _ = Describe("RDS Broker", func() {
 			//FIXME: These tests are pending until we allow this user provided parameter
 			PContext("but has PreferredBackupWindow Parameter", func() {
 				BeforeEach(func() {
-					provisionDetails.Parameters = map[string]interface{}{"preferred_backup_window": "test-preferred-backup-window-parameter"}
+					provisionDetails.Parameters = map[string]interface{}{"preferred_backup_window": "03:00-03:30"}
 				})
 
 				It("makes the proper calls", func() {
 					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
-					Expect(dbInstance.CreateDBInstanceDetails.PreferredBackupWindow).To(Equal("test-preferred-backup-window-parameter"))
+					Expect(dbInstance.CreateDBInstanceDetails.PreferredBackupWindow).To(Equal("03:00-03:30"))
 					Expect(err).ToNot(HaveOccurred())
 				})
 			})
@@ -611,12 +722,12 @@ var _ = Describe("RDS Broker", func() {
 			//FIXME: These tests are pending until we allow this user provided parameter
 			PContext("but has PreferredMaintenanceWindow Parameter", func() {
 				BeforeEach(func() {
-					provisionDetails.Parameters = map[string]interface{}{"preferred_maintenance_window": "test-preferred-maintenance-window-parameter"}
+					provisionDetails.Parameters = map[string]interface{}{"preferred_maintenance_window": "sun:04:00-sun:04:30"}
 				})
 
 				It("makes the proper calls", func() {
 					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
-					Expect(dbInstance.CreateDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("test-preferred-maintenance-window-parameter"))
+					Expect(dbInstance.CreateDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("sun:04:00-sun:04:30"))
 					Expect(err).ToNot(HaveOccurred())
 				})
 			})
@@ -689,7 +800,41 @@ var _ = Describe("RDS Broker", func() {
 			It("returns the proper error", func() {
 				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("skip_final_snapshot must be set to true or false, or not set at all"))
+				Expect(err.Error()).To(ContainSubstring("skip_final_snapshot must be one of 'true', 'false'"))
+			})
+
+			It("returns a bad request error", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).To(BeAssignableToTypeOf(&FailureResponse{}))
+				Expect(err.(*FailureResponse).StatusCode()).To(Equal(http.StatusBadRequest))
+			})
+
+			Context("and a parameter has the wrong type", func() {
+				BeforeEach(func() {
+					provisionDetails.Parameters = map[string]interface{}{"backup_retention_period": "seven"}
+				})
+
+				It("returns a field level error", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("backup_retention_period must be an integer, got a string"))
+					Expect(dbInstance.CreateCalled).To(BeFalse())
+				})
+			})
+
+			Context("and a parameter is unknown", func() {
+				BeforeEach(func() {
+					provisionDetails.Parameters = map[string]interface{}{"backup_retention_periods": 7}
+				})
+
+				It("returns a bad request listing the accepted parameters", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(BeAssignableToTypeOf(&FailureResponse{}))
+					Expect(err.(*FailureResponse).StatusCode()).To(Equal(http.StatusBadRequest))
+					Expect(err.Error()).To(ContainSubstring("backup_retention_periods is not allowed"))
+					Expect(err.Error()).To(ContainSubstring("Accepted parameters: backup_retention_period, character_set_name, db_name"))
+					Expect(dbInstance.CreateCalled).To(BeFalse())
+				})
 			})
 
 			Context("and user provision parameters are not allowed", func() {
@@ -704,1099 +849,4423 @@ var _ = Describe("RDS Broker", func() {
 			})
 		})
 
-		Context("when Service Plan is not found", func() {
+		Context("when the windows Parameters are not valid", func() {
 			BeforeEach(func() {
-				provisionDetails.PlanID = "unknown"
+				provisionDetails.Parameters = map[string]interface{}{"preferred_backup_window": "03:00-03:10"}
 			})
 
-			It("returns the proper error", func() {
+			It("returns a bad request explaining the problem", func() {
 				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+				Expect(err).To(BeAssignableToTypeOf(&FailureResponse{}))
+				Expect(err.(*FailureResponse).StatusCode()).To(Equal(http.StatusBadRequest))
+				Expect(err).To(MatchError(ContainSubstring("preferred_backup_window must be at least 30 minutes long")))
+				Expect(dbInstance.CreateCalled).To(BeFalse())
 			})
-		})
 
-		Context("when creating the DB Instance fails", func() {
-			BeforeEach(func() {
-				dbInstance.CreateError = errors.New("operation failed")
-			})
+			Context("and the backup window overlaps the plan maintenance window", func() {
+				BeforeEach(func() {
+					rdsProperties1.PreferredMaintenanceWindow = "tue:03:00-tue:04:00"
+					provisionDetails.Parameters = map[string]interface{}{"preferred_backup_window": "03:30-04:30"}
+				})
 
-			It("returns the proper error", func() {
-				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("operation failed"))
+				It("returns a bad request explaining the problem", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(MatchError(ContainSubstring("preferred_backup_window '03:30-04:30' overlaps preferred_maintenance_window 'tue:03:00-tue:04:00'")))
+					Expect(dbInstance.CreateCalled).To(BeFalse())
+				})
 			})
-		})
-	})
-
-	var _ = Describe("Update", func() {
-		var (
-			updateDetails     brokerapi.UpdateDetails
-			acceptsIncomplete bool
-		)
-
-		BeforeEach(func() {
-			updateDetails = brokerapi.UpdateDetails{
-				ServiceID:  "Service-2",
-				PlanID:     "Plan-2",
-				Parameters: map[string]interface{}{},
-				PreviousValues: brokerapi.PreviousValues{
-					PlanID:         "Plan-1",
-					ServiceID:      "Service-1",
-					OrganizationID: "organization-id",
-					SpaceID:        "space-id",
-				},
-			}
-			acceptsIncomplete = true
-		})
 
-		It("returns the proper response", func() {
-			asynch, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-			Expect(asynch).To(BeTrue())
-			Expect(err).ToNot(HaveOccurred())
-		})
+			Context("and the backup window does not overlap the plan maintenance window", func() {
+				BeforeEach(func() {
+					rdsProperties1.PreferredMaintenanceWindow = "tue:03:00-tue:04:00"
+					provisionDetails.Parameters = map[string]interface{}{"preferred_backup_window": "04:00-04:30"}
+				})
 
-		It("makes the proper calls", func() {
-			_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-			Expect(dbInstance.ModifyCalled).To(BeTrue())
-			Expect(dbInstance.ModifyID).To(Equal(dbInstanceIdentifier))
-			Expect(dbInstance.ModifyDBInstanceDetails.DBInstanceClass).To(Equal("db.m2.test"))
-			Expect(dbInstance.ModifyDBInstanceDetails.Engine).To(Equal("test-engine-2"))
-			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Owner"]).To(Equal("Cloud Foundry"))
-			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Broker Name"]).To(Equal("mybroker"))
-			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Updated by"]).To(Equal("AWS RDS Service Broker"))
-			Expect(dbInstance.ModifyDBInstanceDetails.Tags).To(HaveKey("Updated at"))
-			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Service ID"]).To(Equal("Service-2"))
-			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Plan ID"]).To(Equal("Plan-2"))
-			Expect(err).ToNot(HaveOccurred())
+				It("makes the proper calls", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CreateDBInstanceDetails.PreferredBackupWindow).To(Equal("04:00-04:30"))
+					Expect(dbInstance.CreateDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("tue:03:00-tue:04:00"))
+				})
+			})
 		})
 
-		Context("when has AllocatedStorage", func() {
+		Context("when the window scheduler is configured", func() {
 			BeforeEach(func() {
-				rdsProperties2.AllocatedStorage = int64(100)
+				rdsProperties1.PreferredBackupWindow = "03:00-03:30"
+				rdsProperties1.PreferredMaintenanceWindow = "sun:04:00-sun:04:30"
+				windowScheduler = &WindowSchedulerConfig{
+					BackupWindowRanges:      []string{"01:00-02:00"},
+					MaintenanceWindowRanges: []string{"tue:01:00-tue:02:00"},
+					WindowDuration:          30,
+				}
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.AllocatedStorage).To(Equal(int64(100)))
+			It("schedules the windows of the instance", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.CreateDBInstanceDetails.PreferredBackupWindow).To(HavePrefix("01:"))
+				Expect(dbInstance.CreateDBInstanceDetails.PreferredMaintenanceWindow).To(HavePrefix("tue:01:"))
+				Expect(dbInstance.CreateDBInstanceDetails.Tags).ToNot(HaveKey("UserPreferredBackupWindow"))
+				Expect(dbInstance.CreateDBInstanceDetails.Tags).ToNot(HaveKey("UserPreferredMaintenanceWindow"))
 			})
-		})
 
-		Context("when has AutoMinorVersionUpgrade", func() {
-			BeforeEach(func() {
-				rdsProperties2.AutoMinorVersionUpgrade = true
+			Context("but the user sets a window", func() {
+				BeforeEach(func() {
+					provisionDetails.Parameters = map[string]interface{}{"preferred_backup_window": "05:00-05:30"}
+				})
+
+				It("keeps the window set by the user", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CreateDBInstanceDetails.PreferredBackupWindow).To(Equal("05:00-05:30"))
+					Expect(dbInstance.CreateDBInstanceDetails.PreferredMaintenanceWindow).To(HavePrefix("tue:01:"))
+					Expect(dbInstance.CreateDBInstanceDetails.Tags).To(HaveKeyWithValue("UserPreferredBackupWindow", "true"))
+				})
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.AutoMinorVersionUpgrade).To(BeTrue())
-				Expect(err).ToNot(HaveOccurred())
+			Context("but the user sets a window overlapping the plan window", func() {
+				BeforeEach(func() {
+					provisionDetails.Parameters = map[string]interface{}{"preferred_backup_window": "04:00-04:30"}
+				})
+
+				It("checks it against the scheduled window instead", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CreateDBInstanceDetails.PreferredBackupWindow).To(Equal("04:00-04:30"))
+					Expect(dbInstance.CreateDBInstanceDetails.PreferredMaintenanceWindow).To(HavePrefix("tue:01:"))
+				})
 			})
 		})
 
-		Context("when has AvailabilityZone", func() {
+		Context("when Parameters use the deprecated casing", func() {
 			BeforeEach(func() {
-				rdsProperties2.AvailabilityZone = "test-az"
+				provisionDetails.Parameters = map[string]interface{}{
+					"BackupRetentionPeriod": 7,
+					"SkipFinalSnapshot":     "true",
+				}
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.AvailabilityZone).To(Equal("test-az"))
+			It("still accepts them", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.CreateDBInstanceDetails.BackupRetentionPeriod).To(Equal(int64(7)))
+				Expect(dbInstance.CreateDBInstanceDetails.Tags["SkipFinalSnapshot"]).To(Equal("true"))
 			})
 		})
 
-		Context("when has BackupRetentionPeriod", func() {
+		Context("when the plan restricts the user parameters", func() {
 			BeforeEach(func() {
-				rdsProperties2.BackupRetentionPeriod = int64(7)
+				maxBackupRetentionPeriod := int64(7)
+				userParameters = &UserParameters{
+					Provision: map[string]ParameterConstraints{
+						"backup_retention_period": ParameterConstraints{Maximum: &maxBackupRetentionPeriod},
+					},
+				}
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.BackupRetentionPeriod).To(Equal(int64(7)))
+			It("accepts parameters within the plan bounds", func() {
+				provisionDetails.Parameters = map[string]interface{}{"backup_retention_period": 7}
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.CreateDBInstanceDetails.BackupRetentionPeriod).To(Equal(int64(7)))
 			})
 
-			//FIXME: These tests are pending until we allow this user provided parameter
-			PContext("but has BackupRetentionPeriod Parameter", func() {
+			It("rejects parameters out of the plan bounds", func() {
+				provisionDetails.Parameters = map[string]interface{}{"backup_retention_period": 35}
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).To(MatchError(ContainSubstring("backup_retention_period must be less than or equal to 7")))
+				Expect(dbInstance.CreateCalled).To(BeFalse())
+			})
+
+			It("rejects parameters not in the plan allow-list", func() {
+				provisionDetails.Parameters = map[string]interface{}{"db_name": "mydb"}
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).To(MatchError(ContainSubstring("db_name is not allowed")))
+				Expect(dbInstance.CreateCalled).To(BeFalse())
+			})
+
+			Context("and user provision parameters are not allowed broker wide", func() {
 				BeforeEach(func() {
-					updateDetails.Parameters = map[string]interface{}{"backup_retention_period": 12}
+					allowUserProvisionParameters = false
 				})
 
-				It("makes the proper calls", func() {
-					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-					Expect(dbInstance.ModifyDBInstanceDetails.BackupRetentionPeriod).To(Equal(int64(12)))
+				It("still accepts the parameters allowed by the plan", func() {
+					provisionDetails.Parameters = map[string]interface{}{"backup_retention_period": 3}
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
 					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CreateDBInstanceDetails.BackupRetentionPeriod).To(Equal(int64(3)))
 				})
 			})
 		})
 
-		Context("when has CharacterSetName", func() {
+		Context("when Service Plan is not found", func() {
 			BeforeEach(func() {
-				rdsProperties2.CharacterSetName = "test-characterset-name"
+				provisionDetails.PlanID = "unknown"
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.CharacterSetName).To(Equal("test-characterset-name"))
-				Expect(err).ToNot(HaveOccurred())
+			It("returns the proper error", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
 			})
 		})
 
-		Context("when has CopyTagsToSnapshot", func() {
+		Context("when creating the DB Instance fails", func() {
 			BeforeEach(func() {
-				rdsProperties2.CopyTagsToSnapshot = true
-			})
-
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.CopyTagsToSnapshot).To(BeTrue())
-				Expect(err).ToNot(HaveOccurred())
+				dbInstance.CreateError = errors.New("operation failed")
 			})
-		})
 
-		Context("when has DBParameterGroupName", func() {
-			BeforeEach(func() {
-				rdsProperties2.DBParameterGroupName = "test-db-parameter-group-name"
+			It("returns the proper error", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("operation failed"))
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.DBParameterGroupName).To(Equal("test-db-parameter-group-name"))
-				Expect(err).ToNot(HaveOccurred())
+			It("does not delete the groups of the plan", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(dbInstance.DeleteParameterGroupCalled).To(BeFalse())
+				Expect(dbInstance.DeleteOptionGroupCalled).To(BeFalse())
 			})
 		})
 
-		Context("when has DBSecurityGroups", func() {
+		Context("when the plan has post-provision SQL", func() {
 			BeforeEach(func() {
-				rdsProperties2.DBSecurityGroups = []string{"test-db-security-group"}
+				rdsProperties1.PostProvisionSQL = []string{"CREATE EXTENSION postgis"}
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.DBSecurityGroups).To(Equal([]string{"test-db-security-group"}))
+			It("starts the post-provision workflow", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+
+				w, err := workflowStore.Get(instanceID)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("post-provision"))
+				Expect(w.Step).To(Equal("sql"))
+				Expect(w.State).To(Equal(workflow.StateInProgress))
+				Expect(w.Data).To(Equal(map[string]string{"plan_id": "Plan-1"}))
 			})
-		})
 
-		Context("when has DBSubnetGroupName", func() {
-			BeforeEach(func() {
-				rdsProperties2.DBSubnetGroupName = "test-db-subnet-group-name"
+			Context("when creating the DB Instance fails", func() {
+				BeforeEach(func() {
+					dbInstance.CreateError = errors.New("operation failed")
+				})
+
+				It("forgets the post-provision workflow", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(HaveOccurred())
+
+					_, err = workflowStore.Get(instanceID)
+					Expect(err).To(Equal(workflow.ErrNotFound))
+				})
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.DBSubnetGroupName).To(Equal("test-db-subnet-group-name"))
-				Expect(err).ToNot(HaveOccurred())
+			Context("when the workflow store is not configured", func() {
+				BeforeEach(func() {
+					workflowStoreConfig = nil
+				})
+
+				It("refuses to provision the DB instance", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(Equal(ErrWorkflowStoreNotConfigured))
+					Expect(dbInstance.CreateCalled).To(BeFalse())
+				})
 			})
 		})
 
-		Context("when has EngineVersion", func() {
+		Context("when the plan allows user DB parameters", func() {
 			BeforeEach(func() {
-				rdsProperties2.EngineVersion = "1.2.3"
+				maximum := int64(1024)
+				rdsProperties1.DBParameterGroupName = "plan-group"
+				rdsProperties1.UserDBParameters = map[string]ParameterConstraints{
+					"max_connections": ParameterConstraints{Maximum: &maximum},
+					"sql_mode":        ParameterConstraints{},
+				}
+				provisionDetails.Parameters = map[string]interface{}{
+					"db_parameters": map[string]interface{}{
+						"max_connections": float64(200),
+						"sql_mode":        "STRICT_ALL_TABLES",
+					},
+				}
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.EngineVersion).To(Equal("1.2.3"))
+			It("creates a DB parameter group for the instance", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
 				Expect(err).ToNot(HaveOccurred())
-			})
-		})
+				Expect(dbInstance.CopyParameterGroupSourceID).To(Equal("plan-group"))
+				Expect(dbInstance.CopyParameterGroupID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.CopyParameterGroupTags["Plan ID"]).To(Equal("Plan-1"))
+				Expect(dbInstance.ModifyParameterGroupID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.ModifyParameterGroupParameters).To(Equal(map[string]string{
+					"max_connections": "200",
+					"sql_mode":        "STRICT_ALL_TABLES",
+				}))
+				Expect(dbInstance.CreateDBInstanceDetails.DBParameterGroupName).To(Equal(dbInstanceIdentifier))
+			})
+
+			Context("when a DB parameter is out of bounds", func() {
+				BeforeEach(func() {
+					provisionDetails.Parameters = map[string]interface{}{
+						"db_parameters": map[string]interface{}{"max_connections": float64(2000)},
+					}
+				})
 
-		Context("when has Iops", func() {
-			BeforeEach(func() {
-				rdsProperties2.Iops = int64(1000)
+				It("returns the proper error", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("Invalid parameters: db_parameters entry 'max_connections' must be less than or equal to 1024"))
+					Expect(dbInstance.CopyParameterGroupCalled).To(BeFalse())
+				})
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.Iops).To(Equal(int64(1000)))
-				Expect(err).ToNot(HaveOccurred())
+			Context("when a DB parameter is not allowed", func() {
+				BeforeEach(func() {
+					provisionDetails.Parameters = map[string]interface{}{
+						"db_parameters": map[string]interface{}{"shared_buffers": float64(2000)},
+					}
+				})
+
+				It("returns the proper error", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("Invalid parameters: db_parameters entry 'shared_buffers' is not allowed"))
+				})
 			})
-		})
 
-		Context("when storage encryption settings are updated", func() {
-			Context("when tries to enable StorageEncrypted", func() {
+			Context("when setting the DB parameters fails", func() {
 				BeforeEach(func() {
-					rdsProperties1.StorageEncrypted = false
-					rdsProperties2.StorageEncrypted = true
+					dbInstance.ModifyParameterGroupError = errors.New("operation failed")
 				})
 
-				It("fails noisily", func() {
-					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				It("does not create the DB Instance", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("Could not set the parameters of DB parameter group '" + dbInstanceIdentifier + "': operation failed"))
+					Expect(dbInstance.CreateCalled).To(BeFalse())
+				})
+
+				It("deletes the DB parameter group", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
 					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(ErrEncryptionNotUpdateable))
+					Expect(dbInstance.DeleteParameterGroupID).To(Equal(dbInstanceIdentifier))
 				})
 			})
-			Context("when tries to disable StorageEncrypted", func() {
+
+			Context("when creating the DB Instance fails", func() {
 				BeforeEach(func() {
-					rdsProperties1.StorageEncrypted = true
-					rdsProperties2.StorageEncrypted = false
+					dbInstance.CreateError = errors.New("operation failed")
 				})
 
-				It("fails noisily", func() {
-					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(ErrEncryptionNotUpdateable))
+				It("deletes the DB parameter group", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("operation failed"))
+					Expect(dbInstance.DeleteParameterGroupID).To(Equal(dbInstanceIdentifier))
+				})
+
+				Context("and deleting the DB parameter group fails", func() {
+					BeforeEach(func() {
+						dbInstance.DeleteParameterGroupError = errors.New("delete failed")
+					})
+
+					It("returns the error of the DB Instance", func() {
+						_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+						Expect(err).To(MatchError("operation failed"))
+					})
 				})
 			})
-			Context("when changes KmsKeyID with StorageEncrypted enabled", func() {
+		})
+
+		Context("when the plan allows user options", func() {
+			BeforeEach(func() {
+				rdsProperties1.UserOptions = map[string]OptionConstraints{
+					"MARIADB_AUDIT_PLUGIN": OptionConstraints{
+						Settings: map[string]ParameterConstraints{
+							"SERVER_AUDIT_EVENTS": ParameterConstraints{Enum: []string{"CONNECT", "QUERY"}},
+						},
+					},
+					"MEMCACHED": OptionConstraints{Port: 11211, VpcSecurityGroupIDs: []string{"sg-1"}},
+				}
+				provisionDetails.Parameters = map[string]interface{}{
+					"options": map[string]interface{}{
+						"MARIADB_AUDIT_PLUGIN": map[string]interface{}{"SERVER_AUDIT_EVENTS": "CONNECT"},
+						"MEMCACHED":            map[string]interface{}{},
+					},
+				}
+			})
+
+			It("creates an option group for the instance", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.CreateOptionGroupID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.CreateOptionGroupEngine).To(Equal("test-engine-1"))
+				Expect(dbInstance.CreateOptionGroupMajorEngineVersion).To(Equal("1.2"))
+				Expect(dbInstance.CreateOptionGroupTags["Plan ID"]).To(Equal("Plan-1"))
+				Expect(dbInstance.ModifyOptionGroupID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.ModifyOptionGroupOptionsToInclude).To(Equal([]awsrds.DBOption{
+					awsrds.DBOption{
+						Name:     "MARIADB_AUDIT_PLUGIN",
+						Settings: map[string]string{"SERVER_AUDIT_EVENTS": "CONNECT"},
+					},
+					awsrds.DBOption{
+						Name:                "MEMCACHED",
+						Settings:            map[string]string{},
+						Port:                11211,
+						VpcSecurityGroupIds: []string{"sg-1"},
+					},
+				}))
+				Expect(dbInstance.ModifyOptionGroupOptionsToRemove).To(BeEmpty())
+				Expect(dbInstance.CreateDBInstanceDetails.OptionGroupName).To(Equal(dbInstanceIdentifier))
+			})
+
+			Context("and the plan has an option group", func() {
 				BeforeEach(func() {
-					rdsProperties1.StorageEncrypted = true
-					rdsProperties2.StorageEncrypted = true
-					rdsProperties2.KmsKeyID = "test-old-kms-key-id"
-					rdsProperties2.KmsKeyID = "test-new-kms-key-id"
+					rdsProperties1.OptionGroupName = "plan-option-group"
 				})
 
-				It("fails noisily", func() {
-					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(ErrEncryptionNotUpdateable))
+				It("copies the option group of the plan for the instance", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CreateOptionGroupCalled).To(BeFalse())
+					Expect(dbInstance.CopyOptionGroupSourceID).To(Equal("plan-option-group"))
+					Expect(dbInstance.CopyOptionGroupID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.CopyOptionGroupTags["Plan ID"]).To(Equal("Plan-1"))
+					Expect(dbInstance.ModifyOptionGroupID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.CreateDBInstanceDetails.OptionGroupName).To(Equal(dbInstanceIdentifier))
+				})
+			})
+
+			Context("when an option setting is not allowed", func() {
+				BeforeEach(func() {
+					provisionDetails.Parameters = map[string]interface{}{
+						"options": map[string]interface{}{
+							"MARIADB_AUDIT_PLUGIN": map[string]interface{}{"SERVER_AUDIT_LOGGING": "OFF"},
+						},
+					}
+				})
+
+				It("returns the proper error", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("Invalid parameters: options entry 'MARIADB_AUDIT_PLUGIN' entry 'SERVER_AUDIT_LOGGING' is not allowed"))
+					Expect(dbInstance.CreateOptionGroupCalled).To(BeFalse())
 				})
 			})
 
+			Context("when setting the options fails", func() {
+				BeforeEach(func() {
+					dbInstance.ModifyOptionGroupError = errors.New("operation failed")
+				})
+
+				It("does not create the DB Instance", func() {
+					_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("Could not set the options of option group '" + dbInstanceIdentifier + "': operation failed"))
+					Expect(dbInstance.CreateCalled).To(BeFalse())
+				})
+			})
 		})
 
-		Context("when has LicenseModel", func() {
+		Context("when extensions are requested", func() {
 			BeforeEach(func() {
-				rdsProperties2.LicenseModel = "test-license-model"
+				rdsProperties1.AllowedExtensions = []string{"postgis", "pg_trgm"}
+				provisionDetails.Parameters = map[string]interface{}{
+					"extensions": []interface{}{"postgis", "pg_trgm", "postgis"},
+				}
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.LicenseModel).To(Equal("test-license-model"))
+			It("starts the post-provision workflow to create them", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+
+				w, err := workflowStore.Get(instanceID)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("post-provision"))
+				Expect(w.Data).To(Equal(map[string]string{
+					"plan_id":    "Plan-1",
+					"extensions": "pg_trgm postgis",
+				}))
 			})
 		})
 
-		Context("when has MultiAZ", func() {
+		It("does not start a workflow when the plan has no post-provision SQL", func() {
+			_, _, err := rdsBroker.Provision(instanceID, provisionDetails, acceptsIncomplete)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workflowStore.Get(instanceID)
+			Expect(err).To(Equal(workflow.ErrNotFound))
+		})
+	})
+
+	var _ = Describe("Provision with a master password store", func() {
+		var (
+			provisionDetails brokerapi.ProvisionDetails
+		)
+
+		BeforeEach(func() {
+			secretStore = fakeSecretStore
+
+			provisionDetails = brokerapi.ProvisionDetails{
+				OrganizationGUID: "organization-id",
+				PlanID:           "Plan-1",
+				ServiceID:        "Service-1",
+				SpaceGUID:        "space-id",
+				Parameters:       map[string]interface{}{},
+			}
+		})
+
+		It("creates the DB instance with a random master password kept in the store", func() {
+			_, _, err := rdsBroker.Provision(instanceID, provisionDetails, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeSecretStore.CreateCalled).To(BeTrue())
+			Expect(fakeSecretStore.CreateName).To(Equal(dbInstanceIdentifier))
+			Expect(fakeSecretStore.CreateValue).To(HaveLen(32))
+			Expect(fakeSecretStore.CreateValue).ToNot(Equal(masterUserPassword))
+			Expect(fakeSecretStore.CreateTags["Instance ID"]).To(Equal(instanceID))
+			Expect(fakeSecretStore.CreateTags["Broker Name"]).To(Equal(brokerName))
+			Expect(fakeSecretStore.CreateTags["Space ID"]).To(Equal("space-id"))
+			Expect(dbInstance.CreateDBInstanceDetails.MasterUserPassword).To(Equal(fakeSecretStore.CreateValue))
+		})
+
+		Context("when storing the master password fails", func() {
 			BeforeEach(func() {
-				rdsProperties2.MultiAZ = true
+				fakeSecretStore.CreateError = errors.New("operation failed")
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.MultiAZ).To(BeTrue())
-				Expect(err).ToNot(HaveOccurred())
+			It("does not create the DB instance", func() {
+				_, _, err := rdsBroker.Provision(instanceID, provisionDetails, true)
+				Expect(err).To(MatchError("operation failed"))
+				Expect(dbInstance.CreateCalled).To(BeFalse())
 			})
 		})
+	})
 
-		Context("when has OptionGroupName", func() {
+	var _ = Describe("Update", func() {
+		var (
+			updateDetails     brokerapi.UpdateDetails
+			acceptsIncomplete bool
+		)
+
+		BeforeEach(func() {
+			updateDetails = brokerapi.UpdateDetails{
+				ServiceID:  "Service-2",
+				PlanID:     "Plan-2",
+				Parameters: map[string]interface{}{},
+				PreviousValues: brokerapi.PreviousValues{
+					PlanID:         "Plan-1",
+					ServiceID:      "Service-1",
+					OrganizationID: "organization-id",
+					SpaceID:        "space-id",
+				},
+			}
+			acceptsIncomplete = true
+		})
+
+		It("returns the proper response", func() {
+			asynch, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+			Expect(asynch).To(BeTrue())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("makes the proper calls", func() {
+			_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+			Expect(dbInstance.ModifyCalled).To(BeTrue())
+			Expect(dbInstance.ModifyID).To(Equal(dbInstanceIdentifier))
+			Expect(dbInstance.ModifyDBInstanceDetails.DBInstanceClass).To(Equal("db.m2.test"))
+			Expect(dbInstance.ModifyDBInstanceDetails.Engine).To(Equal("test-engine-2"))
+			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Owner"]).To(Equal("Cloud Foundry"))
+			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Broker Name"]).To(Equal("mybroker"))
+			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Updated by"]).To(Equal("AWS RDS Service Broker"))
+			Expect(dbInstance.ModifyDBInstanceDetails.Tags).To(HaveKey("Updated at"))
+			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Service ID"]).To(Equal("Service-2"))
+			Expect(dbInstance.ModifyDBInstanceDetails.Tags["Plan ID"]).To(Equal("Plan-2"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the plan allows user DB parameters", func() {
 			BeforeEach(func() {
-				rdsProperties2.OptionGroupName = "test-option-group-name"
+				rdsProperties2.DBParameterGroupName = "plan-group"
+				rdsProperties2.UserDBParameters = map[string]ParameterConstraints{
+					"work_mem": ParameterConstraints{},
+				}
+				updateDetails.Parameters = map[string]interface{}{
+					"db_parameters": map[string]interface{}{"work_mem": "8MB"},
+				}
 			})
 
-			It("makes the proper calls", func() {
+			It("gives the instance its own DB parameter group", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.OptionGroupName).To(Equal("test-option-group-name"))
 				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.CopyParameterGroupSourceID).To(Equal("plan-group"))
+				Expect(dbInstance.CopyParameterGroupID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.ModifyParameterGroupParameters).To(Equal(map[string]string{"work_mem": "8MB"}))
+				Expect(dbInstance.ModifyDBInstanceDetails.DBParameterGroupName).To(Equal(dbInstanceIdentifier))
+			})
+
+			Context("and the instance already has its own DB parameter group", func() {
+				BeforeEach(func() {
+					rdsProperties1.DBParameterGroupName = "plan-group"
+					rdsProperties1.UserDBParameters = rdsProperties2.UserDBParameters
+				})
+
+				It("only sets the DB parameters", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CopyParameterGroupCalled).To(BeFalse())
+					Expect(dbInstance.ModifyParameterGroupID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.ModifyParameterGroupParameters).To(Equal(map[string]string{"work_mem": "8MB"}))
+				})
+
+				Context("copied from another DB parameter group", func() {
+					BeforeEach(func() {
+						rdsProperties1.DBParameterGroupName = "other-plan-group"
+					})
+
+					It("returns the proper error", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).To(MatchError("DB Instance '" + dbInstanceIdentifier + "' has its own DB parameter group, copied from 'other-plan-group', which cannot be replaced by a copy of 'plan-group'"))
+						Expect(dbInstance.ModifyCalled).To(BeFalse())
+					})
+				})
 			})
 		})
 
-		Context("when has Port", func() {
+		Context("when the instance has its own DB parameter group but the new plan does not", func() {
 			BeforeEach(func() {
-				rdsProperties2.Port = int64(3306)
+				rdsProperties1.DBParameterGroupName = "plan-group"
+				rdsProperties1.UserDBParameters = map[string]ParameterConstraints{
+					"work_mem": ParameterConstraints{},
+				}
+				rdsProperties2.DBParameterGroupName = "plan-group"
 			})
 
-			It("makes the proper calls", func() {
+			It("moves the instance to the DB parameter group of the plan", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.ModifyDBInstanceDetails.DBParameterGroupName).To(Equal("plan-group"))
+			})
+
+			It("starts the deletion of its own DB parameter group", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.Port).To(Equal(int64(3306)))
 				Expect(err).ToNot(HaveOccurred())
+
+				w, err := workflowStore.Get(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("delete-groups"))
+				Expect(w.Data).To(Equal(map[string]string{"db_parameter_group": dbInstanceIdentifier}))
+				Expect(w.State).To(Equal(workflow.StateInProgress))
 			})
 		})
 
-		Context("when has PreferredBackupWindow", func() {
+		Context("when the plan allows user options", func() {
 			BeforeEach(func() {
-				rdsProperties2.PreferredBackupWindow = "test-preferred-backup-window"
+				rdsProperties2.UserOptions = map[string]OptionConstraints{
+					"MARIADB_AUDIT_PLUGIN": OptionConstraints{},
+					"MEMCACHED":            OptionConstraints{},
+				}
+				updateDetails.Parameters = map[string]interface{}{
+					"options": map[string]interface{}{
+						"MARIADB_AUDIT_PLUGIN": map[string]interface{}{},
+					},
+				}
 			})
 
-			It("makes the proper calls", func() {
+			It("gives the instance its own option group", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.PreferredBackupWindow).To(Equal("test-preferred-backup-window"))
 				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.CreateOptionGroupID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.CreateOptionGroupEngine).To(Equal("test-engine-2"))
+				Expect(dbInstance.CreateOptionGroupMajorEngineVersion).To(Equal("4.5"))
+				Expect(dbInstance.ModifyOptionGroupOptionsToInclude).To(Equal([]awsrds.DBOption{
+					awsrds.DBOption{Name: "MARIADB_AUDIT_PLUGIN", Settings: map[string]string{}},
+				}))
+				Expect(dbInstance.ModifyDBInstanceDetails.OptionGroupName).To(Equal(dbInstanceIdentifier))
 			})
 
-			//FIXME: These tests are pending until we allow this user provided parameter
-			PContext("but has PreferredBackupWindow Parameter", func() {
+			Context("and the instance already has its own option group", func() {
 				BeforeEach(func() {
-					updateDetails.Parameters = map[string]interface{}{"preferred_backup_window": "test-preferred-backup-window-parameter"}
+					rdsProperties1.Engine = "test-engine-2"
+					rdsProperties1.EngineVersion = "4.5.1"
+					rdsProperties1.UserOptions = rdsProperties2.UserOptions
 				})
 
-				It("makes the proper calls", func() {
+				It("enables the requested options and disables the other ones", func() {
 					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-					Expect(dbInstance.ModifyDBInstanceDetails.PreferredBackupWindow).To(Equal("test-preferred-backup-window-parameter"))
 					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CreateOptionGroupCalled).To(BeFalse())
+					Expect(dbInstance.ModifyOptionGroupID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.ModifyOptionGroupOptionsToInclude).To(Equal([]awsrds.DBOption{
+						awsrds.DBOption{Name: "MARIADB_AUDIT_PLUGIN", Settings: map[string]string{}},
+					}))
+					Expect(dbInstance.ModifyOptionGroupOptionsToRemove).To(Equal([]string{"MEMCACHED"}))
+				})
+
+				Context("when no options are requested", func() {
+					BeforeEach(func() {
+						updateDetails.Parameters = map[string]interface{}{}
+					})
+
+					It("leaves the options as they are", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(dbInstance.ModifyOptionGroupCalled).To(BeFalse())
+					})
+				})
+
+				Context("for another major engine version", func() {
+					BeforeEach(func() {
+						rdsProperties1.EngineVersion = "4.4.1"
+					})
+
+					It("returns the proper error", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).To(MatchError("DB Instance '" + dbInstanceIdentifier + "' has its own option group, for test-engine-2 4.4, which cannot be used with test-engine-2 4.5"))
+						Expect(dbInstance.ModifyCalled).To(BeFalse())
+					})
+
+					It("does not give the instance its own DB parameter group first", func() {
+						rdsProperties2.DBParameterGroupName = "plan-group"
+						rdsProperties2.UserDBParameters = map[string]ParameterConstraints{
+							"max_connections": ParameterConstraints{},
+						}
+
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).To(HaveOccurred())
+						Expect(dbInstance.CopyParameterGroupCalled).To(BeFalse())
+					})
+				})
+
+				Context("copied from another option group", func() {
+					BeforeEach(func() {
+						rdsProperties1.OptionGroupName = "plan-option-group"
+					})
+
+					It("returns the proper error", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).To(MatchError("DB Instance '" + dbInstanceIdentifier + "' has its own option group, copied from 'plan-option-group', which cannot be replaced by a copy of ''"))
+						Expect(dbInstance.ModifyCalled).To(BeFalse())
+					})
 				})
 			})
 		})
 
-		Context("when has PreferredMaintenanceWindow", func() {
+		Context("when extensions are requested", func() {
 			BeforeEach(func() {
-				rdsProperties2.PreferredMaintenanceWindow = "test-preferred-maintenance-window"
+				rdsProperties2.AllowedExtensions = []string{"pg_trgm", "postgis", "uuid-ossp"}
+				updateDetails.Parameters = map[string]interface{}{
+					"extensions": []interface{}{"uuid-ossp", "pg_trgm"},
+				}
+				dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
+					Identifier:     dbInstanceIdentifier,
+					Address:        "endpoint-address",
+					Port:           5432,
+					DBName:         dbName,
+					MasterUsername: "master-username",
+				}
+				sqlEngine.ListExtensionsExtensions = []string{"pg_trgm", "plpgsql", "postgis"}
 			})
 
-			It("makes the proper calls", func() {
+			It("creates the missing extensions and drops the other allowed ones", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("test-preferred-maintenance-window"))
 				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
+				Expect(sqlEngine.OpenDBName).To(Equal(dbName))
+				Expect(sqlEngine.OpenUsername).To(Equal("master-username"))
+				Expect(sqlEngine.OpenPassword).To(Equal(masterUserPassword))
+				Expect(sqlEngine.CreateExtensionsExtensions).To(Equal([]string{"uuid-ossp"}))
+				Expect(sqlEngine.DropExtensionsExtensions).To(Equal([]string{"postgis"}))
+				Expect(sqlEngine.CloseCalled).To(BeTrue())
+				Expect(dbInstance.AddTagsID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.AddTagsTags).To(Equal(map[string]string{"Extensions": "pg_trgm uuid-ossp"}))
+				Expect(dbInstance.ModifyCalled).To(BeTrue())
 			})
 
-			//FIXME: These tests are pending until we allow this user provided parameter
-			PContext("but has PreferredMaintenanceWindow Parameter", func() {
+			Context("and the list is empty", func() {
 				BeforeEach(func() {
-					updateDetails.Parameters = map[string]interface{}{"preferred_maintenance_window": "test-preferred-maintenance-window-parameter"}
+					updateDetails.Parameters = map[string]interface{}{
+						"extensions": []interface{}{},
+					}
 				})
 
-				It("makes the proper calls", func() {
+				It("drops all the allowed extensions", func() {
 					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-					Expect(dbInstance.ModifyDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("test-preferred-maintenance-window-parameter"))
 					Expect(err).ToNot(HaveOccurred())
+					Expect(sqlEngine.CreateExtensionsCalled).To(BeFalse())
+					Expect(sqlEngine.DropExtensionsExtensions).To(Equal([]string{"pg_trgm", "postgis"}))
+					Expect(dbInstance.RemoveTagsKeys).To(Equal([]string{"Extensions"}))
+				})
+			})
+
+			Context("and an extension is not allowed by the plan", func() {
+				BeforeEach(func() {
+					updateDetails.Parameters = map[string]interface{}{
+						"extensions": []interface{}{"plperlu"},
+					}
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("Invalid parameters: extensions item 0 must be one of 'pg_trgm', 'postgis', 'uuid-ossp'"))
+					Expect(sqlEngine.OpenCalled).To(BeFalse())
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
+
+			Context("and creating the extensions fails", func() {
+				BeforeEach(func() {
+					sqlEngine.CreateExtensionsError = errors.New("could not open extension control file")
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("Could not create extensions on DB Instance '" + dbInstanceIdentifier + "': could not open extension control file"))
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
 				})
 			})
 		})
 
-		Context("when has PubliclyAccessible", func() {
+		Context("when has AllocatedStorage", func() {
 			BeforeEach(func() {
-				rdsProperties2.PubliclyAccessible = true
+				rdsProperties2.AllocatedStorage = int64(100)
 			})
 
 			It("makes the proper calls", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.PubliclyAccessible).To(BeTrue())
+				Expect(dbInstance.ModifyDBInstanceDetails.AllocatedStorage).To(Equal(int64(100)))
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when has StorageType", func() {
+		Context("when has AutoMinorVersionUpgrade", func() {
 			BeforeEach(func() {
-				rdsProperties2.StorageType = "test-storage-type"
+				rdsProperties2.AutoMinorVersionUpgrade = true
 			})
 
 			It("makes the proper calls", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.StorageType).To(Equal("test-storage-type"))
+				Expect(*dbInstance.ModifyDBInstanceDetails.AutoMinorVersionUpgrade).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when has VpcSecurityGroupIds", func() {
+		Context("when has AvailabilityZone", func() {
 			BeforeEach(func() {
-				rdsProperties2.VpcSecurityGroupIds = []string{"test-vpc-security-group-ids"}
+				rdsProperties2.AvailabilityZone = "test-az"
 			})
 
 			It("makes the proper calls", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(dbInstance.ModifyDBInstanceDetails.VpcSecurityGroupIds).To(Equal([]string{"test-vpc-security-group-ids"}))
+				Expect(dbInstance.ModifyDBInstanceDetails.AvailabilityZone).To(Equal("test-az"))
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when request does not accept incomplete", func() {
-			BeforeEach(func() {
-				acceptsIncomplete = false
-			})
-
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(Equal(brokerapi.ErrAsyncRequired))
-			})
-		})
-
-		Context("when Parameters are not valid", func() {
+		Context("when has BackupRetentionPeriod", func() {
 			BeforeEach(func() {
-				updateDetails.Parameters = map[string]interface{}{"skip_final_snapshot": "invalid"}
+				rdsProperties2.BackupRetentionPeriod = int64(7)
 			})
 
-			It("returns the proper error", func() {
+			It("makes the proper calls", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("skip_final_snapshot must be set to true or false, or not set at all"))
+				Expect(dbInstance.ModifyDBInstanceDetails.BackupRetentionPeriod).To(Equal(int64(7)))
+				Expect(err).ToNot(HaveOccurred())
 			})
 
-			Context("and user update parameters are not allowed", func() {
+			//FIXME: These tests are pending until we allow this user provided parameter
+			PContext("but has BackupRetentionPeriod Parameter", func() {
 				BeforeEach(func() {
-					allowUserUpdateParameters = false
+					updateDetails.Parameters = map[string]interface{}{"backup_retention_period": 12}
 				})
 
-				It("does not return an error", func() {
+				It("makes the proper calls", func() {
 					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(dbInstance.ModifyDBInstanceDetails.BackupRetentionPeriod).To(Equal(int64(12)))
 					Expect(err).ToNot(HaveOccurred())
 				})
 			})
 		})
 
-		Context("when Service is not found", func() {
+		Context("when has CharacterSetName", func() {
 			BeforeEach(func() {
-				updateDetails.ServiceID = "unknown"
+				rdsProperties2.CharacterSetName = "test-characterset-name"
 			})
 
-			It("returns the proper error", func() {
+			It("makes the proper calls", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Service 'unknown' not found"))
+				Expect(dbInstance.ModifyDBInstanceDetails.CharacterSetName).To(Equal("test-characterset-name"))
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when Plans is not updateable", func() {
+		Context("when has CopyTagsToSnapshot", func() {
 			BeforeEach(func() {
-				planUpdateable = false
+				rdsProperties2.CopyTagsToSnapshot = true
 			})
 
-			It("returns the proper error", func() {
+			It("makes the proper calls", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(Equal(brokerapi.ErrInstanceNotUpdateable))
+				Expect(*dbInstance.ModifyDBInstanceDetails.CopyTagsToSnapshot).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when Service Plan is not found", func() {
+		Context("when has DeletionProtection", func() {
 			BeforeEach(func() {
-				updateDetails.PlanID = "unknown"
+				rdsProperties2.DeletionProtection = true
 			})
 
-			It("returns the proper error", func() {
+			It("makes the proper calls", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+				Expect(*dbInstance.ModifyDBInstanceDetails.DeletionProtection).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when modifying the DB Instance fails", func() {
+		Context("when has IAMDatabaseAuthentication", func() {
 			BeforeEach(func() {
-				dbInstance.ModifyError = errors.New("operation failed")
+				rdsProperties2.IAMDatabaseAuthentication = true
 			})
 
-			It("returns the proper error", func() {
+			It("makes the proper calls", func() {
 				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("operation failed"))
+				Expect(*dbInstance.ModifyDBInstanceDetails.IAMDatabaseAuthentication).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
 			})
+		})
+
+		Context("when has TakeSnapshot parameter", func() {
+			BeforeEach(func() {
+				allowUserUpdateParameters = true
+				rdsProperties2.MaxManualSnapshots = 2
+				updateDetails.PreviousValues.PlanID = updateDetails.PlanID
+				updateDetails.PreviousValues.OrganizationID = "organization-id"
+				updateDetails.PreviousValues.SpaceID = "space-id"
+				updateDetails.Parameters = map[string]interface{}{"take_snapshot": true}
+				dbInstance.CreateSnapshotSnapshotID = "new-snapshot"
+			})
+
+			It("takes a tagged snapshot instead of modifying the DB instance", func() {
+				asynch, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(asynch).To(BeTrue())
+				Expect(dbInstance.ModifyCalled).To(BeFalse())
+				Expect(dbInstance.CreateSnapshotCalled).To(BeTrue())
+				Expect(dbInstance.CreateSnapshotID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Instance ID", instanceID))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Snapshot type", "manual"))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Broker Name", brokerName))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Plan ID", "Plan-2"))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Organization ID", "organization-id"))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Space ID", "space-id"))
+				Expect(dbInstance.DeleteSnapshotCalled).To(BeFalse())
+
+				w, err := workflowStore.Get(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("manual-snapshot"))
+				Expect(w.Step).To(Equal("snapshot"))
+				Expect(w.Data).To(HaveKeyWithValue("snapshot_id", "new-snapshot"))
+				Expect(w.Data).To(HaveKeyWithValue("plan_id", "Plan-2"))
+			})
+
+			Context("and the instance has reached its manual snapshots limit", func() {
+				var snapshotStatus string
 
-			Context("when the DB Instance does not exists", func() {
 				BeforeEach(func() {
-					dbInstance.ModifyError = awsrds.ErrDBInstanceDoesNotExist
+					snapshotStatus = "creating"
+				})
+
+				JustBeforeEach(func() {
+					now := time.Now()
+					dbInstance.DescribeInstanceSnapshotsDBSnapshotDetails = []*awsrds.DBSnapshotDetails{
+						&awsrds.DBSnapshotDetails{
+							Identifier: "new-snapshot",
+							CreateTime: now,
+							Status:     snapshotStatus,
+							Tags:       map[string]string{"Snapshot type": "manual"},
+						},
+						&awsrds.DBSnapshotDetails{
+							Identifier: "newer-snapshot",
+							CreateTime: now.Add(-time.Hour),
+							Tags:       map[string]string{"Snapshot type": "manual"},
+						},
+						&awsrds.DBSnapshotDetails{
+							Identifier: "older-snapshot",
+							CreateTime: now.Add(-2 * time.Hour),
+							Tags:       map[string]string{"Snapshot type": "manual"},
+						},
+						&awsrds.DBSnapshotDetails{
+							Identifier: "other-snapshot",
+							CreateTime: now.Add(-3 * time.Hour),
+						},
+					}
+				})
+
+				It("keeps the oldest manual snapshots while the new one is not available", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+
+					rdsBroker.RunWorkflows()
+					Expect(dbInstance.DeleteSnapshotCalled).To(BeFalse())
+
+					w, err := workflowStore.Get(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(w.State).To(Equal(workflow.StateInProgress))
+				})
+
+				Context("and the new snapshot is available", func() {
+					BeforeEach(func() {
+						snapshotStatus = "available"
+					})
+
+					It("deletes the oldest manual snapshots", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(dbInstance.DeleteSnapshotCalled).To(BeFalse())
+
+						rdsBroker.RunWorkflows()
+						Expect(dbInstance.DescribeInstanceSnapshotsID).To(Equal(dbInstanceIdentifier))
+						Expect(dbInstance.DeleteSnapshotIDs).To(Equal([]string{"older-snapshot"}))
+
+						w, err := workflowStore.Get(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(w.State).To(Equal(workflow.StateSucceeded))
+					})
+				})
+
+				Context("and the new snapshot failed", func() {
+					BeforeEach(func() {
+						snapshotStatus = "failed"
+					})
+
+					It("keeps the previous manual snapshots", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).ToNot(HaveOccurred())
+
+						rdsBroker.RunWorkflows()
+						Expect(dbInstance.DeleteSnapshotCalled).To(BeFalse())
+
+						w, err := workflowStore.Get(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(w.State).To(Equal(workflow.StateFailed))
+						Expect(w.Description).To(Equal("Manual DB Snapshot 'new-snapshot' of DB Instance '" + dbInstanceIdentifier + "' failed, the previous manual DB Snapshots have been kept"))
+					})
+				})
+			})
+
+			Context("and the plan does not allow manual snapshots", func() {
+				BeforeEach(func() {
+					rdsProperties2.MaxManualSnapshots = 0
 				})
 
 				It("returns the proper error", func() {
 					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+					Expect(err).To(MatchError("Manual snapshots are not enabled for Service Plan 'Plan-2'"))
+					Expect(dbInstance.CreateSnapshotCalled).To(BeFalse())
 				})
 			})
-		})
-	})
 
-	var _ = Describe("Deprovision", func() {
-		var (
-			deprovisionDetails brokerapi.DeprovisionDetails
-			acceptsIncomplete  bool
-		)
+			Context("and it is combined with other parameters", func() {
+				BeforeEach(func() {
+					updateDetails.Parameters["backup_retention_period"] = 7
+				})
 
-		BeforeEach(func() {
-			deprovisionDetails = brokerapi.DeprovisionDetails{
-				ServiceID: "Service-1",
-				PlanID:    "Plan-1",
-			}
-			acceptsIncomplete = true
-		})
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("take_snapshot cannot be combined with a plan change or other parameters"))
+					Expect(dbInstance.CreateSnapshotCalled).To(BeFalse())
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
 
-		It("returns the proper response", func() {
-			asynch, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
-			Expect(asynch).To(BeTrue())
-			Expect(err).ToNot(HaveOccurred())
-		})
+			Context("and it is combined with extensions", func() {
+				BeforeEach(func() {
+					rdsProperties2.AllowedExtensions = []string{"pg_trgm"}
+					updateDetails.Parameters["extensions"] = []interface{}{"pg_trgm"}
+				})
 
-		It("makes the proper calls", func() {
-			_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
-			Expect(dbInstance.DeleteCalled).To(BeTrue())
-			Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier))
-			Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeTrue())
-			Expect(err).ToNot(HaveOccurred())
+				It("does not change the extensions", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("take_snapshot cannot be combined with a plan change or other parameters"))
+					Expect(sqlEngine.OpenCalled).To(BeFalse())
+					Expect(dbInstance.CreateSnapshotCalled).To(BeFalse())
+				})
+			})
+
+			Context("and creating the snapshot fails", func() {
+				BeforeEach(func() {
+					dbInstance.CreateSnapshotError = errors.New("operation failed")
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("operation failed"))
+				})
+			})
 		})
 
-		Context("when it does not skip final snaphot", func() {
+		Context("when has PreChangeSnapshot", func() {
 			BeforeEach(func() {
-				rdsProperties1.SkipFinalSnapshot = false
+				rdsProperties2.PreChangeSnapshot = true
+				dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
+					Identifier:      dbInstanceIdentifier,
+					DBInstanceClass: "db.m1.test",
+					EngineVersion:   "4.5.6",
+				}
+				dbInstance.CreateSnapshotSnapshotID = "pre-change-snapshot"
 			})
 
-			It("makes the proper calls", func() {
-				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
-				Expect(dbInstance.DeleteCalled).To(BeTrue())
-				Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier))
-				Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeFalse())
+			It("takes a tagged snapshot instead of modifying the DB instance", func() {
+				asynch, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(asynch).To(BeTrue())
+				Expect(dbInstance.ModifyCalled).To(BeFalse())
+				Expect(dbInstance.CreateSnapshotID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Instance ID", instanceID))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Snapshot type", "pre-change"))
+				Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Plan ID", "Plan-1"))
+
+				w, err := workflowStore.Get(instanceID)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("pre-change-snapshot"))
+				Expect(w.Step).To(Equal("snapshot"))
+				Expect(w.State).To(Equal(workflow.StateInProgress))
+				Expect(w.Data).To(HaveKeyWithValue("snapshot_id", "pre-change-snapshot"))
+				Expect(w.Data).To(HaveKeyWithValue("plan_id", "Plan-2"))
+				Expect(w.Data).ToNot(HaveKey("parameters"))
 			})
-		})
 
-		Context("when request does not accept incomplete", func() {
-			BeforeEach(func() {
-				acceptsIncomplete = false
+			It("upgrades the major engine version only after a snapshot", func() {
+				dbInstance.DescribeDBInstanceDetails.DBInstanceClass = "db.m2.test"
+				dbInstance.DescribeDBInstanceDetails.EngineVersion = "4.4.6"
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.CreateSnapshotCalled).To(BeTrue())
+				Expect(dbInstance.ModifyCalled).To(BeFalse())
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(Equal(brokerapi.ErrAsyncRequired))
+			It("modifies the DB instance straight away for other changes", func() {
+				dbInstance.DescribeDBInstanceDetails.DBInstanceClass = "db.m2.test"
+				dbInstance.DescribeDBInstanceDetails.EngineVersion = "4.5.2"
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.CreateSnapshotCalled).To(BeFalse())
+				Expect(dbInstance.ModifyCalled).To(BeTrue())
 			})
-		})
 
-		Context("when Service Plan is not found", func() {
-			BeforeEach(func() {
-				deprovisionDetails.PlanID = "unknown"
+			It("records the update parameters in the workflow", func() {
+				allowUserUpdateParameters = true
+				updateDetails.Parameters = map[string]interface{}{"apply_immediately": true}
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+
+				w, err := workflowStore.Get(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Data).To(HaveKeyWithValue("parameters", `{"apply_immediately":true}`))
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+			Context("and the new plan gives the DB instance its own DB parameter group", func() {
+				BeforeEach(func() {
+					rdsProperties2.DBParameterGroupName = "plan-group"
+					rdsProperties2.UserDBParameters = map[string]ParameterConstraints{
+						"max_connections": ParameterConstraints{},
+					}
+				})
+
+				It("does not create it before the snapshot", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CreateSnapshotCalled).To(BeTrue())
+					Expect(dbInstance.CopyParameterGroupCalled).To(BeFalse())
+				})
 			})
-		})
 
-		Context("when deleting the DB Instance fails", func() {
-			BeforeEach(func() {
-				dbInstance.DeleteError = errors.New("operation failed")
+			It("refuses another update while the snapshot is pending", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).To(MatchError(ErrOperationInProgress.Error()))
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("operation failed"))
+			It("keeps the previous pre-change snapshots until the new one is available", func() {
+				dbInstance.DescribeInstanceSnapshotsDBSnapshotDetails = []*awsrds.DBSnapshotDetails{
+					&awsrds.DBSnapshotDetails{
+						Identifier: "previous-pre-change-snapshot",
+						Status:     "available",
+						Tags:       map[string]string{"Snapshot type": "pre-change"},
+					},
+				}
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.DeleteSnapshotCalled).To(BeFalse())
 			})
 
-			Context("when the DB instance does not exists", func() {
+			Context("and creating the snapshot fails", func() {
 				BeforeEach(func() {
-					dbInstance.DeleteError = awsrds.ErrDBInstanceDoesNotExist
+					dbInstance.CreateSnapshotError = errors.New("operation failed")
 				})
 
 				It("returns the proper error", func() {
-					_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("operation failed"))
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+
+					_, err = workflowStore.Get(instanceID)
+					Expect(err).To(Equal(workflow.ErrNotFound))
 				})
 			})
 		})
-	})
-
-	var _ = Describe("Bind", func() {
-		var (
-			bindDetails brokerapi.BindDetails
-		)
-
-		BeforeEach(func() {
-			bindDetails = brokerapi.BindDetails{
-				ServiceID:  "Service-1",
-				PlanID:     "Plan-1",
-				AppGUID:    "Application-1",
-				Parameters: map[string]interface{}{},
-			}
 
-			dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
-				Identifier:     dbInstanceIdentifier,
-				Address:        "endpoint-address",
-				Port:           3306,
-				DBName:         "test-db",
-				MasterUsername: "master-username",
-			}
+		Context("when has DBParameterGroupName", func() {
+			BeforeEach(func() {
+				rdsProperties2.DBParameterGroupName = "test-db-parameter-group-name"
+			})
 
-			sqlEngine.CreateUserUsername = dbUsername
-			sqlEngine.CreateUserPassword = "secret"
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.DBParameterGroupName).To(Equal("test-db-parameter-group-name"))
+				Expect(err).ToNot(HaveOccurred())
+			})
 		})
 
-		It("returns the proper response", func() {
-			bindingResponse, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-			credentials := bindingResponse.Credentials.(*brokerapi.CredentialsHash)
-			Expect(bindingResponse.SyslogDrainURL).To(BeEmpty())
-			Expect(credentials.Host).To(Equal("endpoint-address"))
-			Expect(credentials.Port).To(Equal(int64(3306)))
-			Expect(credentials.Name).To(Equal("test-db"))
-			Expect(credentials.Username).To(Equal(dbUsername))
-			Expect(credentials.Password).To(Equal("secret"))
-			Expect(credentials.URI).To(ContainSubstring("@endpoint-address:3306/test-db?reconnect=true"))
-			Expect(credentials.JDBCURI).To(ContainSubstring("jdbc:fake://endpoint-address:3306/test-db?user=" + dbUsername + "&password="))
-			Expect(err).ToNot(HaveOccurred())
+		Context("when has DBSecurityGroups", func() {
+			BeforeEach(func() {
+				rdsProperties2.DBSecurityGroups = []string{"test-db-security-group"}
+			})
+
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.DBSecurityGroups).To(Equal([]string{"test-db-security-group"}))
+				Expect(err).ToNot(HaveOccurred())
+			})
 		})
 
-		It("makes the proper calls", func() {
-			_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-			Expect(dbInstance.DescribeCalled).To(BeTrue())
-			Expect(dbInstance.DescribeID).To(Equal(dbInstanceIdentifier))
-			Expect(sqlProvider.GetSQLEngineCalled).To(BeTrue())
-			Expect(sqlProvider.GetSQLEngineEngine).To(Equal("test-engine-1"))
-			Expect(sqlEngine.OpenCalled).To(BeTrue())
-			Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
-			Expect(sqlEngine.OpenPort).To(Equal(int64(3306)))
-			Expect(sqlEngine.OpenDBName).To(Equal("test-db"))
-			Expect(sqlEngine.OpenUsername).To(Equal("master-username"))
-			Expect(sqlEngine.OpenPassword).ToNot(BeEmpty())
-			Expect(sqlEngine.CreateUserCalled).To(BeTrue())
-			Expect(sqlEngine.CreateUserBindingID).To(Equal(bindingID))
-			Expect(sqlEngine.CreateUserDBName).To(Equal("test-db"))
-			Expect(sqlEngine.CloseCalled).To(BeTrue())
-			Expect(err).ToNot(HaveOccurred())
+		Context("when has DBSubnetGroupName", func() {
+			BeforeEach(func() {
+				rdsProperties2.DBSubnetGroupName = "test-db-subnet-group-name"
+			})
+
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.DBSubnetGroupName).To(Equal("test-db-subnet-group-name"))
+				Expect(err).ToNot(HaveOccurred())
+			})
 		})
 
-		// FIXME: Re-enable these tests when we have some bind-time parameters again
-		PContext("when Parameters are not valid", func() {
+		Context("when has EngineVersion", func() {
 			BeforeEach(func() {
-				bindDetails.Parameters = map[string]interface{}{"dbname": true}
+				rdsProperties2.EngineVersion = "1.2.3"
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("'dbname' expected type 'string', got unconvertible type 'bool'"))
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.EngineVersion).To(Equal("1.2.3"))
+				Expect(err).ToNot(HaveOccurred())
 			})
+		})
 
-			Context("and user bind parameters are not allowed", func() {
+		Context("when has Iops", func() {
+			BeforeEach(func() {
+				rdsProperties2.Iops = int64(1000)
+			})
+
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.Iops).To(Equal(int64(1000)))
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when storage encryption settings are updated", func() {
+			BeforeEach(func() {
+				updateDetails.Parameters = map[string]interface{}{"confirm_encryption_change": true}
+			})
+
+			Context("when tries to enable StorageEncrypted", func() {
 				BeforeEach(func() {
-					allowUserBindParameters = false
+					rdsProperties1.StorageEncrypted = false
+					rdsProperties2.StorageEncrypted = true
+					dbInstance.CreateSnapshotSnapshotID = "encryption-snapshot"
 				})
 
-				It("does not return an error", func() {
-					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				It("starts an encryption change instead of modifying the DB instance", func() {
+					asynch, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(asynch).To(BeTrue())
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+					Expect(dbInstance.CreateSnapshotID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Snapshot type", "encryption-change"))
+					Expect(dbInstance.CreateSnapshotTags).To(HaveKeyWithValue("Instance ID", instanceID))
+					Expect(dbInstance.AddTagsID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.AddTagsTags).To(Equal(map[string]string{"Encryption change": "encryption-snapshot"}))
+
+					w, err := workflowStore.Get(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(w.Kind).To(Equal("encryption-change"))
+					Expect(w.Step).To(Equal("snapshot"))
+					Expect(w.Data).To(HaveKeyWithValue("snapshot_id", "encryption-snapshot"))
+					Expect(w.Data).To(HaveKeyWithValue("plan_id", "Plan-2"))
+				})
+
+				Context("and taking the snapshot fails", func() {
+					BeforeEach(func() {
+						dbInstance.CreateSnapshotError = errors.New("operation failed")
+					})
+
+					It("returns the proper error", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).To(MatchError("operation failed"))
+						Expect(dbInstance.AddTagsCalled).To(BeFalse())
+					})
+				})
+
+				Context("and the workflow store is not configured", func() {
+					BeforeEach(func() {
+						workflowStoreConfig = nil
+					})
+
+					It("refuses the encryption change", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).To(Equal(ErrWorkflowStoreNotConfigured))
+						Expect(dbInstance.CreateSnapshotCalled).To(BeFalse())
+					})
+				})
+
+				Context("and the encryption change is not confirmed", func() {
+					BeforeEach(func() {
+						updateDetails.Parameters = map[string]interface{}{}
+					})
+
+					It("refuses the encryption change", func() {
+						_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+						Expect(err).To(MatchError(ErrEncryptionChangeNotConfirmed.Error()))
+						Expect(err.(*FailureResponse).StatusCode()).To(Equal(http.StatusBadRequest))
+						Expect(dbInstance.CreateSnapshotCalled).To(BeFalse())
+						Expect(dbInstance.ModifyCalled).To(BeFalse())
+					})
+				})
+			})
+			Context("when tries to disable StorageEncrypted", func() {
+				BeforeEach(func() {
+					rdsProperties1.StorageEncrypted = true
+					rdsProperties2.StorageEncrypted = false
+				})
+
+				It("fails noisily", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(Equal(ErrEncryptionNotUpdateable))
+				})
+
+				It("does not give the instance its own DB parameter group first", func() {
+					rdsProperties2.DBParameterGroupName = "plan-group"
+					rdsProperties2.UserDBParameters = map[string]ParameterConstraints{
+						"work_mem": ParameterConstraints{},
+					}
+
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(Equal(ErrEncryptionNotUpdateable))
+					Expect(dbInstance.CopyParameterGroupCalled).To(BeFalse())
+				})
+			})
+			Context("when changes KmsKeyID with StorageEncrypted enabled", func() {
+				BeforeEach(func() {
+					rdsProperties1.StorageEncrypted = true
+					rdsProperties2.StorageEncrypted = true
+					rdsProperties1.KmsKeyID = "test-old-kms-key-id"
+					rdsProperties2.KmsKeyID = "test-new-kms-key-id"
+				})
+
+				It("starts an encryption change instead of modifying the DB instance", func() {
+					asynch, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
 					Expect(err).ToNot(HaveOccurred())
+					Expect(asynch).To(BeTrue())
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+					Expect(dbInstance.CreateSnapshotCalled).To(BeTrue())
 				})
 			})
+
 		})
 
-		Context("when Service is not found", func() {
+		Context("when has LicenseModel", func() {
 			BeforeEach(func() {
-				bindDetails.ServiceID = "unknown"
+				rdsProperties2.LicenseModel = "test-license-model"
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Service 'unknown' not found"))
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.LicenseModel).To(Equal("test-license-model"))
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when Service is not bindable", func() {
+		Context("when has MultiAZ", func() {
 			BeforeEach(func() {
-				serviceBindable = false
+				rdsProperties2.MultiAZ = true
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(Equal(brokerapi.ErrInstanceNotBindable))
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(*dbInstance.ModifyDBInstanceDetails.MultiAZ).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when Service Plan is not found", func() {
+		Context("when has OptionGroupName", func() {
 			BeforeEach(func() {
-				bindDetails.PlanID = "unknown"
+				rdsProperties2.OptionGroupName = "test-option-group-name"
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.OptionGroupName).To(Equal("test-option-group-name"))
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when describing the DB Instance fails", func() {
+		Context("when has Port", func() {
 			BeforeEach(func() {
-				dbInstance.DescribeError = errors.New("operation failed")
+				rdsProperties2.Port = int64(3306)
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("operation failed"))
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.Port).To(Equal(int64(3306)))
+				Expect(err).ToNot(HaveOccurred())
 			})
+		})
 
-			Context("when the DB Instance does not exists", func() {
+		Context("when has PreferredBackupWindow", func() {
+			BeforeEach(func() {
+				rdsProperties2.PreferredBackupWindow = "test-preferred-backup-window"
+			})
+
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.PreferredBackupWindow).To(Equal("test-preferred-backup-window"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			//FIXME: These tests are pending until we allow this user provided parameter
+			PContext("but has PreferredBackupWindow Parameter", func() {
 				BeforeEach(func() {
-					dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
+					updateDetails.Parameters = map[string]interface{}{"preferred_backup_window": "03:00-03:30"}
 				})
 
-				It("returns the proper error", func() {
-					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+				It("makes the proper calls", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(dbInstance.ModifyDBInstanceDetails.PreferredBackupWindow).To(Equal("03:00-03:30"))
+					Expect(err).ToNot(HaveOccurred())
 				})
 			})
 		})
 
-		Context("when getting the SQL Engine fails", func() {
+		Context("when has PreferredMaintenanceWindow", func() {
 			BeforeEach(func() {
-				sqlProvider.GetSQLEngineError = errors.New("Engine 'unknown' not supported")
+				rdsProperties2.PreferredMaintenanceWindow = "test-preferred-maintenance-window"
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Engine 'unknown' not supported"))
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("test-preferred-maintenance-window"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			//FIXME: These tests are pending until we allow this user provided parameter
+			PContext("but has PreferredMaintenanceWindow Parameter", func() {
+				BeforeEach(func() {
+					updateDetails.Parameters = map[string]interface{}{"preferred_maintenance_window": "sun:04:00-sun:04:30"}
+				})
+
+				It("makes the proper calls", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(dbInstance.ModifyDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("sun:04:00-sun:04:30"))
+					Expect(err).ToNot(HaveOccurred())
+				})
 			})
 		})
 
-		Context("when opening a DB connection fails", func() {
+		Context("when the window scheduler is configured", func() {
 			BeforeEach(func() {
-				sqlEngine.OpenError = errors.New("Failed to open sqlEngine")
+				rdsProperties2.PreferredBackupWindow = "03:00-03:30"
+				rdsProperties2.PreferredMaintenanceWindow = "sun:04:00-sun:04:30"
+				windowScheduler = &WindowSchedulerConfig{
+					BackupWindowRanges: []string{"01:00-02:00"},
+					WindowDuration:     30,
+				}
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Failed to open sqlEngine"))
+			It("does not override the scheduled windows with the plan ones", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.ModifyDBInstanceDetails.PreferredBackupWindow).To(BeEmpty())
+				Expect(dbInstance.ModifyDBInstanceDetails.PreferredMaintenanceWindow).To(BeEmpty())
+			})
+
+			Context("but the user sets a window", func() {
+				BeforeEach(func() {
+					updateDetails.Parameters = map[string]interface{}{"preferred_maintenance_window": "sat:04:00-sat:04:30"}
+				})
+
+				It("applies the window set by the user", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.ModifyDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("sat:04:00-sat:04:30"))
+					Expect(dbInstance.ModifyDBInstanceDetails.Tags).To(HaveKeyWithValue("UserPreferredMaintenanceWindow", "true"))
+				})
+			})
+
+			Context("but the user sets a window overlapping the window of the instance", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeDBInstanceDetails.PreferredBackupWindow = "04:00-04:30"
+					updateDetails.Parameters = map[string]interface{}{"preferred_maintenance_window": "sat:04:00-sat:04:30"}
+				})
+
+				It("returns a bad request explaining the problem", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(BeAssignableToTypeOf(&FailureResponse{}))
+					Expect(err.(*FailureResponse).StatusCode()).To(Equal(http.StatusBadRequest))
+					Expect(err).To(MatchError(ContainSubstring("preferred_backup_window '04:00-04:30' overlaps preferred_maintenance_window 'sat:04:00-sat:04:30'")))
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
+
+			Context("but the user sets a window overlapping the plan window only", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeDBInstanceDetails.PreferredMaintenanceWindow = "tue:01:00-tue:01:30"
+					updateDetails.Parameters = map[string]interface{}{"preferred_backup_window": "04:00-04:30"}
+				})
+
+				It("applies the window set by the user", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.ModifyDBInstanceDetails.PreferredBackupWindow).To(Equal("04:00-04:30"))
+				})
 			})
 		})
 
-		Context("when creating a DB user fails", func() {
+		Context("when has PubliclyAccessible", func() {
 			BeforeEach(func() {
-				sqlEngine.CreateUserError = errors.New("Failed to create user")
+				rdsProperties2.PubliclyAccessible = true
 			})
 
-			It("returns the proper error", func() {
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Failed to create user"))
-				Expect(sqlEngine.CloseCalled).To(BeTrue())
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.PubliclyAccessible).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
-	})
 
-	var _ = Describe("Unbind", func() {
+		Context("when has StorageType", func() {
+			BeforeEach(func() {
+				rdsProperties2.StorageType = "test-storage-type"
+			})
+
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.StorageType).To(Equal("test-storage-type"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when has VpcSecurityGroupIds", func() {
+			BeforeEach(func() {
+				rdsProperties2.VpcSecurityGroupIds = []string{"test-vpc-security-group-ids"}
+			})
+
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(dbInstance.ModifyDBInstanceDetails.VpcSecurityGroupIds).To(Equal([]string{"test-vpc-security-group-ids"}))
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when request does not accept incomplete", func() {
+			BeforeEach(func() {
+				acceptsIncomplete = false
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(Equal(brokerapi.ErrAsyncRequired))
+			})
+		})
+
+		Context("when Parameters are not valid", func() {
+			BeforeEach(func() {
+				updateDetails.Parameters = map[string]interface{}{"skip_final_snapshot": "invalid"}
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("skip_final_snapshot must be one of 'true', 'false'"))
+			})
+
+			Context("and the windows overlap", func() {
+				BeforeEach(func() {
+					updateDetails.Parameters = map[string]interface{}{
+						"preferred_backup_window":      "03:30-04:30",
+						"preferred_maintenance_window": "tue:03:00-tue:04:00",
+					}
+				})
+
+				It("returns a bad request explaining the problem", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(BeAssignableToTypeOf(&FailureResponse{}))
+					Expect(err.(*FailureResponse).StatusCode()).To(Equal(http.StatusBadRequest))
+					Expect(err).To(MatchError(ContainSubstring("overlaps preferred_maintenance_window")))
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
+
+			Context("and user update parameters are not allowed", func() {
+				BeforeEach(func() {
+					allowUserUpdateParameters = false
+				})
+
+				It("does not return an error", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when Service is not found", func() {
+			BeforeEach(func() {
+				updateDetails.ServiceID = "unknown"
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Service 'unknown' not found"))
+			})
+		})
+
+		Context("when Plans is not updateable", func() {
+			BeforeEach(func() {
+				planUpdateable = false
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(Equal(brokerapi.ErrInstanceNotUpdateable))
+			})
+		})
+
+		Context("when Service Plan is not found", func() {
+			BeforeEach(func() {
+				updateDetails.PlanID = "unknown"
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+			})
+		})
+
+		Context("when modifying the DB Instance fails", func() {
+			BeforeEach(func() {
+				dbInstance.ModifyError = errors.New("operation failed")
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("operation failed"))
+			})
+
+			Context("when the DB Instance does not exists", func() {
+				BeforeEach(func() {
+					dbInstance.ModifyError = awsrds.ErrDBInstanceDoesNotExist
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Update(instanceID, updateDetails, acceptsIncomplete)
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+				})
+			})
+		})
+	})
+
+	var _ = Describe("Deprovision", func() {
 		var (
-			unbindDetails brokerapi.UnbindDetails
+			deprovisionDetails brokerapi.DeprovisionDetails
+			acceptsIncomplete  bool
 		)
 
 		BeforeEach(func() {
-			unbindDetails = brokerapi.UnbindDetails{
+			deprovisionDetails = brokerapi.DeprovisionDetails{
 				ServiceID: "Service-1",
 				PlanID:    "Plan-1",
 			}
+			acceptsIncomplete = true
+		})
 
-			dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
-				Identifier:     dbInstanceIdentifier,
-				Address:        "endpoint-address",
-				Port:           3306,
-				DBName:         "test-db",
-				MasterUsername: "master-username",
-			}
+		It("returns the proper response", func() {
+			asynch, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+			Expect(asynch).To(BeTrue())
+			Expect(err).ToNot(HaveOccurred())
 		})
 
 		It("makes the proper calls", func() {
-			err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
-			Expect(dbInstance.DescribeCalled).To(BeTrue())
-			Expect(dbInstance.DescribeID).To(Equal(dbInstanceIdentifier))
-			Expect(sqlProvider.GetSQLEngineCalled).To(BeTrue())
-			Expect(sqlProvider.GetSQLEngineEngine).To(Equal("test-engine-1"))
-			Expect(sqlEngine.OpenCalled).To(BeTrue())
-			Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
-			Expect(sqlEngine.OpenPort).To(Equal(int64(3306)))
-			Expect(sqlEngine.OpenDBName).To(Equal("test-db"))
-			Expect(sqlEngine.OpenUsername).To(Equal("master-username"))
-			Expect(sqlEngine.OpenPassword).ToNot(BeEmpty())
-			Expect(sqlEngine.DropUserCalled).To(BeTrue())
-			Expect(sqlEngine.DropUserBindingID).To(Equal(bindingID))
-			Expect(sqlEngine.CloseCalled).To(BeTrue())
+			_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+			Expect(dbInstance.DeleteCalled).To(BeTrue())
+			Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier))
+			Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeTrue())
 			Expect(err).ToNot(HaveOccurred())
 		})
 
-		Context("when Service Plan is not found", func() {
+		Context("when the post-provision SQL has not run yet", func() {
+			BeforeEach(func() {
+				Expect(workflowStore.Save(&workflow.Workflow{
+					InstanceID: instanceID,
+					Kind:       "post-provision",
+					Step:       "sql",
+					State:      workflow.StateInProgress,
+					Data:       map[string]string{"plan_id": "Plan-1"},
+				})).To(Succeed())
+			})
+
+			It("deletes the DB Instance and forgets the workflow", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.DeleteCalled).To(BeTrue())
+
+				_, err = workflowStore.Get(instanceID)
+				Expect(err).To(Equal(workflow.ErrNotFound))
+			})
+		})
+
+		Context("when there is a master password store", func() {
+			BeforeEach(func() {
+				secretStore = fakeSecretStore
+				fakeSecretStore.Secrets = map[string]string{dbInstanceIdentifier: "stored-password"}
+			})
+
+			It("deletes the master password", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeSecretStore.DeleteCalled).To(BeTrue())
+				Expect(fakeSecretStore.Secrets).To(BeEmpty())
+			})
+
+			Context("and a final snapshot is taken", func() {
+				BeforeEach(func() {
+					rdsProperties1.SkipFinalSnapshot = false
+				})
+
+				It("keeps the master password for the final snapshot", func() {
+					_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeFalse())
+					Expect(fakeSecretStore.DeleteCalled).To(BeFalse())
+				})
+			})
+
+			Context("but the DB instance has no master password in the store", func() {
+				BeforeEach(func() {
+					fakeSecretStore.Secrets = nil
+				})
+
+				It("does not return an error", func() {
+					_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when the instance has its own DB parameter group", func() {
+			BeforeEach(func() {
+				rdsProperties1.DBParameterGroupName = "plan-group"
+				rdsProperties1.UserDBParameters = map[string]ParameterConstraints{"work_mem": ParameterConstraints{}}
+			})
+
+			It("starts the deletion of the DB parameter group", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.DeleteCalled).To(BeTrue())
+
+				w, err := workflowStore.Get(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("delete-groups"))
+				Expect(w.Data).To(Equal(map[string]string{"db_parameter_group": dbInstanceIdentifier}))
+				Expect(w.State).To(Equal(workflow.StateInProgress))
+			})
+		})
+
+		Context("when the instance has its own option group", func() {
+			BeforeEach(func() {
+				rdsProperties1.UserOptions = map[string]OptionConstraints{"MEMCACHED": OptionConstraints{}}
+			})
+
+			It("starts the deletion of the option group", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+
+				w, err := workflowStore.Get(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("delete-groups"))
+				Expect(w.Data).To(Equal(map[string]string{"option_group": dbInstanceIdentifier}))
+			})
+		})
+
+		Context("when the groups left behind by a plan change are waiting to be deleted", func() {
+			BeforeEach(func() {
+				rdsProperties1.UserOptions = map[string]OptionConstraints{"MEMCACHED": OptionConstraints{}}
+				Expect(workflowStore.Save(&workflow.Workflow{
+					InstanceID: instanceID,
+					Kind:       "delete-groups",
+					Step:       "delete",
+					State:      workflow.StateInProgress,
+					Data:       map[string]string{"db_parameter_group": dbInstanceIdentifier},
+				})).To(Succeed())
+			})
+
+			It("deletes them with the groups of the plan", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+
+				w, err := workflowStore.Get(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("delete-groups"))
+				Expect(w.Data).To(Equal(map[string]string{
+					"db_parameter_group": dbInstanceIdentifier,
+					"option_group":       dbInstanceIdentifier,
+				}))
+			})
+		})
+
+		Context("when the encryption is being changed", func() {
+			BeforeEach(func() {
+				Expect(workflowStore.Save(&workflow.Workflow{
+					InstanceID: instanceID,
+					Kind:       "encryption-change",
+					Step:       "restore",
+					State:      workflow.StateInProgress,
+				})).To(Succeed())
+			})
+
+			It("refuses to delete the DB Instance", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).To(MatchError(ErrOperationInProgress.Error()))
+				Expect(dbInstance.DeleteCalled).To(BeFalse())
+			})
+		})
+
+		Context("when it does not skip final snaphot", func() {
+			BeforeEach(func() {
+				rdsProperties1.SkipFinalSnapshot = false
+			})
+
+			It("makes the proper calls", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(dbInstance.DeleteCalled).To(BeTrue())
+				Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeFalse())
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when request does not accept incomplete", func() {
+			BeforeEach(func() {
+				acceptsIncomplete = false
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(Equal(brokerapi.ErrAsyncRequired))
+			})
+		})
+
+		Context("when Service Plan is not found", func() {
+			BeforeEach(func() {
+				deprovisionDetails.PlanID = "unknown"
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+			})
+		})
+
+		Context("when deleting the DB Instance fails", func() {
+			BeforeEach(func() {
+				dbInstance.DeleteError = errors.New("operation failed")
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("operation failed"))
+			})
+
+			Context("when the DB instance does not exists", func() {
+				BeforeEach(func() {
+					dbInstance.DeleteError = awsrds.ErrDBInstanceDoesNotExist
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+				})
+			})
+		})
+
+		Context("when soft-delete is enabled", func() {
+			BeforeEach(func() {
+				softDelete = &SoftDeleteConfig{GracePeriod: "168h", CheckInterval: "1h"}
+				dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
+					Identifier: dbInstanceIdentifier,
+					Status:     "available",
+				}
+			})
+
+			It("stops and tags the DB instance instead of deleting it", func() {
+				asynch, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(asynch).To(BeFalse())
+
+				Expect(dbInstance.DeleteCalled).To(BeFalse())
+				Expect(dbInstance.StopCalled).To(BeTrue())
+				Expect(dbInstance.StopID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.AddTagsCalled).To(BeTrue())
+				Expect(dbInstance.AddTagsID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.AddTagsTags).To(HaveKeyWithValue("Deleted by", "AWS RDS Service Broker"))
+				Expect(dbInstance.AddTagsTags).To(HaveKey("Deleted at"))
+			})
+
+			Context("and the DB instance is already stopped", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeDBInstanceDetails.Status = "stopped"
+				})
+
+				It("only tags the DB instance", func() {
+					_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.StopCalled).To(BeFalse())
+					Expect(dbInstance.AddTagsCalled).To(BeTrue())
+				})
+			})
+
+			Context("and stopping the DB instance fails", func() {
+				BeforeEach(func() {
+					dbInstance.StopError = errors.New("operation failed")
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+					Expect(err).To(MatchError("operation failed"))
+					Expect(dbInstance.AddTagsCalled).To(BeFalse())
+				})
+			})
+
+			Context("and the DB instance does not exist", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Deprovision(instanceID, deprovisionDetails, acceptsIncomplete)
+					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+				})
+			})
+		})
+	})
+
+	var _ = Describe("Bind", func() {
+		var (
+			bindDetails brokerapi.BindDetails
+		)
+
+		BeforeEach(func() {
+			bindDetails = brokerapi.BindDetails{
+				ServiceID:  "Service-1",
+				PlanID:     "Plan-1",
+				AppGUID:    "Application-1",
+				Parameters: map[string]interface{}{},
+			}
+
+			dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
+				Identifier:     dbInstanceIdentifier,
+				Address:        "endpoint-address",
+				Port:           3306,
+				DBName:         "test-db",
+				MasterUsername: "master-username",
+			}
+
+			sqlEngine.CreateUserUsername = dbUsername
+			sqlEngine.CreateUserPassword = "secret"
+		})
+
+		It("returns the proper response", func() {
+			bindingResponse, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+			credentials := bindingResponse.Credentials.(*brokerapi.CredentialsHash)
+			Expect(bindingResponse.SyslogDrainURL).To(BeEmpty())
+			Expect(credentials.Host).To(Equal("endpoint-address"))
+			Expect(credentials.Port).To(Equal(int64(3306)))
+			Expect(credentials.Name).To(Equal("test-db"))
+			Expect(credentials.Username).To(Equal(dbUsername))
+			Expect(credentials.Password).To(Equal("secret"))
+			Expect(credentials.URI).To(ContainSubstring("@endpoint-address:3306/test-db?reconnect=true"))
+			Expect(credentials.JDBCURI).To(ContainSubstring("jdbc:fake://endpoint-address:3306/test-db?user=" + dbUsername + "&password="))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("makes the proper calls", func() {
+			_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+			Expect(dbInstance.DescribeCalled).To(BeTrue())
+			Expect(dbInstance.DescribeID).To(Equal(dbInstanceIdentifier))
+			Expect(sqlProvider.GetSQLEngineCalled).To(BeTrue())
+			Expect(sqlProvider.GetSQLEngineEngine).To(Equal("test-engine-1"))
+			Expect(sqlEngine.OpenCalled).To(BeTrue())
+			Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
+			Expect(sqlEngine.OpenPort).To(Equal(int64(3306)))
+			Expect(sqlEngine.OpenDBName).To(Equal("test-db"))
+			Expect(sqlEngine.OpenUsername).To(Equal("master-username"))
+			Expect(sqlEngine.OpenPassword).ToNot(BeEmpty())
+			Expect(sqlEngine.CreateUserCalled).To(BeTrue())
+			Expect(sqlEngine.CreateUserBindingID).To(Equal(bindingID))
+			Expect(sqlEngine.CreateUserDBName).To(Equal("test-db"))
+			Expect(sqlEngine.CloseCalled).To(BeTrue())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("connects with the master password derived from the seed with the first version", func() {
+			_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dbInstance.GetTagKey).To(Equal("Master password version"))
+			Expect(sqlEngine.OpenPassword).To(Equal(masterUserPassword))
+		})
+
+		Context("when the instance has a master password version", func() {
+			BeforeEach(func() {
+				dbInstance.GetTagValue = "2"
+			})
+
+			It("connects with the master password derived with that version", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.OpenPassword).To(Equal(latestMasterPassword))
+			})
+		})
+
+		Context("when the seed is being rotated", func() {
+			BeforeEach(func() {
+				previousMasterPasswordSeed = "old-secret"
+				sqlEngine.OpenAcceptedPassword = previousMasterPassword
+			})
+
+			It("connects with the master password derived from the previous seed", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.OpenPassword).To(Equal(previousMasterPassword))
+				Expect(sqlEngine.CreateUserCalled).To(BeTrue())
+			})
+
+			Context("but neither password works", func() {
+				BeforeEach(func() {
+					sqlEngine.OpenAcceptedPassword = "another-password"
+				})
+
+				It("returns the login error", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).To(Equal(sqlengine.LoginFailedError))
+					Expect(sqlEngine.CreateUserCalled).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the master password version is unknown", func() {
+			BeforeEach(func() {
+				dbInstance.GetTagValue = "99"
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(MatchError("Unknown master password version '99' of DB Instance 'cf-instance-id'"))
+				Expect(sqlEngine.OpenCalled).To(BeFalse())
+			})
+		})
+
+		Context("when there is a master password store", func() {
+			BeforeEach(func() {
+				secretStore = fakeSecretStore
+				fakeSecretStore.Secrets = map[string]string{dbInstanceIdentifier: "stored-password"}
+			})
+
+			It("connects with the stored master password", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeSecretStore.GetName).To(Equal(dbInstanceIdentifier))
+				Expect(sqlEngine.OpenPassword).To(Equal("stored-password"))
+			})
+
+			Context("but the DB instance has no master password in the store", func() {
+				BeforeEach(func() {
+					fakeSecretStore.Secrets = nil
+				})
+
+				It("connects with the master password derived from the seed", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sqlEngine.OpenPassword).To(Equal(masterUserPassword))
+				})
+			})
+
+			Context("when getting the master password fails", func() {
+				BeforeEach(func() {
+					fakeSecretStore.GetError = errors.New("operation failed")
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).To(MatchError("operation failed"))
+					Expect(sqlEngine.OpenCalled).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the plan has IAM database authentication", func() {
+			BeforeEach(func() {
+				rdsProperties1.IAMDatabaseAuthentication = true
+				dbInstance.DescribeDBInstanceDetails.DbiResourceId = "db-ABCDEFGHIJKL"
+				sqlEngine.CreateIAMUserUsername = "iam-user"
+			})
+
+			It("returns the credentials to generate authentication tokens", func() {
+				bindingResponse, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(bindingResponse.Credentials).To(Equal(&IAMCredentialsHash{
+					Host:       "endpoint-address",
+					Port:       3306,
+					Name:       "test-db",
+					Username:   "iam-user",
+					Region:     "rds-region",
+					ResourceID: "db-ABCDEFGHIJKL",
+				}))
+				Expect(sqlEngine.CreateIAMUserBindingID).To(Equal(bindingID))
+				Expect(sqlEngine.CreateIAMUserDBName).To(Equal("test-db"))
+				Expect(sqlEngine.CreateUserCalled).To(BeFalse())
+			})
+
+			Context("when binding a service key and service keys have their own users", func() {
+				BeforeEach(func() {
+					bindDetails.AppGUID = ""
+					serviceKeys = &ServiceKeysConfig{ReadOnly: true}
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).To(MatchError(ErrServiceKeysNotSupportedWithIAM.Error()))
+					Expect(err.(*FailureResponse).StatusCode()).To(Equal(http.StatusBadRequest))
+					Expect(sqlEngine.CreateIAMUserCalled).To(BeFalse())
+				})
+			})
+
+			Context("when binding a service key without a service keys policy", func() {
+				BeforeEach(func() {
+					bindDetails.AppGUID = ""
+				})
+
+				It("creates an IAM user", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sqlEngine.CreateIAMUserCalled).To(BeTrue())
+				})
+			})
+
+			Context("when creating the DB user fails", func() {
+				BeforeEach(func() {
+					sqlEngine.CreateIAMUserError = errors.New("Failed to create user")
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).To(MatchError("Failed to create user"))
+					Expect(sqlEngine.CloseCalled).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the credentials expire", func() {
+			BeforeEach(func() {
+				bindDetails.Parameters = map[string]interface{}{"expires_in": 3600}
+			})
+
+			It("creates a user valid until then", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.CreateUserOptions.ValidUntil).To(BeTemporally("~", time.Now().Add(time.Hour), 2*time.Second))
+			})
+
+			It("returns when the credentials expire", func() {
+				bindingResponse, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				credentials := bindingResponse.Credentials.(*ExpiringCredentialsHash)
+				Expect(credentials.Username).To(Equal(dbUsername))
+				Expect(credentials.Password).To(Equal("secret"))
+				Expect(*credentials.ExpiresAt).To(Equal(sqlEngine.CreateUserOptions.ValidUntil))
+			})
+
+			Context("but the engine cannot expire users", func() {
+				BeforeEach(func() {
+					sqlEngine.CreateUserError = sqlengine.ErrExpiringUsersNotSupported
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).To(BeAssignableToTypeOf(&FailureResponse{}))
+					Expect(err.(*FailureResponse).StatusCode()).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when the plan limits the users of the bindings", func() {
+			BeforeEach(func() {
+				rdsProperties1.Engine = "postgres"
+				rdsProperties1.BindingConnectionLimit = 10
+				rdsProperties1.BindingStatementTimeout = 30000
+			})
+
+			It("creates a user with the limits of the plan", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.CreateUserOptions).To(Equal(sqlengine.UserOptions{
+					ConnectionLimit:  10,
+					StatementTimeout: 30000,
+				}))
+			})
+
+			Context("and the binding has its own limits", func() {
+				BeforeEach(func() {
+					bindDetails.Parameters = map[string]interface{}{
+						"connection_limit":                    2,
+						"idle_in_transaction_session_timeout": 60000,
+					}
+				})
+
+				It("creates a user with the limits of the binding", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sqlEngine.CreateUserOptions).To(Equal(sqlengine.UserOptions{
+						ConnectionLimit:                 2,
+						StatementTimeout:                30000,
+						IdleInTransactionSessionTimeout: 60000,
+					}))
+				})
+			})
+		})
+
+		Context("when the plan engine has no session settings by user", func() {
+			BeforeEach(func() {
+				rdsProperties1.Engine = "mysql"
+				bindDetails.Parameters = map[string]interface{}{"statement_timeout": 30000}
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("statement_timeout"))
+				Expect(sqlEngine.CreateUserCalled).To(BeFalse())
+			})
+		})
+
+		It("creates users which do not expire by default", func() {
+			_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sqlEngine.CreateUserOptions).To(Equal(sqlengine.UserOptions{}))
+		})
+
+		Context("when binding a service key", func() {
+			BeforeEach(func() {
+				bindDetails.AppGUID = ""
+			})
+
+			It("gives it the credentials of the apps by default", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.CreateUserOptions).To(Equal(sqlengine.UserOptions{}))
+			})
+
+			Context("and service keys have their own users", func() {
+				BeforeEach(func() {
+					serviceKeys = &ServiceKeysConfig{ReadOnly: true, ExpiresIn: 86400}
+				})
+
+				It("creates a read-only user which expires", func() {
+					bindingResponse, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sqlEngine.CreateUserBindingID).To(Equal(bindingID))
+					Expect(sqlEngine.CreateUserOptions.ServiceKey).To(BeTrue())
+					Expect(sqlEngine.CreateUserOptions.ReadOnly).To(BeTrue())
+					Expect(sqlEngine.CreateUserOptions.ValidUntil).To(BeTemporally("~", time.Now().Add(24*time.Hour), 2*time.Second))
+
+					credentials := bindingResponse.Credentials.(*ExpiringCredentialsHash)
+					Expect(*credentials.ExpiresAt).To(Equal(sqlEngine.CreateUserOptions.ValidUntil))
+				})
+
+				It("keeps an earlier expiry of the binding", func() {
+					bindDetails.Parameters = map[string]interface{}{"expires_in": 3600}
+
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sqlEngine.CreateUserOptions.ValidUntil).To(BeTemporally("~", time.Now().Add(time.Hour), 2*time.Second))
+				})
+
+				It("does not apply to the bindings of apps", func() {
+					bindDetails.AppGUID = "Application-1"
+
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sqlEngine.CreateUserOptions).To(Equal(sqlengine.UserOptions{}))
+				})
+			})
+
+			Context("and service keys have users which do not expire", func() {
+				BeforeEach(func() {
+					serviceKeys = &ServiceKeysConfig{}
+				})
+
+				It("creates a user of its own which does not expire", func() {
+					bindingResponse, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sqlEngine.CreateUserOptions).To(Equal(sqlengine.UserOptions{ServiceKey: true}))
+					Expect(bindingResponse.Credentials).To(BeAssignableToTypeOf(&brokerapi.CredentialsHash{}))
+				})
+			})
+		})
+
+		Context("when Parameters are not valid", func() {
+			BeforeEach(func() {
+				bindDetails.Parameters = map[string]interface{}{"expires_in": 0}
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("expires_in"))
+				Expect(sqlEngine.CreateUserCalled).To(BeFalse())
+			})
+
+			Context("and user bind parameters are not allowed", func() {
+				BeforeEach(func() {
+					allowUserBindParameters = false
+				})
+
+				It("does not return an error", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when Service is not found", func() {
+			BeforeEach(func() {
+				bindDetails.ServiceID = "unknown"
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Service 'unknown' not found"))
+			})
+		})
+
+		Context("when Service is not bindable", func() {
+			BeforeEach(func() {
+				serviceBindable = false
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(Equal(brokerapi.ErrInstanceNotBindable))
+			})
+		})
+
+		Context("when Service Plan is not found", func() {
+			BeforeEach(func() {
+				bindDetails.PlanID = "unknown"
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+			})
+		})
+
+		Context("when describing the DB Instance fails", func() {
+			BeforeEach(func() {
+				dbInstance.DescribeError = errors.New("operation failed")
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("operation failed"))
+			})
+
+			Context("when the DB Instance does not exists", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+				})
+			})
+		})
+
+		Context("when getting the SQL Engine fails", func() {
+			BeforeEach(func() {
+				sqlProvider.GetSQLEngineError = errors.New("Engine 'unknown' not supported")
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Engine 'unknown' not supported"))
+			})
+		})
+
+		Context("when opening a DB connection fails", func() {
+			BeforeEach(func() {
+				sqlEngine.OpenError = errors.New("Failed to open sqlEngine")
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Failed to open sqlEngine"))
+			})
+		})
+
+		Context("when creating a DB user fails", func() {
+			BeforeEach(func() {
+				sqlEngine.CreateUserError = errors.New("Failed to create user")
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Failed to create user"))
+				Expect(sqlEngine.CloseCalled).To(BeTrue())
+			})
+		})
+	})
+
+	var _ = Describe("Unbind", func() {
+		var (
+			unbindDetails brokerapi.UnbindDetails
+		)
+
+		BeforeEach(func() {
+			unbindDetails = brokerapi.UnbindDetails{
+				ServiceID: "Service-1",
+				PlanID:    "Plan-1",
+			}
+
+			dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
+				Identifier:     dbInstanceIdentifier,
+				Address:        "endpoint-address",
+				Port:           3306,
+				DBName:         "test-db",
+				MasterUsername: "master-username",
+			}
+		})
+
+		It("makes the proper calls", func() {
+			err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+			Expect(dbInstance.DescribeCalled).To(BeTrue())
+			Expect(dbInstance.DescribeID).To(Equal(dbInstanceIdentifier))
+			Expect(sqlProvider.GetSQLEngineCalled).To(BeTrue())
+			Expect(sqlProvider.GetSQLEngineEngine).To(Equal("test-engine-1"))
+			Expect(sqlEngine.OpenCalled).To(BeTrue())
+			Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
+			Expect(sqlEngine.OpenPort).To(Equal(int64(3306)))
+			Expect(sqlEngine.OpenDBName).To(Equal("test-db"))
+			Expect(sqlEngine.OpenUsername).To(Equal("master-username"))
+			Expect(sqlEngine.OpenPassword).ToNot(BeEmpty())
+			Expect(sqlEngine.DropUserCalled).To(BeTrue())
+			Expect(sqlEngine.DropUserBindingID).To(Equal(bindingID))
+			Expect(sqlEngine.CloseCalled).To(BeTrue())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when there is a master password store", func() {
+			BeforeEach(func() {
+				secretStore = fakeSecretStore
+				fakeSecretStore.Secrets = map[string]string{dbInstanceIdentifier: "stored-password"}
+			})
+
+			It("connects with the stored master password", func() {
+				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.OpenPassword).To(Equal("stored-password"))
+			})
+		})
+
+		Context("when the plan has IAM database authentication", func() {
+			BeforeEach(func() {
+				rdsProperties1.IAMDatabaseAuthentication = true
+			})
+
+			It("drops the IAM user of the binding", func() {
+				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.DropIAMUserCalled).To(BeTrue())
+				Expect(sqlEngine.DropIAMUserBindingID).To(Equal(bindingID))
+				Expect(sqlEngine.DropUserCalled).To(BeFalse())
+			})
+		})
+
+		Context("when Service Plan is not found", func() {
+			BeforeEach(func() {
+				unbindDetails.PlanID = "unknown"
+			})
+
+			It("returns the proper error", func() {
+				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+			})
+		})
+
+		Context("when describing the DB Instance fails", func() {
+			BeforeEach(func() {
+				dbInstance.DescribeError = errors.New("operation failed")
+			})
+
+			It("returns the proper error", func() {
+				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("operation failed"))
+			})
+
+			Context("when the DB Instance does not exists", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
+				})
+
+				It("returns the proper error", func() {
+					err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+				})
+			})
+		})
+
+		Context("when getting the SQL Engine fails", func() {
+			BeforeEach(func() {
+				sqlProvider.GetSQLEngineError = errors.New("SQL Engine 'unknown' not supported")
+			})
+
+			It("returns the proper error", func() {
+				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("SQL Engine 'unknown' not supported"))
+			})
+		})
+
+		Context("when opening a DB connection fails", func() {
+			BeforeEach(func() {
+				sqlEngine.OpenError = errors.New("Failed to open sqlEngine")
+			})
+
+			It("returns the proper error", func() {
+				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Failed to open sqlEngine"))
+			})
+		})
+
+		Context("when deleting a user fails", func() {
+			BeforeEach(func() {
+				sqlEngine.DropUserError = errors.New("Failed to delete user")
+			})
+
+			It("returns the proper error", func() {
+				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Failed to delete user"))
+				Expect(sqlEngine.CloseCalled).To(BeTrue())
+			})
+		})
+	})
+
+	var _ = Describe("LastOperation", func() {
+		var (
+			dbInstanceStatus            string
+			lastOperationState          string
+			properLastOperationResponse brokerapi.LastOperationResponse
+		)
+
+		JustBeforeEach(func() {
+			dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
+				Identifier:     dbInstanceIdentifier,
+				Engine:         "test-engine",
+				Address:        "endpoint-address",
+				Port:           3306,
+				DBName:         "test-db",
+				MasterUsername: "master-username",
+				Status:         dbInstanceStatus,
+			}
+
+			properLastOperationResponse = brokerapi.LastOperationResponse{
+				State:       lastOperationState,
+				Description: "DB Instance '" + dbInstanceIdentifier + "' status is '" + dbInstanceStatus + "'",
+			}
+		})
+
+		Context("when describing the DB Instance fails", func() {
+			BeforeEach(func() {
+				dbInstance.DescribeError = errors.New("operation failed")
+			})
+
+			It("returns the proper error", func() {
+				_, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("operation failed"))
+			})
+
+			Context("when the DB Instance does not exists", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+				})
+			})
+		})
+
+		Context("when last operation is still in progress", func() {
+			BeforeEach(func() {
+				dbInstanceStatus = "creating"
+				lastOperationState = brokerapi.LastOperationInProgress
+			})
+
+			It("returns the proper LastOperationResponse", func() {
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+			})
+		})
+
+		Context("when last operation failed", func() {
+			BeforeEach(func() {
+				dbInstanceStatus = "failed"
+				lastOperationState = brokerapi.LastOperationFailed
+			})
+
+			It("returns the proper LastOperationResponse", func() {
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+			})
+		})
+
+		Context("when last operation succeeded", func() {
+			BeforeEach(func() {
+				dbInstanceStatus = "available"
+				lastOperationState = brokerapi.LastOperationSucceeded
+			})
+
+			It("returns the proper LastOperationResponse", func() {
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+			})
+
+			Context("but its DB parameter group is pending a reboot", func() {
+				JustBeforeEach(func() {
+					dbInstance.DescribeDBInstanceDetails.PendingReboot = true
+				})
+
+				It("reports that the DB Instance must be rebooted", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationSucceeded,
+						Description: "DB Instance '" + dbInstanceIdentifier + "' status is 'available', but it must be rebooted to apply the static parameters of its DB parameter group",
+					}))
+				})
+			})
+
+			Context("but has pending modifications", func() {
+				JustBeforeEach(func() {
+					dbInstance.DescribeDBInstanceDetails.PendingModifications = true
+
+					properLastOperationResponse = brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationInProgress,
+						Description: "DB Instance '" + dbInstanceIdentifier + "' has pending modifications",
+					}
+				})
+
+				It("returns the proper LastOperationResponse", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+				})
+			})
+
+			Context("but a snapshot is being created", func() {
+				JustBeforeEach(func() {
+					dbInstance.DescribeInstanceSnapshotsDBSnapshotDetails = []*awsrds.DBSnapshotDetails{
+						&awsrds.DBSnapshotDetails{Identifier: "old-snapshot", Status: "available"},
+						&awsrds.DBSnapshotDetails{Identifier: "new-snapshot", Status: "creating"},
+					}
+
+					properLastOperationResponse = brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationInProgress,
+						Description: "DB Snapshot 'new-snapshot' is being created",
+					}
+				})
+
+				It("returns the proper LastOperationResponse", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.DescribeInstanceSnapshotsID).To(Equal(dbInstanceIdentifier))
+					Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+				})
+			})
+		})
+
+		Context("when the groups of the DB Instance are waiting to be deleted", func() {
+			BeforeEach(func() {
+				dbInstanceStatus = "deleting"
+				Expect(workflowStore.Save(&workflow.Workflow{
+					InstanceID:  instanceID,
+					Kind:        "delete-groups",
+					Step:        "delete",
+					State:       workflow.StateInProgress,
+					Description: "DB Instance '" + dbInstanceIdentifier + "' status is 'deleting'",
+					Data: map[string]string{
+						"db_parameter_group": dbInstanceIdentifier,
+						"option_group":       dbInstanceIdentifier,
+					},
+				})).To(Succeed())
+			})
+
+			It("waits while the DB Instance is being deleted", func() {
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+					State:       brokerapi.LastOperationInProgress,
+					Description: "DB Instance '" + dbInstanceIdentifier + "' status is 'deleting'",
+				}))
+				Expect(dbInstance.DeleteParameterGroupCalled).To(BeFalse())
+				Expect(dbInstance.DeleteOptionGroupCalled).To(BeFalse())
+			})
+
+			Context("and the DB Instance is gone", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
+				})
+
+				It("deletes the groups and reports the instance as gone", func() {
+					_, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
+					Expect(dbInstance.DeleteParameterGroupID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.DeleteOptionGroupID).To(Equal(dbInstanceIdentifier))
+
+					_, err = workflowStore.Get(instanceID)
+					Expect(err).To(Equal(workflow.ErrNotFound))
+				})
+			})
+		})
+
+		Context("when the groups left behind by a plan change are waiting to be deleted", func() {
+			var dbParameterGroupName string
+
+			BeforeEach(func() {
+				dbInstanceStatus = "available"
+				dbParameterGroupName = dbInstanceIdentifier
+				Expect(workflowStore.Save(&workflow.Workflow{
+					InstanceID:  instanceID,
+					Kind:        "delete-groups",
+					Step:        "delete",
+					State:       workflow.StateInProgress,
+					Description: "DB Instance '" + dbInstanceIdentifier + "' status is 'modifying'",
+					Data:        map[string]string{"db_parameter_group": dbInstanceIdentifier},
+				})).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				dbInstance.DescribeDBInstanceDetails.DBParameterGroupName = dbParameterGroupName
+			})
+
+			It("waits while the DB Instance uses them", func() {
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+				Expect(dbInstance.DeleteParameterGroupCalled).To(BeFalse())
+			})
+
+			Context("and the DB Instance uses the groups of its plan", func() {
+				BeforeEach(func() {
+					dbParameterGroupName = "plan-group"
+				})
+
+				It("deletes them and reports the status of the DB Instance", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.DeleteParameterGroupID).To(Equal(dbInstanceIdentifier))
+					Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+
+					_, err = workflowStore.Get(instanceID)
+					Expect(err).To(Equal(workflow.ErrNotFound))
+				})
+
+				It("waits for the pending modifications of the DB Instance", func() {
+					dbInstance.DescribeDBInstanceDetails.PendingModifications = true
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+					Expect(dbInstance.DeleteParameterGroupCalled).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the post-provision SQL is pending", func() {
+			BeforeEach(func() {
+				dbInstanceStatus = "creating"
+				rdsProperties1.PostProvisionSQL = []string{"CREATE EXTENSION postgis", "CREATE EXTENSION pg_trgm"}
+				Expect(workflowStore.Save(&workflow.Workflow{
+					InstanceID:  instanceID,
+					Kind:        "post-provision",
+					Step:        "sql",
+					State:       workflow.StateInProgress,
+					Description: "Creating DB Instance '" + dbInstanceIdentifier + "'",
+					Data:        map[string]string{"plan_id": "Plan-1"},
+				})).To(Succeed())
+			})
+
+			It("waits while the DB Instance is being created", func() {
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+					State:       brokerapi.LastOperationInProgress,
+					Description: "DB Instance '" + dbInstanceIdentifier + "' status is 'creating'",
+				}))
+				Expect(sqlEngine.ExecuteStatementsCalled).To(BeFalse())
+			})
+
+			Context("and the DB Instance is available", func() {
+				BeforeEach(func() {
+					dbInstanceStatus = "available"
+				})
+
+				It("runs the SQL with the master credentials before reporting success", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationSucceeded,
+						Description: "DB Instance '" + dbInstanceIdentifier + "' status is 'available'",
+					}))
+					Expect(sqlProvider.GetSQLEngineEngine).To(Equal("test-engine-1"))
+					Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
+					Expect(sqlEngine.OpenDBName).To(Equal("test-db"))
+					Expect(sqlEngine.OpenUsername).To(Equal("master-username"))
+					Expect(sqlEngine.OpenPassword).To(Equal(masterUserPassword))
+					Expect(sqlEngine.ExecuteStatementsStatements).To(Equal([]string{"CREATE EXTENSION postgis", "CREATE EXTENSION pg_trgm"}))
+					Expect(sqlEngine.CloseCalled).To(BeTrue())
+
+					_, err = workflowStore.Get(instanceID)
+					Expect(err).To(Equal(workflow.ErrNotFound))
+				})
+
+				Context("when extensions were requested", func() {
+					BeforeEach(func() {
+						w, err := workflowStore.Get(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						w.Data["extensions"] = "pg_trgm postgis"
+						Expect(workflowStore.Save(w)).To(Succeed())
+					})
+
+					It("creates them after the SQL and reports them on the DB Instance", func() {
+						lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+						Expect(sqlEngine.ExecuteStatementsCalled).To(BeTrue())
+						Expect(sqlEngine.CreateExtensionsCalled).To(BeFalse())
+
+						lastOperationResponse, err = rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+						Expect(sqlEngine.CreateExtensionsExtensions).To(Equal([]string{"pg_trgm", "postgis"}))
+						Expect(dbInstance.AddTagsCalled).To(BeFalse())
+
+						lastOperationResponse, err = rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationSucceeded))
+						Expect(dbInstance.AddTagsID).To(Equal(dbInstanceIdentifier))
+						Expect(dbInstance.AddTagsTags).To(Equal(map[string]string{"Extensions": "pg_trgm postgis"}))
+					})
+
+					Context("and creating them fails", func() {
+						BeforeEach(func() {
+							sqlEngine.CreateExtensionsError = errors.New("extension not available")
+						})
+
+						It("retries without running the SQL again", func() {
+							_, err := rdsBroker.LastOperation(instanceID)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(sqlEngine.ExecuteStatementsCalled).To(BeTrue())
+
+							sqlEngine.ExecuteStatementsCalled = false
+							for i := 0; i < 2; i++ {
+								lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+								Expect(err).ToNot(HaveOccurred())
+								Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+							}
+							Expect(sqlEngine.CreateExtensionsCalled).To(BeTrue())
+							Expect(sqlEngine.ExecuteStatementsCalled).To(BeFalse())
+
+							w, err := workflowStore.Get(instanceID)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(w.Step).To(Equal("extensions"))
+						})
+					})
+				})
+
+				Context("when the SQL fails", func() {
+					BeforeEach(func() {
+						sqlEngine.ExecuteStatementsError = errors.New("extension not available")
+					})
+
+					It("retries before reporting the failure", func() {
+						for i := 0; i < 4; i++ {
+							lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+						}
+
+						lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+							State:       brokerapi.LastOperationFailed,
+							Description: "Could not run the post-provision SQL on DB Instance '" + dbInstanceIdentifier + "': extension not available",
+						}))
+					})
+				})
+			})
+		})
+
+		Context("when a pre-change snapshot is pending", func() {
+			var snapshotStatus string
+
+			BeforeEach(func() {
+				dbInstanceStatus = "available"
+				snapshotStatus = "creating"
+				Expect(workflowStore.Save(&workflow.Workflow{
+					InstanceID:  instanceID,
+					Kind:        "pre-change-snapshot",
+					Step:        "snapshot",
+					State:       workflow.StateInProgress,
+					Description: "Taking pre-change DB Snapshot 'pre-change-snapshot' before modifying DB Instance '" + dbInstanceIdentifier + "'",
+					Data: map[string]string{
+						"snapshot_id":      "pre-change-snapshot",
+						"service_id":       "Service-1",
+						"plan_id":          "Plan-2",
+						"previous_plan_id": "Plan-1",
+						"parameters":       `{"apply_immediately":true}`,
+					},
+				})).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				dbInstance.DescribeInstanceSnapshotsDBSnapshotDetails = []*awsrds.DBSnapshotDetails{
+					&awsrds.DBSnapshotDetails{
+						Identifier: "pre-change-snapshot",
+						Status:     snapshotStatus,
+						Tags:       map[string]string{"Snapshot type": "pre-change"},
+					},
+					&awsrds.DBSnapshotDetails{
+						Identifier: "previous-pre-change-snapshot",
+						Status:     "available",
+						Tags:       map[string]string{"Snapshot type": "pre-change"},
+					},
+					&awsrds.DBSnapshotDetails{
+						Identifier: "manual-snapshot",
+						Status:     "available",
+						Tags:       map[string]string{"Snapshot type": "manual"},
+					},
+				}
+			})
+
+			It("reports the snapshot in progress", func() {
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+					State:       brokerapi.LastOperationInProgress,
+					Description: "Taking pre-change DB Snapshot 'pre-change-snapshot' before modifying DB Instance '" + dbInstanceIdentifier + "'",
+				}))
+				Expect(dbInstance.ModifyCalled).To(BeFalse())
+				Expect(dbInstance.DeleteSnapshotCalled).To(BeFalse())
+			})
+
+			Context("and the snapshot is available", func() {
+				BeforeEach(func() {
+					snapshotStatus = "available"
+				})
+
+				It("modifies the DB instance", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationInProgress,
+						Description: "Pre-change DB Snapshot 'pre-change-snapshot' is available, modifying DB Instance '" + dbInstanceIdentifier + "'",
+					}))
+					Expect(dbInstance.ModifyCalled).To(BeTrue())
+					Expect(dbInstance.ModifyID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.ModifyDBInstanceDetails.DBInstanceClass).To(Equal("db.m2.test"))
+					Expect(dbInstance.ModifyDBInstanceDetails.Tags).To(HaveKeyWithValue("Plan ID", "Plan-2"))
+					Expect(dbInstance.ModifyDBInstanceDetails.Tags).To(HaveKeyWithValue("Service ID", "Service-1"))
+					Expect(dbInstance.ModifyApplyImmediately).To(BeTrue())
+				})
+
+				Context("and the new plan gives the DB instance its own DB parameter group", func() {
+					BeforeEach(func() {
+						rdsProperties2.DBParameterGroupName = "plan-group"
+						rdsProperties2.UserDBParameters = map[string]ParameterConstraints{
+							"max_connections": ParameterConstraints{},
+						}
+					})
+
+					It("creates it before modifying the DB instance", func() {
+						_, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(dbInstance.CopyParameterGroupCalled).To(BeTrue())
+						Expect(dbInstance.CopyParameterGroupSourceID).To(Equal("plan-group"))
+						Expect(dbInstance.CopyParameterGroupID).To(Equal(dbInstanceIdentifier))
+						Expect(dbInstance.ModifyDBInstanceDetails.DBParameterGroupName).To(Equal(dbInstanceIdentifier))
+					})
+				})
+
+				Context("and the DB instance leaves its own DB parameter group behind", func() {
+					BeforeEach(func() {
+						rdsProperties1.UserDBParameters = map[string]ParameterConstraints{
+							"max_connections": ParameterConstraints{},
+						}
+					})
+
+					It("deletes it once the DB instance has stopped using it", func() {
+						_, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+
+						w, err := workflowStore.Get(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(w.Step).To(Equal("delete"))
+						Expect(w.Data).To(HaveKeyWithValue("db_parameter_group", dbInstanceIdentifier))
+						Expect(dbInstance.DeleteParameterGroupCalled).To(BeFalse())
+
+						_, err = rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(dbInstance.DeleteParameterGroupID).To(Equal(dbInstanceIdentifier))
+					})
+				})
+
+				It("deletes the previous pre-change snapshots", func() {
+					_, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.DeleteSnapshotIDs).To(Equal([]string{"previous-pre-change-snapshot"}))
+				})
+
+				It("then reports the status of the DB instance", func() {
+					_, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+
+					_, err = workflowStore.Get(instanceID)
+					Expect(err).To(Equal(workflow.ErrNotFound))
+				})
+
+				Context("but the DB instance is still backing up", func() {
+					BeforeEach(func() {
+						dbInstanceStatus = "backing-up"
+					})
+
+					It("waits for the DB instance", func() {
+						lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+						Expect(dbInstance.ModifyCalled).To(BeFalse())
+					})
+				})
+
+				Context("but modifying the DB instance fails", func() {
+					BeforeEach(func() {
+						dbInstance.ModifyError = errors.New("operation failed")
+					})
+
+					It("reports the failure", func() {
+						lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+							State:       brokerapi.LastOperationFailed,
+							Description: "Could not modify DB Instance '" + dbInstanceIdentifier + "' after pre-change DB Snapshot 'pre-change-snapshot': operation failed",
+						}))
+
+						_, err = workflowStore.Get(instanceID)
+						Expect(err).To(Equal(workflow.ErrNotFound))
+					})
+				})
+			})
+
+			Context("and the snapshot failed", func() {
+				BeforeEach(func() {
+					snapshotStatus = "failed"
+				})
+
+				It("reports the failure without modifying the DB instance", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationFailed,
+						Description: "Pre-change DB Snapshot 'pre-change-snapshot' failed, DB Instance '" + dbInstanceIdentifier + "' has not been modified",
+					}))
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+					Expect(dbInstance.DeleteSnapshotCalled).To(BeFalse())
+				})
+			})
+		})
+		Context("when the encryption is being changed", func() {
+			var (
+				step         string
+				failure      string
+				swapped      bool
+				snapshotTags map[string]string
+			)
+
+			BeforeEach(func() {
+				step = "snapshot"
+				failure = ""
+				swapped = false
+				snapshotTags = map[string]string{
+					"Service ID":    "Service-1",
+					"Snapshot type": "encryption-change",
+				}
+				dbInstance.DescribeInstanceSnapshotsDBSnapshotDetails = []*awsrds.DBSnapshotDetails{
+					&awsrds.DBSnapshotDetails{Identifier: "encryption-snapshot", Status: "available", Tags: snapshotTags},
+					&awsrds.DBSnapshotDetails{Identifier: "encryption-snapshot-encrypted", Status: "available", Tags: snapshotTags},
+				}
+			})
+
+			JustBeforeEach(func() {
+				dbInstance.Instances = map[string]awsrds.DBInstanceDetails{
+					dbInstanceIdentifier: awsrds.DBInstanceDetails{
+						Identifier:                 dbInstanceIdentifier,
+						Status:                     "available",
+						BackupRetentionPeriod:      7,
+						PreferredBackupWindow:      "03:00-03:30",
+						PreferredMaintenanceWindow: "sun:04:00-sun:04:30",
+						Tags: map[string]string{
+							"Encryption change": "encryption-snapshot",
+							"Plan ID":           "Plan-1",
+						},
+					},
+					dbInstanceIdentifier + "-new": awsrds.DBInstanceDetails{
+						Identifier: dbInstanceIdentifier + "-new",
+						Status:     "available",
+					},
+				}
+
+				data := map[string]string{
+					"snapshot_id":      "encryption-snapshot",
+					"service_id":       "Service-1",
+					"plan_id":          "Plan-2",
+					"previous_plan_id": "Plan-1",
+				}
+				if swapped {
+					data["swapped"] = "true"
+				}
+				Expect(workflowStore.Save(&workflow.Workflow{
+					InstanceID:  instanceID,
+					Kind:        "encryption-change",
+					Step:        step,
+					State:       workflow.StateInProgress,
+					Description: "Taking DB Snapshot 'encryption-snapshot' before changing the encryption of DB Instance '" + dbInstanceIdentifier + "'",
+					Failure:     failure,
+					Data:        data,
+				})).To(Succeed())
+			})
+
+			currentStep := func() string {
+				w, err := workflowStore.Get(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				return w.Step
+			}
+
+			It("encrypts a copy of the snapshot once it is available", func() {
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+					State:       brokerapi.LastOperationInProgress,
+					Description: "Encrypting a copy of DB Snapshot 'encryption-snapshot'",
+				}))
+				Expect(dbInstance.CopySnapshotID).To(Equal("encryption-snapshot"))
+				Expect(dbInstance.CopySnapshotTargetID).To(Equal("encryption-snapshot-encrypted"))
+				Expect(dbInstance.CopySnapshotKmsKeyID).To(Equal("alias/aws/rds"))
+				Expect(dbInstance.CopySnapshotTags).To(Equal(snapshotTags))
+				Expect(currentStep()).To(Equal("copy"))
+			})
+
+			Context("and the new plan has a KMS key", func() {
+				BeforeEach(func() {
+					rdsProperties2.KmsKeyID = "test-kms-key-id"
+				})
+
+				It("encrypts the copy with it", func() {
+					_, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dbInstance.CopySnapshotKmsKeyID).To(Equal("test-kms-key-id"))
+				})
+			})
+
+			It("waits for the snapshot", func() {
+				dbInstance.DescribeInstanceSnapshotsDBSnapshotDetails[0].Status = "creating"
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+					State:       brokerapi.LastOperationInProgress,
+					Description: "Taking DB Snapshot 'encryption-snapshot' before changing the encryption of DB Instance '" + dbInstanceIdentifier + "'",
+				}))
+				Expect(dbInstance.CopySnapshotCalled).To(BeFalse())
+			})
+
+			It("rolls back when the snapshot fails", func() {
+				dbInstance.DescribeInstanceSnapshotsDBSnapshotDetails[0].Status = "failed"
+				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+				Expect(currentStep()).To(Equal("rollback"))
+
+				lastOperationResponse, err = rdsBroker.LastOperation(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+					State:       brokerapi.LastOperationFailed,
+					Description: "Could not change the encryption of DB Instance '" + dbInstanceIdentifier + "': DB Snapshot 'encryption-snapshot' failed. The changes have been rolled back",
+				}))
+				Expect(dbInstance.CopySnapshotCalled).To(BeFalse())
+				Expect(dbInstance.DeleteSnapshotIDs).To(Equal([]string{"encryption-snapshot", "encryption-snapshot-encrypted"}))
+				Expect(dbInstance.RemoveTagsID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.RemoveTagsKeys).To(Equal([]string{"Encryption change"}))
+			})
+
+			Context("and the encrypted copy is available", func() {
+				BeforeEach(func() {
+					step = "copy"
+				})
+
+				It("restores a new DB instance from it", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+					Expect(dbInstance.RestoreFromSnapshotID).To(Equal(dbInstanceIdentifier + "-new"))
+					Expect(dbInstance.RestoreFromSnapshotSnapshotID).To(Equal("encryption-snapshot-encrypted"))
+					Expect(dbInstance.RestoreFromSnapshotDBInstanceDetails.DBInstanceClass).To(Equal("db.m2.test"))
+					Expect(dbInstance.RestoreFromSnapshotDBInstanceDetails.Tags).To(Equal(map[string]string{
+						"Encryption change": "encryption-snapshot",
+						"Plan ID":           "Plan-1",
+					}))
+					Expect(currentStep()).To(Equal("restore"))
+				})
+
+				Context("and the new plan gives the DB instance its own DB parameter group", func() {
+					BeforeEach(func() {
+						rdsProperties2.DBParameterGroupName = "plan-group"
+						rdsProperties2.UserDBParameters = map[string]ParameterConstraints{
+							"max_connections": ParameterConstraints{},
+						}
+					})
+
+					It("creates it before restoring the new DB instance into it", func() {
+						_, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(dbInstance.CopyParameterGroupSourceID).To(Equal("plan-group"))
+						Expect(dbInstance.CopyParameterGroupID).To(Equal(dbInstanceIdentifier))
+						Expect(dbInstance.RestoreFromSnapshotDBInstanceDetails.DBParameterGroupName).To(Equal(dbInstanceIdentifier))
+					})
+				})
+
+				Context("but restoring fails", func() {
+					BeforeEach(func() {
+						dbInstance.RestoreFromSnapshotError = errors.New("operation failed")
+					})
+
+					It("retries later", func() {
+						lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+
+						w, err := workflowStore.Get(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(w.Step).To(Equal("copy"))
+						Expect(w.LastError).To(Equal("operation failed"))
+					})
+				})
+			})
+
+			Context("and the new DB instance has been restored", func() {
+				BeforeEach(func() {
+					step = "restore"
+				})
+
+				It("configures it like the original one", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationInProgress,
+						Description: "Configuring DB Instance '" + dbInstanceIdentifier + "-new'",
+					}))
+					Expect(dbInstance.ModifyID).To(Equal(dbInstanceIdentifier + "-new"))
+					Expect(dbInstance.ModifyApplyImmediately).To(BeTrue())
+					Expect(dbInstance.ModifyDBInstanceDetails.DBInstanceClass).To(Equal("db.m2.test"))
+					Expect(dbInstance.ModifyDBInstanceDetails.BackupRetentionPeriod).To(Equal(int64(7)))
+					Expect(dbInstance.ModifyDBInstanceDetails.PreferredBackupWindow).To(Equal("03:00-03:30"))
+					Expect(dbInstance.ModifyDBInstanceDetails.PreferredMaintenanceWindow).To(Equal("sun:04:00-sun:04:30"))
+					Expect(dbInstance.ModifyDBInstanceDetails.Tags).To(HaveKeyWithValue("Plan ID", "Plan-2"))
+					Expect(currentStep()).To(Equal("configure"))
+				})
+
+				It("waits for the new DB instance", func() {
+					newDBInstance := dbInstance.Instances[dbInstanceIdentifier+"-new"]
+					newDBInstance.Status = "creating"
+					dbInstance.Instances[dbInstanceIdentifier+"-new"] = newDBInstance
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationInProgress,
+						Description: "Preparing DB Instance '" + dbInstanceIdentifier + "-new', its status is 'creating'",
+					}))
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+
+				It("rolls back when the new DB instance failed", func() {
+					newDBInstance := dbInstance.Instances[dbInstanceIdentifier+"-new"]
+					newDBInstance.Status = "failed"
+					dbInstance.Instances[dbInstanceIdentifier+"-new"] = newDBInstance
+					_, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationFailed))
+					Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier + "-new"))
+					Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeTrue())
+					Expect(dbInstance.DeleteSnapshotIDs).To(Equal([]string{"encryption-snapshot", "encryption-snapshot-encrypted"}))
+					Expect(dbInstance.RemoveTagsID).To(Equal(dbInstanceIdentifier))
+				})
+			})
+
+			Context("and the new DB instance has been configured", func() {
+				BeforeEach(func() {
+					step = "configure"
+				})
+
+				It("renames the original DB instance out of the way", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationInProgress,
+						Description: "Swapping DB Instance '" + dbInstanceIdentifier + "' with '" + dbInstanceIdentifier + "-new'",
+					}))
+					Expect(dbInstance.RenameIDs).To(Equal([]string{dbInstanceIdentifier}))
+					Expect(dbInstance.RenameNewIDs).To(Equal([]string{dbInstanceIdentifier + "-old"}))
+					Expect(currentStep()).To(Equal("rename"))
+				})
+			})
+
+			Context("and the original DB instance has been renamed", func() {
+				BeforeEach(func() {
+					step = "rename"
+				})
+
+				JustBeforeEach(func() {
+					oldDBInstance := dbInstance.Instances[dbInstanceIdentifier]
+					oldDBInstance.Identifier = dbInstanceIdentifier + "-old"
+					delete(dbInstance.Instances, dbInstanceIdentifier)
+					dbInstance.Instances[dbInstanceIdentifier+"-old"] = oldDBInstance
+				})
+
+				It("gives the identifier to the new DB instance", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+					Expect(dbInstance.RenameIDs).To(Equal([]string{dbInstanceIdentifier + "-new"}))
+					Expect(dbInstance.RenameNewIDs).To(Equal([]string{dbInstanceIdentifier}))
+					Expect(currentStep()).To(Equal("cleanup"))
+
+					w, err := workflowStore.Get(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(w.Data).To(HaveKeyWithValue("swapped", "true"))
+				})
+
+				It("waits while the original DB instance is being renamed", func() {
+					delete(dbInstance.Instances, dbInstanceIdentifier+"-old")
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+					Expect(dbInstance.RenameCalled).To(BeFalse())
+				})
+
+				Context("but renaming the new DB instance fails", func() {
+					BeforeEach(func() {
+						dbInstance.RenameError = errors.New("operation failed")
+					})
+
+					It("rolls back", func() {
+						lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+						Expect(currentStep()).To(Equal("rollback"))
+					})
+				})
+			})
+
+			Context("and the DB instances have been swapped", func() {
+				BeforeEach(func() {
+					step = "cleanup"
+				})
+
+				It("deletes the original DB instance and the snapshots", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationSucceeded,
+						Description: "DB Instance '" + dbInstanceIdentifier + "' status is 'available'",
+					}))
+					Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier + "-old"))
+					Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeTrue())
+					Expect(dbInstance.DeleteSnapshotIDs).To(Equal([]string{"encryption-snapshot", "encryption-snapshot-encrypted"}))
+					Expect(dbInstance.RemoveTagsID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.RemoveTagsKeys).To(Equal([]string{"Encryption change"}))
+
+					_, err = workflowStore.Get(instanceID)
+					Expect(err).To(Equal(workflow.ErrNotFound))
+				})
+
+				It("waits while the new DB instance is being renamed", func() {
+					delete(dbInstance.Instances, dbInstanceIdentifier)
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationInProgress))
+					Expect(dbInstance.DeleteCalled).To(BeFalse())
+				})
+			})
+
+			Context("and the encryption change is rolling back", func() {
+				BeforeEach(func() {
+					step = "rollback"
+					failure = "operation failed"
+				})
+
+				It("deletes the new DB instance and the snapshots", func() {
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationFailed,
+						Description: "Could not change the encryption of DB Instance '" + dbInstanceIdentifier + "': operation failed. The changes have been rolled back",
+					}))
+					Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier + "-new"))
+					Expect(dbInstance.DeleteSnapshotIDs).To(Equal([]string{"encryption-snapshot", "encryption-snapshot-encrypted"}))
+					Expect(dbInstance.RemoveTagsKeys).To(Equal([]string{"Encryption change"}))
+				})
+
+				It("first renames the original DB instance back", func() {
+					oldDBInstance := dbInstance.Instances[dbInstanceIdentifier]
+					oldDBInstance.Identifier = dbInstanceIdentifier + "-old"
+					delete(dbInstance.Instances, dbInstanceIdentifier)
+					dbInstance.Instances[dbInstanceIdentifier+"-old"] = oldDBInstance
+
+					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+						State:       brokerapi.LastOperationInProgress,
+						Description: "Rolling back the encryption change of DB Instance '" + dbInstanceIdentifier + "'",
+					}))
+					Expect(dbInstance.RenameIDs).To(Equal([]string{dbInstanceIdentifier + "-old"}))
+					Expect(dbInstance.RenameNewIDs).To(Equal([]string{dbInstanceIdentifier}))
+					Expect(dbInstance.DeleteCalled).To(BeFalse())
+				})
+
+				Context("after the DB instances have been swapped", func() {
+					BeforeEach(func() {
+						swapped = true
+					})
+
+					It("deletes the original DB instance instead", func() {
+						lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse).To(Equal(brokerapi.LastOperationResponse{
+							State:       brokerapi.LastOperationInProgress,
+							Description: "Deleting the previous DB Instance '" + dbInstanceIdentifier + "-old'",
+						}))
+						Expect(dbInstance.DeleteCalled).To(BeFalse())
+						Expect(dbInstance.RenameCalled).To(BeFalse())
+						Expect(currentStep()).To(Equal("cleanup"))
+
+						lastOperationResponse, err = rdsBroker.LastOperation(instanceID)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(lastOperationResponse.State).To(Equal(brokerapi.LastOperationSucceeded))
+						Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier + "-old"))
+						Expect(dbInstance.DeleteSnapshotIDs).To(Equal([]string{"encryption-snapshot", "encryption-snapshot-encrypted"}))
+						Expect(dbInstance.RemoveTagsKeys).To(Equal([]string{"Encryption change"}))
+					})
+				})
+			})
+		})
+	})
+
+	var _ = Describe("RebalanceWindows", func() {
+		Context("when the window scheduler is not configured", func() {
+			It("returns the proper error", func() {
+				_, err := rdsBroker.RebalanceWindows()
+				Expect(err).To(MatchError(ErrWindowSchedulerNotConfigured))
+				Expect(dbInstance.DescribeByTagCalled).To(BeFalse())
+			})
+		})
+
+		Context("when the window scheduler is configured", func() {
+			BeforeEach(func() {
+				windowScheduler = &WindowSchedulerConfig{
+					BackupWindowRanges:      []string{"01:00-02:00"},
+					MaintenanceWindowRanges: []string{"tue:01:00-tue:02:00"},
+					WindowDuration:          30,
+				}
+				dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
+					&awsrds.DBInstanceDetails{
+						Identifier:                 dbInstanceIdentifier,
+						PreferredBackupWindow:      "03:00-03:30",
+						PreferredMaintenanceWindow: "sun:04:00-sun:04:30",
+						Tags: map[string]string{
+							"Plan ID": "Plan-1",
+						},
+					},
+				}
+			})
+
+			It("moves the instances to their scheduled windows", func() {
+				assignments, err := rdsBroker.RebalanceWindows()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.DescribeByTagKey).To(Equal("Broker Name"))
+				Expect(dbInstance.DescribeByTagValue).To(Equal(brokerName))
+
+				Expect(assignments).To(HaveLen(1))
+				Expect(assignments[0].InstanceID).To(Equal(instanceID))
+				Expect(assignments[0].PreferredBackupWindow).To(HavePrefix("01:"))
+				Expect(assignments[0].PreferredMaintenanceWindow).To(HavePrefix("tue:01:"))
+				Expect(assignments[0].Error).To(BeEmpty())
+
+				Expect(dbInstance.ModifyCalled).To(BeTrue())
+				Expect(dbInstance.ModifyID).To(Equal(dbInstanceIdentifier))
+				Expect(dbInstance.ModifyDBInstanceDetails).To(Equal(awsrds.DBInstanceDetails{
+					PreferredBackupWindow:      assignments[0].PreferredBackupWindow,
+					PreferredMaintenanceWindow: assignments[0].PreferredMaintenanceWindow,
+				}))
+				Expect(dbInstance.ModifyApplyImmediately).To(BeFalse())
+			})
+
+			Context("and the instance already has its scheduled windows", func() {
+				BeforeEach(func() {
+					backupWindow, maintenanceWindow := NewWindowScheduler(*windowScheduler).Schedule(instanceID, "", "")
+					dbInstance.DescribeByTagDBInstanceDetails[0].PreferredBackupWindow = backupWindow
+					dbInstance.DescribeByTagDBInstanceDetails[0].PreferredMaintenanceWindow = maintenanceWindow
+				})
+
+				It("does not modify the instance", func() {
+					assignments, err := rdsBroker.RebalanceWindows()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(assignments).To(BeEmpty())
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
+
+			Context("and the user has set the windows", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeByTagDBInstanceDetails[0].Tags["UserPreferredBackupWindow"] = "true"
+					dbInstance.DescribeByTagDBInstanceDetails[0].Tags["UserPreferredMaintenanceWindow"] = "true"
+				})
+
+				It("does not modify the instance", func() {
+					assignments, err := rdsBroker.RebalanceWindows()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(assignments).To(BeEmpty())
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
+
+			Context("and modifying the instance fails", func() {
+				BeforeEach(func() {
+					dbInstance.ModifyError = errors.New("operation failed")
+				})
+
+				It("reports the error for the instance", func() {
+					assignments, err := rdsBroker.RebalanceWindows()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(assignments).To(HaveLen(1))
+					Expect(assignments[0].Error).To(Equal("operation failed"))
+				})
+			})
+
+			Context("and listing the instances fails", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeByTagError = errors.New("operation failed")
+				})
+
+				It("returns the proper error", func() {
+					_, err := rdsBroker.RebalanceWindows()
+					Expect(err).To(MatchError("operation failed"))
+				})
+			})
+		})
+	})
+
+	var _ = Describe("CheckAndRotateCredentials", func() {
+		Context("when there is no DB instance", func() {
+			It("shouldn't try to connect to databases", func() {
+				rdsBroker.CheckAndRotateCredentials()
+				Expect(sqlProvider.GetSQLEngineCalled).To(BeFalse())
+				Expect(sqlEngine.OpenCalled).To(BeFalse())
+			})
+		})
+
+		Context("when there are DB instances", func() {
+			BeforeEach(func() {
+				dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
+					&awsrds.DBInstanceDetails{
+						Identifier:     dbInstanceIdentifier,
+						Address:        "endpoint-address",
+						Port:           3306,
+						DBName:         "test-db",
+						MasterUsername: "master-username",
+						Engine:         "fake-engine",
+					},
+				}
+			})
+
+			It("should try to connect to databases", func() {
+				rdsBroker.CheckAndRotateCredentials()
+				Expect(dbInstance.DescribeByTagCalled).To(BeTrue())
+				Expect(dbInstance.DescribeByTagKey).To(BeEquivalentTo("Broker Name"))
+				Expect(dbInstance.DescribeByTagValue).To(BeEquivalentTo(brokerName))
+				Expect(sqlProvider.GetSQLEngineCalled).To(BeTrue())
+				Expect(sqlProvider.GetSQLEngineEngine).To(BeEquivalentTo("fake-engine"))
+				Expect(sqlEngine.OpenCalled).To(BeTrue())
+				Expect(sqlEngine.OpenAddress).To(BeEquivalentTo("endpoint-address"))
+				Expect(sqlEngine.OpenPort).To(BeEquivalentTo(3306))
+				Expect(sqlEngine.OpenDBName).To(BeEquivalentTo("test-db"))
+				Expect(sqlEngine.OpenUsername).To(BeEquivalentTo("master-username"))
+			})
+
+			Context("and an instance has been soft-deleted", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeByTagDBInstanceDetails[0].Tags = map[string]string{
+						"Deleted at": time.Now().Format(time.RFC822Z),
+					}
+				})
+
+				It("should not try to connect to it", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(sqlEngine.OpenCalled).To(BeFalse())
+				})
+			})
+
+			Context("and the passwords work", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeByTagDBInstanceDetails[0].Tags = map[string]string{
+						"Master password version": "2",
+					}
+				})
+
+				It("should not try to change the master password", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(sqlEngine.OpenPassword).To(Equal(latestMasterPassword))
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+					Expect(dbInstance.AddTagsCalled).To(BeFalse())
+				})
+			})
+
+			Context("and the master password uses an old version", func() {
+				BeforeEach(func() {
+					sqlEngine.OpenAcceptedPassword = masterUserPassword
+				})
+
+				It("should move the master password to the latest version", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(dbInstance.ModifyCalled).To(BeTrue())
+					Expect(dbInstance.ModifyID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.ModifyDBInstanceDetails).To(Equal(awsrds.DBInstanceDetails{MasterUserPassword: latestMasterPassword}))
+					Expect(dbInstance.AddTagsID).To(Equal(dbInstanceIdentifier))
+					Expect(dbInstance.AddTagsTags).To(Equal(map[string]string{"Master password version": "2"}))
+				})
+
+				Context("and changing it fails", func() {
+					BeforeEach(func() {
+						dbInstance.ModifyError = errors.New("operation failed")
+					})
+
+					It("should not change the version of the instance", func() {
+						rdsBroker.CheckAndRotateCredentials()
+						Expect(dbInstance.ModifyCalled).To(BeTrue())
+						Expect(dbInstance.AddTagsCalled).To(BeFalse())
+					})
+				})
+			})
+
+			Context("and the master password is derived from the previous seed", func() {
+				rotationProgress := func() lager.Data {
+					for _, log := range testSink.Logs() {
+						if strings.HasSuffix(log.Message, "master-password-seed-rotation-progress") {
+							return log.Data
+						}
+					}
+					return nil
+				}
+
+				BeforeEach(func() {
+					previousMasterPasswordSeed = "old-secret"
+					sqlEngine.OpenAcceptedPassword = previousMasterPassword
+				})
+
+				It("should move the master password to the current seed", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(dbInstance.ModifyCalled).To(BeTrue())
+					Expect(dbInstance.ModifyDBInstanceDetails.MasterUserPassword).To(Equal(latestMasterPassword))
+					Expect(dbInstance.AddTagsTags).To(Equal(map[string]string{"Master password version": "2"}))
+					Expect(rotationProgress()).To(HaveKeyWithValue("rotated", float64(1)))
+					Expect(rotationProgress()).To(HaveKeyWithValue("remaining", float64(0)))
+					Expect(testSink.LogMessages()).To(ContainElement("rdsbroker_test.broker.previous-master-password-seed-unused"))
+				})
+
+				Context("and changing it fails", func() {
+					BeforeEach(func() {
+						dbInstance.ModifyError = errors.New("operation failed")
+					})
+
+					It("should report the instance as remaining", func() {
+						rdsBroker.CheckAndRotateCredentials()
+						Expect(rotationProgress()).To(HaveKeyWithValue("rotated", float64(0)))
+						Expect(rotationProgress()).To(HaveKeyWithValue("remaining", float64(1)))
+						Expect(testSink.LogMessages()).ToNot(ContainElement("rdsbroker_test.broker.previous-master-password-seed-unused"))
+					})
+				})
+			})
+
+			Context("and the version tag is behind the master password", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeByTagDBInstanceDetails[0].Tags = map[string]string{
+						"Master password version": "1",
+					}
+					sqlEngine.OpenAcceptedPassword = latestMasterPassword
+				})
+
+				It("should try the other versions and only fix the tag", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+					Expect(dbInstance.AddTagsTags).To(Equal(map[string]string{"Master password version": "2"}))
+				})
+			})
+
+			Context("and the passwords don't work", func() {
+				BeforeEach(func() {
+					sqlEngine.OpenError = sqlengine.LoginFailedError
+				})
+
+				It("should try to change the master password", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(dbInstance.ModifyCalled).To(BeTrue())
+					Expect(dbInstance.ModifyID).To(BeEquivalentTo(dbInstanceIdentifier))
+					Expect(dbInstance.ModifyDBInstanceDetails.MasterUserPassword).To(BeEquivalentTo(sqlEngine.OpenPassword))
+				})
+			})
+
+			Context("and the master password is in the store", func() {
+				BeforeEach(func() {
+					secretStore = fakeSecretStore
+					fakeSecretStore.Secrets = map[string]string{dbInstanceIdentifier: "stored-password"}
+					sqlEngine.OpenError = sqlengine.LoginFailedError
+				})
+
+				It("should reset the master password to the stored one", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(sqlEngine.OpenPassword).To(Equal("stored-password"))
+					Expect(dbInstance.ModifyCalled).To(BeTrue())
+					Expect(dbInstance.ModifyDBInstanceDetails.MasterUserPassword).To(Equal("stored-password"))
+				})
+			})
+
+			Context("and the master password cannot be read from the store", func() {
+				BeforeEach(func() {
+					secretStore = fakeSecretStore
+					fakeSecretStore.GetError = errors.New("operation failed")
+				})
+
+				It("should not try to connect to it", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(sqlEngine.OpenCalled).To(BeFalse())
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
+
+			Context("and there is an unkown open error", func() {
+				BeforeEach(func() {
+					sqlEngine.OpenError = errors.New("Unknown open connection error")
+				})
+
+				It("should not try to change the master password", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
+
+			Context("and there is DescribeByTagError error", func() {
+				BeforeEach(func() {
+					dbInstance.DescribeByTagError = errors.New("Error when listing instances")
+				})
+
+				It("should exit with an error", func() {
+					rdsBroker.CheckAndRotateCredentials()
+					Expect(dbInstance.ModifyCalled).To(BeFalse())
+				})
+			})
+
+		})
+
+		Context("when we reset the password then try to bind", func() {
+			var (
+				bindDetails brokerapi.BindDetails
+			)
+
+			BeforeEach(func() {
+				dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
+					&awsrds.DBInstanceDetails{
+						Identifier:     dbInstanceIdentifier,
+						Address:        "endpoint-address",
+						Port:           3306,
+						DBName:         "test-db",
+						MasterUsername: "master-username",
+						Engine:         "fake-engine",
+					},
+				}
+
+				bindDetails = brokerapi.BindDetails{
+					ServiceID:  "Service-1",
+					PlanID:     "Plan-1",
+					AppGUID:    "Application-1",
+					Parameters: map[string]interface{}{},
+				}
+			})
+
+			It("the new password and the password used in bind are the same", func() {
+				sqlEngine.OpenError = sqlengine.LoginFailedError
+				rdsBroker.CheckAndRotateCredentials()
+				expectedMasterPassword := sqlEngine.OpenPassword
+				Expect(dbInstance.ModifyCalled).To(BeTrue())
+				Expect(dbInstance.ModifyDBInstanceDetails.MasterUserPassword).To(BeEquivalentTo(expectedMasterPassword))
+
+				Expect(dbInstance.AddTagsTags).To(HaveKeyWithValue("Master password version", "2"))
+				dbInstance.GetTagValue = dbInstance.AddTagsTags["Master password version"]
+
+				sqlEngine.OpenError = nil
+				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sqlEngine.OpenCalled).To(BeTrue())
+
+				Expect(sqlEngine.OpenPassword).To(BeEquivalentTo(expectedMasterPassword))
+			})
+		})
+	})
+
+	var _ = Describe("ReencryptBrokerState", func() {
+		finishedData := func() lager.Data {
+			for _, log := range testSink.Logs() {
+				if strings.HasSuffix(log.Message, "reencrypt-broker-state-finished") {
+					return log.Data
+				}
+			}
+			return nil
+		}
+
+		BeforeEach(func() {
+			dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
+				&awsrds.DBInstanceDetails{
+					Identifier:     dbInstanceIdentifier,
+					Address:        "endpoint-address",
+					Port:           5432,
+					DBName:         "test-db",
+					MasterUsername: "master-username",
+					Engine:         "postgres",
+				},
+			}
+			sqlEngine.ReencryptStateCount = 3
+		})
+
+		It("re-encrypts the broker state of the instances", func() {
+			rdsBroker.ReencryptBrokerState()
+			Expect(dbInstance.DescribeByTagKey).To(Equal("Broker Name"))
+			Expect(dbInstance.DescribeByTagValue).To(Equal(brokerName))
+			Expect(sqlProvider.GetSQLEngineEngine).To(Equal("postgres"))
+			Expect(sqlEngine.OpenDBName).To(Equal("test-db"))
+			Expect(sqlEngine.OpenUsername).To(Equal("master-username"))
+			Expect(sqlEngine.OpenPassword).To(Equal(masterUserPassword))
+			Expect(sqlEngine.ReencryptStateCalled).To(BeTrue())
+			Expect(sqlEngine.CloseCalled).To(BeTrue())
+			Expect(finishedData()).To(HaveKeyWithValue("reencrypted", float64(3)))
+			Expect(finishedData()).To(HaveKeyWithValue("failed", float64(0)))
+		})
+
+		Context("when an instance has been soft-deleted", func() {
+			BeforeEach(func() {
+				dbInstance.DescribeByTagDBInstanceDetails[0].Tags = map[string]string{
+					"Deleted at": time.Now().Format(time.RFC822Z),
+				}
+			})
+
+			It("skips it", func() {
+				rdsBroker.ReencryptBrokerState()
+				Expect(sqlEngine.OpenCalled).To(BeFalse())
+				Expect(sqlEngine.ReencryptStateCalled).To(BeFalse())
+			})
+		})
+
+		Context("when the broker state of an instance cannot be re-encrypted", func() {
+			BeforeEach(func() {
+				sqlEngine.ReencryptStateError = errors.New("operation failed")
+			})
+
+			It("reports it as failed", func() {
+				rdsBroker.ReencryptBrokerState()
+				Expect(finishedData()).To(HaveKeyWithValue("reencrypted", float64(0)))
+				Expect(finishedData()).To(HaveKeyWithValue("failed", float64(1)))
+			})
+		})
+
+		Context("when connecting to an instance fails", func() {
+			BeforeEach(func() {
+				sqlEngine.OpenError = errors.New("connection refused")
+			})
+
+			It("reports it as failed", func() {
+				rdsBroker.ReencryptBrokerState()
+				Expect(sqlEngine.ReencryptStateCalled).To(BeFalse())
+				Expect(finishedData()).To(HaveKeyWithValue("failed", float64(1)))
+			})
+		})
+	})
+
+	var _ = Describe("MigrateBrokerState", func() {
+		finishedData := func() lager.Data {
+			for _, log := range testSink.Logs() {
+				if strings.HasSuffix(log.Message, "migrate-broker-state-finished") {
+					return log.Data
+				}
+			}
+			return nil
+		}
+
+		BeforeEach(func() {
+			dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
+				&awsrds.DBInstanceDetails{
+					Identifier:     dbInstanceIdentifier,
+					Address:        "endpoint-address",
+					Port:           5432,
+					DBName:         "test-db",
+					MasterUsername: "master-username",
+					Engine:         "postgres",
+				},
+			}
+			sqlEngine.MigrateStateCount = 2
+		})
+
+		It("migrates the broker state of the instances", func() {
+			rdsBroker.MigrateBrokerState()
+			Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
+			Expect(sqlEngine.OpenPassword).To(Equal(masterUserPassword))
+			Expect(sqlEngine.MigrateStateCalled).To(BeTrue())
+			Expect(sqlEngine.CloseCalled).To(BeTrue())
+			Expect(finishedData()).To(HaveKeyWithValue("migrated", float64(2)))
+			Expect(finishedData()).To(HaveKeyWithValue("failed", float64(0)))
+		})
+
+		Context("when the broker state of an instance cannot be migrated", func() {
+			BeforeEach(func() {
+				sqlEngine.MigrateStateError = errors.New("operation failed")
+			})
+
+			It("reports it as failed", func() {
+				rdsBroker.MigrateBrokerState()
+				Expect(finishedData()).To(HaveKeyWithValue("migrated", float64(0)))
+				Expect(finishedData()).To(HaveKeyWithValue("failed", float64(1)))
+			})
+		})
+	})
+
+	var _ = Describe("DropExpiredUsers", func() {
+		BeforeEach(func() {
+			dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
+				&awsrds.DBInstanceDetails{
+					Identifier:     dbInstanceIdentifier,
+					Address:        "endpoint-address",
+					Port:           3306,
+					DBName:         "test-db",
+					MasterUsername: "master-username",
+					Engine:         "mysql",
+				},
+			}
+			sqlEngine.DropExpiredUsersCount = 2
+		})
+
+		It("drops the expired users of the instances", func() {
+			rdsBroker.DropExpiredUsers()
+			Expect(sqlProvider.GetSQLEngineEngine).To(Equal("mysql"))
+			Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
+			Expect(sqlEngine.DropExpiredUsersCalled).To(BeTrue())
+			Expect(sqlEngine.CloseCalled).To(BeTrue())
+
+			var finished lager.LogFormat
+			for _, log := range testSink.Logs() {
+				if strings.HasSuffix(log.Message, "drop-expired-users-finished") {
+					finished = log
+				}
+			}
+			Expect(finished.Data).To(HaveKeyWithValue("dropped", float64(2)))
+			Expect(finished.Data).To(HaveKeyWithValue("failed", float64(0)))
+		})
+	})
+
+	var _ = Describe("DeleteSoftDeletedInstances", func() {
+		var softDeletedInstance *awsrds.DBInstanceDetails
+
+		BeforeEach(func() {
+			softDelete = &SoftDeleteConfig{GracePeriod: "168h", CheckInterval: "1h"}
+			softDeletedInstance = &awsrds.DBInstanceDetails{
+				Identifier: dbInstanceIdentifier,
+				Status:     "stopped",
+				Tags: map[string]string{
+					"Plan ID":    "Plan-1",
+					"Deleted at": time.Now().Add(-200 * time.Hour).Format(time.RFC822Z),
+				},
+			}
+			dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
+				&awsrds.DBInstanceDetails{
+					Identifier: "cf-other-instance",
+					Status:     "available",
+				},
+				softDeletedInstance,
+			}
+		})
+
+		It("deletes the instances whose grace period is over", func() {
+			rdsBroker.DeleteSoftDeletedInstances()
+			Expect(dbInstance.DescribeByTagKey).To(Equal("Broker Name"))
+			Expect(dbInstance.DescribeByTagValue).To(Equal(brokerName))
+			Expect(dbInstance.DeleteCalled).To(BeTrue())
+			Expect(dbInstance.DeleteID).To(Equal(dbInstanceIdentifier))
+			Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeTrue())
+			Expect(dbInstance.StopCalled).To(BeFalse())
+		})
+
+		It("does not start a workflow for instances without their own DB parameter group", func() {
+			rdsBroker.DeleteSoftDeletedInstances()
+			_, err := workflowStore.Get(instanceID)
+			Expect(err).To(Equal(workflow.ErrNotFound))
+		})
+
+		Context("when the instance has its own DB parameter group", func() {
+			BeforeEach(func() {
+				rdsProperties1.DBParameterGroupName = "plan-group"
+				rdsProperties1.UserDBParameters = map[string]ParameterConstraints{"work_mem": ParameterConstraints{}}
+			})
+
+			It("starts the deletion of the DB parameter group", func() {
+				rdsBroker.DeleteSoftDeletedInstances()
+				w, err := workflowStore.Get(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Kind).To(Equal("delete-groups"))
+				Expect(w.Data).To(Equal(map[string]string{"db_parameter_group": dbInstanceIdentifier}))
+			})
+		})
+
+		Context("when there is a master password store", func() {
+			BeforeEach(func() {
+				secretStore = fakeSecretStore
+				fakeSecretStore.Secrets = map[string]string{dbInstanceIdentifier: "stored-password"}
+			})
+
+			It("deletes the master password of the deleted instance", func() {
+				rdsBroker.DeleteSoftDeletedInstances()
+				Expect(fakeSecretStore.DeleteName).To(Equal(dbInstanceIdentifier))
+				Expect(fakeSecretStore.Secrets).To(BeEmpty())
+			})
+
+			It("keeps the master password when a final snapshot is taken", func() {
+				softDeletedInstance.Tags["SkipFinalSnapshot"] = "false"
+
+				rdsBroker.DeleteSoftDeletedInstances()
+				Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeFalse())
+				Expect(fakeSecretStore.DeleteCalled).To(BeFalse())
+			})
+		})
+
+		Context("when the instance has a SkipFinalSnapshot tag", func() {
+			BeforeEach(func() {
+				softDeletedInstance.Tags["SkipFinalSnapshot"] = "false"
+			})
+
+			It("uses the tag value", func() {
+				rdsBroker.DeleteSoftDeletedInstances()
+				Expect(dbInstance.DeleteCalled).To(BeTrue())
+				Expect(dbInstance.DeleteSkipFinalSnapshot).To(BeFalse())
+			})
+		})
+
+		Context("when the grace period is not over", func() {
+			BeforeEach(func() {
+				softDeletedInstance.Tags["Deleted at"] = time.Now().Add(-time.Hour).Format(time.RFC822Z)
+			})
+
+			It("does not delete the instance", func() {
+				rdsBroker.DeleteSoftDeletedInstances()
+				Expect(dbInstance.DeleteCalled).To(BeFalse())
+				Expect(dbInstance.StopCalled).To(BeFalse())
+			})
+
+			Context("and RDS has started the instance again", func() {
+				BeforeEach(func() {
+					softDeletedInstance.Status = "available"
+				})
+
+				It("stops the instance", func() {
+					rdsBroker.DeleteSoftDeletedInstances()
+					Expect(dbInstance.DeleteCalled).To(BeFalse())
+					Expect(dbInstance.StopCalled).To(BeTrue())
+					Expect(dbInstance.StopID).To(Equal(dbInstanceIdentifier))
+				})
+			})
+		})
+
+		Context("when the instance is already being deleted", func() {
 			BeforeEach(func() {
-				unbindDetails.PlanID = "unknown"
+				softDeletedInstance.Status = "deleting"
 			})
 
-			It("returns the proper error", func() {
-				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Service Plan 'unknown' not found"))
+			It("does not delete it again", func() {
+				rdsBroker.DeleteSoftDeletedInstances()
+				Expect(dbInstance.DeleteCalled).To(BeFalse())
 			})
 		})
 
-		Context("when describing the DB Instance fails", func() {
+		Context("when soft-delete is not enabled", func() {
 			BeforeEach(func() {
-				dbInstance.DescribeError = errors.New("operation failed")
+				softDelete = nil
 			})
 
-			It("returns the proper error", func() {
-				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("operation failed"))
+			It("does nothing", func() {
+				rdsBroker.DeleteSoftDeletedInstances()
+				Expect(dbInstance.DescribeByTagCalled).To(BeFalse())
+				Expect(dbInstance.DeleteCalled).To(BeFalse())
 			})
+		})
+	})
 
-			Context("when the DB Instance does not exists", func() {
-				BeforeEach(func() {
-					dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
-				})
+	var _ = Describe("UndeleteInstance", func() {
+		BeforeEach(func() {
+			dbInstance.GetTagValue = time.Now().Format(time.RFC822Z)
+			dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
+				Identifier: dbInstanceIdentifier,
+				Status:     "stopped",
+			}
+		})
 
-				It("returns the proper error", func() {
-					err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
-				})
+		It("starts the instance and removes the deletion tags", func() {
+			err := rdsBroker.UndeleteInstance(instanceID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dbInstance.GetTagKey).To(Equal("Deleted at"))
+			Expect(dbInstance.StartCalled).To(BeTrue())
+			Expect(dbInstance.StartID).To(Equal(dbInstanceIdentifier))
+			Expect(dbInstance.RemoveTagsCalled).To(BeTrue())
+			Expect(dbInstance.RemoveTagsID).To(Equal(dbInstanceIdentifier))
+			Expect(dbInstance.RemoveTagsKeys).To(ConsistOf("Deleted at", "Deleted by"))
+		})
+
+		Context("when the instance is already running", func() {
+			BeforeEach(func() {
+				dbInstance.DescribeDBInstanceDetails.Status = "available"
+			})
+
+			It("only removes the deletion tags", func() {
+				err := rdsBroker.UndeleteInstance(instanceID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dbInstance.StartCalled).To(BeFalse())
+				Expect(dbInstance.RemoveTagsCalled).To(BeTrue())
 			})
 		})
 
-		Context("when getting the SQL Engine fails", func() {
+		Context("when the instance has not been soft-deleted", func() {
 			BeforeEach(func() {
-				sqlProvider.GetSQLEngineError = errors.New("SQL Engine 'unknown' not supported")
+				dbInstance.GetTagValue = ""
 			})
 
 			It("returns the proper error", func() {
-				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("SQL Engine 'unknown' not supported"))
+				err := rdsBroker.UndeleteInstance(instanceID)
+				Expect(err).To(Equal(ErrInstanceNotSoftDeleted))
+				Expect(dbInstance.StartCalled).To(BeFalse())
 			})
 		})
 
-		Context("when opening a DB connection fails", func() {
+		Context("when the instance does not exist", func() {
 			BeforeEach(func() {
-				sqlEngine.OpenError = errors.New("Failed to open sqlEngine")
+				dbInstance.GetTagError = awsrds.ErrDBInstanceDoesNotExist
 			})
 
 			It("returns the proper error", func() {
-				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Failed to open sqlEngine"))
+				err := rdsBroker.UndeleteInstance(instanceID)
+				Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
 			})
 		})
 
-		Context("when deleting a user fails", func() {
+		Context("when starting the instance fails", func() {
 			BeforeEach(func() {
-				sqlEngine.DropUserError = errors.New("Failed to delete user")
+				dbInstance.StartError = errors.New("operation failed")
 			})
 
 			It("returns the proper error", func() {
-				err := rdsBroker.Unbind(instanceID, bindingID, unbindDetails)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("Failed to delete user"))
-				Expect(sqlEngine.CloseCalled).To(BeTrue())
+				err := rdsBroker.UndeleteInstance(instanceID)
+				Expect(err).To(MatchError("operation failed"))
+				Expect(dbInstance.RemoveTagsCalled).To(BeFalse())
 			})
 		})
 	})
 
-	var _ = Describe("LastOperation", func() {
+	var _ = Describe("ServiceKeys", func() {
 		var (
-			dbInstanceStatus            string
-			lastOperationState          string
-			properLastOperationResponse brokerapi.LastOperationResponse
+			createdAt time.Time
+			expiresAt time.Time
 		)
 
-		JustBeforeEach(func() {
+		BeforeEach(func() {
+			createdAt = time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+			expiresAt = createdAt.Add(24 * time.Hour)
 			dbInstance.DescribeDBInstanceDetails = awsrds.DBInstanceDetails{
 				Identifier:     dbInstanceIdentifier,
-				Engine:         "test-engine",
+				Engine:         "postgres",
 				Address:        "endpoint-address",
-				Port:           3306,
+				Port:           5432,
 				DBName:         "test-db",
 				MasterUsername: "master-username",
-				Status:         dbInstanceStatus,
 			}
-
-			properLastOperationResponse = brokerapi.LastOperationResponse{
-				State:       lastOperationState,
-				Description: "DB Instance '" + dbInstanceIdentifier + "' status is '" + dbInstanceStatus + "'",
+			sqlEngine.ListServiceKeyUsersUsers = []sqlengine.ServiceKeyUser{
+				{Address: "endpoint-address", BindingID: "binding-1", Username: "user1", ReadOnly: true, CreatedAt: createdAt, ExpiresAt: expiresAt},
+				{Address: "endpoint-address", BindingID: "binding-2", Username: "user2", CreatedAt: createdAt},
 			}
 		})
 
-		Context("when describing the DB Instance fails", func() {
-			BeforeEach(func() {
-				dbInstance.DescribeError = errors.New("operation failed")
-			})
-
-			It("returns the proper error", func() {
-				_, err := rdsBroker.LastOperation(instanceID)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("operation failed"))
-			})
-
-			Context("when the DB Instance does not exists", func() {
-				BeforeEach(func() {
-					dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
-				})
-
-				It("returns the proper error", func() {
-					_, err := rdsBroker.LastOperation(instanceID)
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
-				})
-			})
+		It("returns the service keys of the instance", func() {
+			keys, err := rdsBroker.ServiceKeys(instanceID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(Equal([]ServiceKey{
+				{BindingID: "binding-1", Username: "user1", ReadOnly: true, CreatedAt: createdAt, ExpiresAt: &expiresAt},
+				{BindingID: "binding-2", Username: "user2", CreatedAt: createdAt},
+			}))
+			Expect(sqlProvider.GetSQLEngineEngine).To(Equal("postgres"))
+			Expect(sqlEngine.OpenAddress).To(Equal("endpoint-address"))
+			Expect(sqlEngine.OpenUsername).To(Equal("master-username"))
+			Expect(sqlEngine.CloseCalled).To(BeTrue())
 		})
 
-		Context("when last operation is still in progress", func() {
+		Context("when the instance does not exist", func() {
 			BeforeEach(func() {
-				dbInstanceStatus = "creating"
-				lastOperationState = brokerapi.LastOperationInProgress
+				dbInstance.DescribeError = awsrds.ErrDBInstanceDoesNotExist
 			})
 
-			It("returns the proper LastOperationResponse", func() {
-				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
-				Expect(err).ToNot(HaveOccurred())
-				Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+			It("returns the proper error", func() {
+				_, err := rdsBroker.ServiceKeys(instanceID)
+				Expect(err).To(Equal(brokerapi.ErrInstanceDoesNotExist))
 			})
 		})
 
-		Context("when last operation failed", func() {
+		Context("when service keys are not recorded", func() {
 			BeforeEach(func() {
-				dbInstanceStatus = "failed"
-				lastOperationState = brokerapi.LastOperationFailed
+				sqlEngine.ListServiceKeyUsersError = sqlengine.ErrServiceKeysNotSupported
 			})
 
-			It("returns the proper LastOperationResponse", func() {
-				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
-				Expect(err).ToNot(HaveOccurred())
-				Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+			It("returns the proper error", func() {
+				_, err := rdsBroker.ServiceKeys(instanceID)
+				Expect(err).To(Equal(sqlengine.ErrServiceKeysNotSupported))
 			})
 		})
 
-		Context("when last operation succeeded", func() {
-			BeforeEach(func() {
-				dbInstanceStatus = "available"
-				lastOperationState = brokerapi.LastOperationSucceeded
-			})
-
-			It("returns the proper LastOperationResponse", func() {
-				lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
+		Describe("RevokeServiceKey", func() {
+			It("drops the user of the service key", func() {
+				err := rdsBroker.RevokeServiceKey(instanceID, "binding-2")
 				Expect(err).ToNot(HaveOccurred())
-				Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+				Expect(sqlEngine.DropUserCalled).To(BeTrue())
+				Expect(sqlEngine.DropUserBindingID).To(Equal("binding-2"))
+				Expect(sqlEngine.CloseCalled).To(BeTrue())
 			})
 
-			Context("but has pending modifications", func() {
-				JustBeforeEach(func() {
-					dbInstance.DescribeDBInstanceDetails.PendingModifications = true
+			It("returns an error for unknown service keys", func() {
+				err := rdsBroker.RevokeServiceKey(instanceID, "binding-3")
+				Expect(err).To(Equal(ErrServiceKeyDoesNotExist))
+				Expect(sqlEngine.DropUserCalled).To(BeFalse())
+			})
 
-					properLastOperationResponse = brokerapi.LastOperationResponse{
-						State:       brokerapi.LastOperationInProgress,
-						Description: "DB Instance '" + dbInstanceIdentifier + "' has pending modifications",
-					}
+			Context("when dropping the user fails", func() {
+				BeforeEach(func() {
+					sqlEngine.DropUserError = errors.New("Failed to drop user")
 				})
 
-				It("returns the proper LastOperationResponse", func() {
-					lastOperationResponse, err := rdsBroker.LastOperation(instanceID)
-					Expect(err).ToNot(HaveOccurred())
-					Expect(lastOperationResponse).To(Equal(properLastOperationResponse))
+				It("returns the proper error", func() {
+					err := rdsBroker.RevokeServiceKey(instanceID, "binding-1")
+					Expect(err).To(MatchError("Failed to drop user"))
 				})
 			})
 		})
 	})
 
-	var _ = Describe("CheckAndRotateCredentials", func() {
-		Context("when there is no DB instance", func() {
-			It("shouldn't try to connect to databases", func() {
-				rdsBroker.CheckAndRotateCredentials()
-				Expect(sqlProvider.GetSQLEngineCalled).To(BeFalse())
-				Expect(sqlEngine.OpenCalled).To(BeFalse())
-			})
-		})
+	var _ = Describe("FinalSnapshots", func() {
+		var createTime time.Time
 
-		Context("when there are DB instances", func() {
-			BeforeEach(func() {
-				dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
-					&awsrds.DBInstanceDetails{
-						Identifier:     dbInstanceIdentifier,
-						Address:        "endpoint-address",
-						Port:           3306,
-						DBName:         "test-db",
-						MasterUsername: "master-username",
-						Engine:         "fake-engine",
+		BeforeEach(func() {
+			rdsProperties1.FinalSnapshotRetentionDays = 30
+			createTime = time.Now().Add(-24 * time.Hour).UTC()
+			dbInstance.DescribeSnapshotsDBSnapshotDetails = []*awsrds.DBSnapshotDetails{
+				&awsrds.DBSnapshotDetails{
+					Identifier:           dbInstanceIdentifier + "-final-snapshot-20160102-030405",
+					DBInstanceIdentifier: dbInstanceIdentifier,
+					Status:               "available",
+					CreateTime:           createTime,
+					FinalSnapshot:        true,
+					Tags: map[string]string{
+						"Organization ID": "organization-id",
+						"Space ID":        "space-id",
+						"Plan ID":         "Plan-1",
 					},
-				}
-			})
+				},
+				&awsrds.DBSnapshotDetails{
+					Identifier:           "manual-snapshot",
+					DBInstanceIdentifier: dbInstanceIdentifier,
+					Status:               "available",
+				},
+			}
+		})
 
-			It("should try to connect to databases", func() {
-				rdsBroker.CheckAndRotateCredentials()
-				Expect(dbInstance.DescribeByTagCalled).To(BeTrue())
-				Expect(dbInstance.DescribeByTagKey).To(BeEquivalentTo("Broker Name"))
-				Expect(dbInstance.DescribeByTagValue).To(BeEquivalentTo(brokerName))
-				Expect(sqlProvider.GetSQLEngineCalled).To(BeTrue())
-				Expect(sqlProvider.GetSQLEngineEngine).To(BeEquivalentTo("fake-engine"))
-				Expect(sqlEngine.OpenCalled).To(BeTrue())
-				Expect(sqlEngine.OpenAddress).To(BeEquivalentTo("endpoint-address"))
-				Expect(sqlEngine.OpenPort).To(BeEquivalentTo(3306))
-				Expect(sqlEngine.OpenDBName).To(BeEquivalentTo("test-db"))
-				Expect(sqlEngine.OpenUsername).To(BeEquivalentTo("master-username"))
-			})
+		It("returns the final snapshots with their expiry date", func() {
+			finalSnapshots, err := rdsBroker.FinalSnapshots()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dbInstance.DescribeSnapshotsDBInstanceIdentifierPrefix).To(Equal(dbPrefix + "-"))
+			Expect(dbInstance.DescribeSnapshotsTagKey).To(Equal("Broker Name"))
+			Expect(dbInstance.DescribeSnapshotsTagValue).To(Equal(brokerName))
+
+			expiresAt := createTime.AddDate(0, 0, 30)
+			Expect(finalSnapshots).To(Equal([]FinalSnapshot{
+				{
+					SnapshotID:     dbInstanceIdentifier + "-final-snapshot-20160102-030405",
+					InstanceID:     instanceID,
+					OrganizationID: "organization-id",
+					SpaceID:        "space-id",
+					PlanID:         "Plan-1",
+					Status:         "available",
+					CreatedAt:      createTime,
+					ExpiresAt:      &expiresAt,
+				},
+			}))
+		})
 
-			Context("and the passwords work", func() {
-				It("should not try to change the master password", func() {
-					rdsBroker.CheckAndRotateCredentials()
-					Expect(dbInstance.ModifyCalled).To(BeFalse())
-				})
+		Context("when the plan keeps the final snapshots forever", func() {
+			BeforeEach(func() {
+				rdsProperties1.FinalSnapshotRetentionDays = 0
 			})
 
-			Context("and the passwords don't work", func() {
-				BeforeEach(func() {
-					sqlEngine.OpenError = sqlengine.LoginFailedError
-				})
-
-				It("should try to change the master password", func() {
-					rdsBroker.CheckAndRotateCredentials()
-					Expect(dbInstance.ModifyCalled).To(BeTrue())
-					Expect(dbInstance.ModifyID).To(BeEquivalentTo(dbInstanceIdentifier))
-					Expect(dbInstance.ModifyDBInstanceDetails.MasterUserPassword).To(BeEquivalentTo(sqlEngine.OpenPassword))
-				})
+			It("does not set an expiry date", func() {
+				finalSnapshots, err := rdsBroker.FinalSnapshots()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(finalSnapshots).To(HaveLen(1))
+				Expect(finalSnapshots[0].ExpiresAt).To(BeNil())
 			})
+		})
 
-			Context("and there is an unkown open error", func() {
-				BeforeEach(func() {
-					sqlEngine.OpenError = errors.New("Unknown open connection error")
-				})
-
-				It("should not try to change the master password", func() {
-					rdsBroker.CheckAndRotateCredentials()
-					Expect(dbInstance.ModifyCalled).To(BeFalse())
-				})
+		Context("when describing the snapshots fails", func() {
+			BeforeEach(func() {
+				dbInstance.DescribeSnapshotsError = errors.New("operation failed")
 			})
 
-			Context("and there is DescribeByTagError error", func() {
-				BeforeEach(func() {
-					dbInstance.DescribeByTagError = errors.New("Error when listing instances")
-				})
-
-				It("should exit with an error", func() {
-					rdsBroker.CheckAndRotateCredentials()
-					Expect(dbInstance.ModifyCalled).To(BeFalse())
-				})
+			It("returns the proper error", func() {
+				_, err := rdsBroker.FinalSnapshots()
+				Expect(err).To(MatchError("operation failed"))
 			})
+		})
+	})
 
+	var _ = Describe("DeleteExpiredFinalSnapshots", func() {
+		BeforeEach(func() {
+			rdsProperties1.FinalSnapshotRetentionDays = 7
+			dbInstance.DescribeSnapshotsDBSnapshotDetails = []*awsrds.DBSnapshotDetails{
+				&awsrds.DBSnapshotDetails{
+					Identifier:    "expired-final-snapshot",
+					Status:        "available",
+					CreateTime:    time.Now().AddDate(0, 0, -8),
+					FinalSnapshot: true,
+					Tags:          map[string]string{"Plan ID": "Plan-1"},
+				},
+				&awsrds.DBSnapshotDetails{
+					Identifier:    "recent-final-snapshot",
+					Status:        "available",
+					CreateTime:    time.Now().AddDate(0, 0, -6),
+					FinalSnapshot: true,
+					Tags:          map[string]string{"Plan ID": "Plan-1"},
+				},
+				&awsrds.DBSnapshotDetails{
+					Identifier:    "unknown-plan-final-snapshot",
+					Status:        "available",
+					CreateTime:    time.Now().AddDate(0, 0, -100),
+					FinalSnapshot: true,
+					Tags:          map[string]string{"Plan ID": "unknown"},
+				},
+			}
 		})
 
-		Context("when we reset the password then try to bind", func() {
-			var (
-				bindDetails brokerapi.BindDetails
-			)
+		It("deletes only the expired final snapshots", func() {
+			rdsBroker.DeleteExpiredFinalSnapshots()
+			Expect(dbInstance.DeleteSnapshotIDs).To(Equal([]string{"expired-final-snapshot"}))
+		})
 
+		Context("when there is a master password store", func() {
 			BeforeEach(func() {
-				dbInstance.DescribeByTagDBInstanceDetails = []*awsrds.DBInstanceDetails{
-					&awsrds.DBInstanceDetails{
-						Identifier:     dbInstanceIdentifier,
-						Address:        "endpoint-address",
-						Port:           3306,
-						DBName:         "test-db",
-						MasterUsername: "master-username",
-						Engine:         "fake-engine",
-					},
-				}
-
-				bindDetails = brokerapi.BindDetails{
-					ServiceID:  "Service-1",
-					PlanID:     "Plan-1",
-					AppGUID:    "Application-1",
-					Parameters: map[string]interface{}{},
+				secretStore = fakeSecretStore
+				dbInstance.DescribeSnapshotsDBSnapshotDetails[0].DBInstanceIdentifier = dbInstanceIdentifier
+				dbInstance.DescribeSnapshotsDBSnapshotDetails[1].DBInstanceIdentifier = "cf-recent-instance"
+				fakeSecretStore.Secrets = map[string]string{
+					dbInstanceIdentifier: "stored-password",
+					"cf-recent-instance": "other-stored-password",
 				}
 			})
 
-			It("the new password and the password used in bind are the same", func() {
-				sqlEngine.OpenError = sqlengine.LoginFailedError
-				rdsBroker.CheckAndRotateCredentials()
-				expectedMasterPassword := sqlEngine.OpenPassword
-				Expect(dbInstance.ModifyCalled).To(BeTrue())
-				Expect(dbInstance.ModifyDBInstanceDetails.MasterUserPassword).To(BeEquivalentTo(expectedMasterPassword))
+			It("deletes the master password of the expired final snapshots", func() {
+				rdsBroker.DeleteExpiredFinalSnapshots()
+				Expect(fakeSecretStore.Secrets).To(Equal(map[string]string{"cf-recent-instance": "other-stored-password"}))
+			})
 
-				sqlEngine.OpenError = nil
-				_, err := rdsBroker.Bind(instanceID, bindingID, bindDetails)
-				Expect(err).ToNot(HaveOccurred())
-				Expect(sqlEngine.OpenCalled).To(BeTrue())
+			It("keeps the master password if the final snapshot could not be deleted", func() {
+				dbInstance.DeleteSnapshotError = errors.New("operation failed")
 
-				Expect(sqlEngine.OpenPassword).To(BeEquivalentTo(expectedMasterPassword))
+				rdsBroker.DeleteExpiredFinalSnapshots()
+				Expect(fakeSecretStore.DeleteCalled).To(BeFalse())
 			})
 		})
 	})
-
 })
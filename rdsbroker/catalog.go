@@ -2,6 +2,7 @@ package rdsbroker
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -35,12 +36,13 @@ type ServiceMetadata struct {
 }
 
 type ServicePlan struct {
-	ID            string               `json:"id"`
-	Name          string               `json:"name"`
-	Description   string               `json:"description"`
-	Metadata      *ServicePlanMetadata `json:"metadata,omitempty"`
-	Free          bool                 `json:"free"`
-	RDSProperties RDSProperties        `json:"rds_properties,omitempty"`
+	ID             string               `json:"id"`
+	Name           string               `json:"name"`
+	Description    string               `json:"description"`
+	Metadata       *ServicePlanMetadata `json:"metadata,omitempty"`
+	Free           bool                 `json:"free"`
+	RDSProperties  RDSProperties        `json:"rds_properties,omitempty"`
+	UserParameters *UserParameters      `json:"user_parameters,omitempty"`
 }
 
 type ServicePlanMetadata struct {
@@ -60,32 +62,70 @@ type Cost struct {
 	Unit   string                 `json:"unit,omitempty"`
 }
 
+// UserParameters restricts, for a given plan, which parameters users may set
+// on each kind of call and within which bounds. A call kind that is not
+// listed falls back to the broker wide allow_user_*_parameters setting.
+type UserParameters struct {
+	Provision map[string]ParameterConstraints `json:"provision,omitempty"`
+	Update    map[string]ParameterConstraints `json:"update,omitempty"`
+	Bind      map[string]ParameterConstraints `json:"bind,omitempty"`
+}
+
+type ParameterConstraints struct {
+	Minimum *int64   `json:"minimum,omitempty"`
+	Maximum *int64   `json:"maximum,omitempty"`
+	Enum    []string `json:"enum,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+}
+
+// OptionConstraints describe an option users may enable in the option group
+// of their DB instances, with the settings they may give it. The port and VPC
+// security groups of the option are set by the operator.
+type OptionConstraints struct {
+	Settings            map[string]ParameterConstraints `json:"settings,omitempty"`
+	Port                int64                           `json:"port,omitempty"`
+	VpcSecurityGroupIDs []string                        `json:"vpc_security_group_ids,omitempty"`
+}
+
 type RDSProperties struct {
-	DBInstanceClass            string   `json:"db_instance_class"`
-	Engine                     string   `json:"engine"`
-	EngineVersion              string   `json:"engine_version"`
-	AllocatedStorage           int64    `json:"allocated_storage"`
-	AutoMinorVersionUpgrade    bool     `json:"auto_minor_version_upgrade,omitempty"`
-	AvailabilityZone           string   `json:"availability_zone,omitempty"`
-	BackupRetentionPeriod      int64    `json:"backup_retention_period,omitempty"`
-	CharacterSetName           string   `json:"character_set_name,omitempty"`
-	DBParameterGroupName       string   `json:"db_parameter_group_name,omitempty"`
-	DBSecurityGroups           []string `json:"db_security_groups,omitempty"`
-	DBSubnetGroupName          string   `json:"db_subnet_group_name,omitempty"`
-	LicenseModel               string   `json:"license_model,omitempty"`
-	MultiAZ                    bool     `json:"multi_az,omitempty"`
-	OptionGroupName            string   `json:"option_group_name,omitempty"`
-	Port                       int64    `json:"port,omitempty"`
-	PreferredBackupWindow      string   `json:"preferred_backup_window,omitempty"`
-	PreferredMaintenanceWindow string   `json:"preferred_maintenance_window,omitempty"`
-	PubliclyAccessible         bool     `json:"publicly_accessible,omitempty"`
-	StorageEncrypted           bool     `json:"storage_encrypted,omitempty"`
-	KmsKeyID                   string   `json:"kms_key_id,omitempty"`
-	StorageType                string   `json:"storage_type,omitempty"`
-	Iops                       int64    `json:"iops,omitempty"`
-	VpcSecurityGroupIds        []string `json:"vpc_security_group_ids,omitempty"`
-	CopyTagsToSnapshot         bool     `json:"copy_tags_to_snapshot,omitempty"`
-	SkipFinalSnapshot          bool     `json:"skip_final_snapshot,omitempty"`
+	DBInstanceClass            string                          `json:"db_instance_class"`
+	Engine                     string                          `json:"engine"`
+	EngineVersion              string                          `json:"engine_version"`
+	AllocatedStorage           int64                           `json:"allocated_storage"`
+	AutoMinorVersionUpgrade    bool                            `json:"auto_minor_version_upgrade,omitempty"`
+	AvailabilityZone           string                          `json:"availability_zone,omitempty"`
+	BackupRetentionPeriod      int64                           `json:"backup_retention_period,omitempty"`
+	CharacterSetName           string                          `json:"character_set_name,omitempty"`
+	DBParameterGroupName       string                          `json:"db_parameter_group_name,omitempty"`
+	DBSecurityGroups           []string                        `json:"db_security_groups,omitempty"`
+	DBSubnetGroupName          string                          `json:"db_subnet_group_name,omitempty"`
+	DeletionProtection         bool                            `json:"deletion_protection,omitempty"`
+	IAMDatabaseAuthentication  bool                            `json:"iam_database_authentication,omitempty"`
+	LicenseModel               string                          `json:"license_model,omitempty"`
+	MultiAZ                    bool                            `json:"multi_az,omitempty"`
+	OptionGroupName            string                          `json:"option_group_name,omitempty"`
+	Port                       int64                           `json:"port,omitempty"`
+	PreferredBackupWindow      string                          `json:"preferred_backup_window,omitempty"`
+	PreferredMaintenanceWindow string                          `json:"preferred_maintenance_window,omitempty"`
+	PubliclyAccessible         bool                            `json:"publicly_accessible,omitempty"`
+	StorageEncrypted           bool                            `json:"storage_encrypted,omitempty"`
+	KmsKeyID                   string                          `json:"kms_key_id,omitempty"`
+	StorageType                string                          `json:"storage_type,omitempty"`
+	Iops                       int64                           `json:"iops,omitempty"`
+	VpcSecurityGroupIds        []string                        `json:"vpc_security_group_ids,omitempty"`
+	CopyTagsToSnapshot         bool                            `json:"copy_tags_to_snapshot,omitempty"`
+	SkipFinalSnapshot          bool                            `json:"skip_final_snapshot,omitempty"`
+	FinalSnapshotRetentionDays int64                           `json:"final_snapshot_retention_days,omitempty"`
+	MaxManualSnapshots         int64                           `json:"max_manual_snapshots,omitempty"`
+	PreChangeSnapshot          bool                            `json:"pre_change_snapshot,omitempty"`
+	PostProvisionSQL           []string                        `json:"post_provision_sql,omitempty"`
+	AllowedExtensions          []string                        `json:"allowed_extensions,omitempty"`
+	UserDBParameters           map[string]ParameterConstraints `json:"user_db_parameters,omitempty"`
+	UserOptions                map[string]OptionConstraints    `json:"user_options,omitempty"`
+
+	BindingConnectionLimit                 int64 `json:"binding_connection_limit,omitempty"`
+	BindingStatementTimeout                int64 `json:"binding_statement_timeout,omitempty"`
+	BindingIdleInTransactionSessionTimeout int64 `json:"binding_idle_in_transaction_session_timeout,omitempty"`
 }
 
 func (c Catalog) Validate() error {
@@ -159,6 +199,78 @@ func (sp ServicePlan) Validate() error {
 		return fmt.Errorf("Validating RDS Properties configuration: %s", err)
 	}
 
+	if sp.UserParameters != nil {
+		if err := sp.UserParameters.Validate(); err != nil {
+			return fmt.Errorf("Validating User Parameters configuration: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (up UserParameters) Validate() error {
+	if err := validateParameterConstraints(ProvisionParameters{}, up.Provision); err != nil {
+		return fmt.Errorf("provision: %s", err)
+	}
+
+	if err := validateParameterConstraints(UpdateParameters{}, up.Update); err != nil {
+		return fmt.Errorf("update: %s", err)
+	}
+
+	if err := validateParameterConstraints(BindParameters{}, up.Bind); err != nil {
+		return fmt.Errorf("bind: %s", err)
+	}
+
+	return nil
+}
+
+func validateParameterConstraints(parameters interface{}, constraints map[string]ParameterConstraints) error {
+	for name, constraint := range constraints {
+		field, ok := findParameterField(parameters, name)
+		if !ok {
+			return fmt.Errorf("Unknown parameter '%s'", name)
+		}
+
+		if err := constraint.Validate(jsonSchemaType(field.Type)); err != nil {
+			return fmt.Errorf("Parameter '%s': %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// dbParameterType tells the JSON type of a DB parameter, whose values are
+// integers when bounded and strings otherwise.
+func (pc ParameterConstraints) dbParameterType() string {
+	if pc.Minimum != nil || pc.Maximum != nil {
+		return "integer"
+	}
+	return "string"
+}
+
+func (pc ParameterConstraints) Validate(parameterType string) error {
+	if pc.Minimum != nil || pc.Maximum != nil {
+		if parameterType != "integer" {
+			return fmt.Errorf("minimum and maximum can only be set on integer parameters")
+		}
+	}
+
+	if pc.Minimum != nil && pc.Maximum != nil && *pc.Minimum > *pc.Maximum {
+		return fmt.Errorf("minimum (%d) can not be greater than maximum (%d)", *pc.Minimum, *pc.Maximum)
+	}
+
+	if len(pc.Enum) > 0 || pc.Pattern != "" {
+		if parameterType != "string" {
+			return fmt.Errorf("enum and pattern can only be set on string parameters")
+		}
+	}
+
+	if pc.Pattern != "" {
+		if _, err := regexp.Compile(pc.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -179,5 +291,55 @@ func (rp RDSProperties) Validate() error {
 		return fmt.Errorf("This broker does not support RDS engine '%s' (%+v)", rp.Engine, rp)
 	}
 
+	if len(rp.AllowedExtensions) > 0 && strings.ToLower(rp.Engine) != "postgres" {
+		return fmt.Errorf("Extensions are only supported by the postgres engine (%+v)", rp)
+	}
+
+	if rp.IAMDatabaseAuthentication {
+		engine := strings.ToLower(rp.Engine)
+		if engine != "mysql" && engine != "postgres" {
+			return fmt.Errorf("IAM database authentication is only supported by the mysql and postgres engines (%+v)", rp)
+		}
+	}
+
+	if rp.BindingConnectionLimit < 0 || rp.BindingStatementTimeout < 0 || rp.BindingIdleInTransactionSessionTimeout < 0 {
+		return fmt.Errorf("Binding limits must not be negative (%+v)", rp)
+	}
+
+	if rp.BindingStatementTimeout > 0 || rp.BindingIdleInTransactionSessionTimeout > 0 {
+		if strings.ToLower(rp.Engine) != "postgres" {
+			return fmt.Errorf("Binding timeouts are only supported by the postgres engine (%+v)", rp)
+		}
+	}
+
+	if rp.IAMDatabaseAuthentication && (rp.BindingConnectionLimit > 0 || rp.BindingStatementTimeout > 0 || rp.BindingIdleInTransactionSessionTimeout > 0) {
+		return fmt.Errorf("Binding limits are not supported with IAM database authentication (%+v)", rp)
+	}
+
+	if len(rp.UserDBParameters) > 0 {
+		if rp.DBParameterGroupName == "" {
+			return fmt.Errorf("Must provide a DBParameterGroupName to copy when allowing user DB parameters (%+v)", rp)
+		}
+		for name, constraint := range rp.UserDBParameters {
+			if err := constraint.Validate(constraint.dbParameterType()); err != nil {
+				return fmt.Errorf("DB parameter '%s': %s", name, err)
+			}
+		}
+	}
+
+	if len(rp.UserOptions) > 0 {
+		engine := strings.ToLower(rp.Engine)
+		if engine != "mariadb" && engine != "mysql" {
+			return fmt.Errorf("Options are only supported by the mariadb and mysql engines (%+v)", rp)
+		}
+		for name, option := range rp.UserOptions {
+			for settingName, constraint := range option.Settings {
+				if err := constraint.Validate(constraint.dbParameterType()); err != nil {
+					return fmt.Errorf("Option '%s' setting '%s': %s", name, settingName, err)
+				}
+			}
+		}
+	}
+
 	return nil
 }
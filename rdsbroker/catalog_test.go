@@ -203,6 +203,16 @@ var _ = Describe("ServicePlan", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("Validating RDS Properties configuration"))
 		})
+
+		It("returns error if UserParameters are not valid", func() {
+			servicePlan.UserParameters = &UserParameters{
+				Provision: map[string]ParameterConstraints{"unknown": ParameterConstraints{}},
+			}
+
+			err := servicePlan.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Validating User Parameters configuration"))
+		})
 	})
 })
 
@@ -251,5 +261,162 @@ var _ = Describe("RDSProperties", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("This broker does not support RDS engine"))
 		})
+
+		It("returns error if user DB parameters are allowed without a DB parameter group to copy", func() {
+			rdsProperties.UserDBParameters = map[string]ParameterConstraints{"max_connections": ParameterConstraints{}}
+
+			err := rdsProperties.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Must provide a DBParameterGroupName to copy when allowing user DB parameters"))
+
+			rdsProperties.DBParameterGroupName = "plan-group"
+			Expect(rdsProperties.Validate()).To(Succeed())
+		})
+
+		It("returns error if user DB parameters constraints are not valid", func() {
+			minimum := int64(10)
+			rdsProperties.DBParameterGroupName = "plan-group"
+			rdsProperties.UserDBParameters = map[string]ParameterConstraints{
+				"max_connections": ParameterConstraints{Minimum: &minimum, Enum: []string{"10"}},
+			}
+
+			err := rdsProperties.Validate()
+			Expect(err).To(MatchError("DB parameter 'max_connections': enum and pattern can only be set on string parameters"))
+		})
+
+		It("returns error if IAM database authentication is enabled on an engine other than mysql or postgres", func() {
+			rdsProperties.Engine = "mariadb"
+			rdsProperties.IAMDatabaseAuthentication = true
+
+			err := rdsProperties.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("IAM database authentication is only supported by the mysql and postgres engines"))
+
+			rdsProperties.Engine = "postgres"
+			Expect(rdsProperties.Validate()).To(Succeed())
+		})
+
+		It("returns error if user options are allowed on an engine other than mariadb or mysql", func() {
+			rdsProperties.Engine = "postgres"
+			rdsProperties.UserOptions = map[string]OptionConstraints{"MEMCACHED": OptionConstraints{}}
+
+			err := rdsProperties.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Options are only supported by the mariadb and mysql engines"))
+		})
+
+		It("accepts an option group to copy along with user options", func() {
+			rdsProperties.OptionGroupName = "plan-options"
+			rdsProperties.UserOptions = map[string]OptionConstraints{"MEMCACHED": OptionConstraints{}}
+
+			err := rdsProperties.Validate()
+			Expect(err).ToNot(HaveOccurred())
+
+			rdsProperties.OptionGroupName = ""
+			Expect(rdsProperties.Validate()).To(Succeed())
+		})
+
+		It("returns error if extensions are allowed on an engine other than postgres", func() {
+			rdsProperties.AllowedExtensions = []string{"postgis"}
+
+			err := rdsProperties.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Extensions are only supported by the postgres engine"))
+
+			rdsProperties.Engine = "postgres"
+			Expect(rdsProperties.Validate()).To(Succeed())
+		})
+
+		It("returns error if binding limits are negative", func() {
+			rdsProperties.BindingConnectionLimit = -1
+
+			err := rdsProperties.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Binding limits must not be negative"))
+		})
+
+		It("returns error if binding timeouts are set on an engine other than postgres", func() {
+			rdsProperties.Engine = "mysql"
+			rdsProperties.BindingConnectionLimit = 10
+			Expect(rdsProperties.Validate()).To(Succeed())
+
+			rdsProperties.BindingStatementTimeout = 30000
+			err := rdsProperties.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Binding timeouts are only supported by the postgres engine"))
+
+			rdsProperties.Engine = "postgres"
+			Expect(rdsProperties.Validate()).To(Succeed())
+		})
+
+		It("returns error if binding limits are set with IAM database authentication", func() {
+			rdsProperties.Engine = "postgres"
+			rdsProperties.IAMDatabaseAuthentication = true
+			rdsProperties.BindingConnectionLimit = 10
+
+			err := rdsProperties.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Binding limits are not supported with IAM database authentication"))
+		})
+	})
+})
+
+var _ = Describe("UserParameters", func() {
+	var (
+		userParameters UserParameters
+
+		one   = int64(1)
+		seven = int64(7)
+	)
+
+	BeforeEach(func() {
+		userParameters = UserParameters{
+			Provision: map[string]ParameterConstraints{
+				"backup_retention_period": ParameterConstraints{Minimum: &one, Maximum: &seven},
+				"skip_final_snapshot":     ParameterConstraints{Enum: []string{"false"}},
+			},
+			Update: map[string]ParameterConstraints{
+				"BackupRetentionPeriod": ParameterConstraints{Maximum: &seven},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		It("does not return error if all fields are valid", func() {
+			err := userParameters.Validate()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns error if a parameter is unknown", func() {
+			userParameters.Bind = map[string]ParameterConstraints{"dbname": ParameterConstraints{}}
+
+			err := userParameters.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bind: Unknown parameter 'dbname'"))
+		})
+
+		It("returns error if minimum is greater than maximum", func() {
+			userParameters.Update["BackupRetentionPeriod"] = ParameterConstraints{Minimum: &seven, Maximum: &one}
+
+			err := userParameters.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("minimum (7) can not be greater than maximum (1)"))
+		})
+
+		It("returns error if bounds are set on a string parameter", func() {
+			userParameters.Provision["dbname"] = ParameterConstraints{Maximum: &seven}
+
+			err := userParameters.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("minimum and maximum can only be set on integer parameters"))
+		})
+
+		It("returns error if the pattern is not a valid regular expression", func() {
+			userParameters.Provision["dbname"] = ParameterConstraints{Pattern: "("}
+
+			err := userParameters.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid pattern"))
+		})
 	})
 })
@@ -3,6 +3,7 @@ package rdsbroker
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 type Config struct {
@@ -11,16 +12,97 @@ type Config struct {
 	BrokerName                   string  `json:"broker_name"`
 	AWSPartition                 string  `json:"aws_partition"`
 	MasterPasswordSeed           string  `json:"master_password_seed"`
+	PreviousMasterPasswordSeed   string  `json:"previous_master_password_seed"`
 	AllowUserProvisionParameters bool    `json:"allow_user_provision_parameters"`
 	AllowUserUpdateParameters    bool    `json:"allow_user_update_parameters"`
 	AllowUserBindParameters      bool    `json:"allow_user_bind_parameters"`
 	Catalog                      Catalog `json:"catalog"`
+
+	WindowScheduler *WindowSchedulerConfig `json:"window_scheduler,omitempty"`
+	SoftDelete      *SoftDeleteConfig      `json:"soft_delete,omitempty"`
+	WorkflowStore   *WorkflowStoreConfig   `json:"workflow_store,omitempty"`
+
+	MasterPasswordStore    *MasterPasswordStoreConfig    `json:"master_password_store,omitempty"`
+	MasterPasswordRotation *MasterPasswordRotationConfig `json:"master_password_rotation,omitempty"`
+
+	ServiceKeys *ServiceKeysConfig `json:"service_keys,omitempty"`
+}
+
+type WindowSchedulerConfig struct {
+	BackupWindowRanges      []string `json:"backup_window_ranges"`
+	MaintenanceWindowRanges []string `json:"maintenance_window_ranges"`
+	WindowDuration          int      `json:"window_duration"`
+}
+
+type SoftDeleteConfig struct {
+	GracePeriod   string `json:"grace_period"`
+	CheckInterval string `json:"check_interval"`
+}
+
+// WorkflowStoreConfig sets where the progress of multi-step operations is kept.
+// Type is "file", with the Path of a local directory, or "postgres" or "mysql",
+// with the URL of the database.
+type WorkflowStoreConfig struct {
+	Type          string `json:"type"`
+	Path          string `json:"path,omitempty"`
+	URL           string `json:"url,omitempty"`
+	CheckInterval string `json:"check_interval"`
+}
+
+// MasterPasswordStoreConfig makes the broker generate random master passwords
+// and keep them in a secret store, instead of deriving them from the
+// MasterPasswordSeed. Type is "secretsmanager", with an optional KMSKeyID
+// encrypting the secrets.
+type MasterPasswordStoreConfig struct {
+	Type     string `json:"type"`
+	KMSKeyID string `json:"kms_key_id,omitempty"`
+}
+
+// MasterPasswordRotationConfig sets the pace at which the master passwords of
+// the DB instances are moved from the PreviousMasterPasswordSeed to the
+// MasterPasswordSeed.
+type MasterPasswordRotationConfig struct {
+	Delay         string `json:"delay"`
+	CheckInterval string `json:"check_interval"`
+}
+
+// ServiceKeysConfig gives the service keys, the bindings without an app, a user
+// of their own, recorded in the state store, instead of the credentials of the
+// apps. The users are ReadOnly, and expire after ExpiresIn seconds unless it
+// is 0.
+type ServiceKeysConfig struct {
+	ReadOnly  bool  `json:"read_only"`
+	ExpiresIn int64 `json:"expires_in"`
 }
 
 func (c *Config) FillDefaults() {
 	if c.AWSPartition == "" {
 		c.AWSPartition = "aws"
 	}
+
+	if c.WindowScheduler != nil && c.WindowScheduler.WindowDuration == 0 {
+		c.WindowScheduler.WindowDuration = minWindowDuration
+	}
+
+	if c.SoftDelete != nil && c.SoftDelete.CheckInterval == "" {
+		c.SoftDelete.CheckInterval = "1h"
+	}
+
+	if c.WorkflowStore != nil && c.WorkflowStore.CheckInterval == "" {
+		c.WorkflowStore.CheckInterval = "30s"
+	}
+
+	if c.PreviousMasterPasswordSeed != "" && c.MasterPasswordRotation == nil {
+		c.MasterPasswordRotation = &MasterPasswordRotationConfig{}
+	}
+	if c.MasterPasswordRotation != nil {
+		if c.MasterPasswordRotation.Delay == "" {
+			c.MasterPasswordRotation.Delay = "10s"
+		}
+		if c.MasterPasswordRotation.CheckInterval == "" {
+			c.MasterPasswordRotation.CheckInterval = "1h"
+		}
+	}
 }
 
 func (c Config) Validate() error {
@@ -36,13 +118,204 @@ func (c Config) Validate() error {
 		return errors.New("Must provide a non-empty BrokerName")
 	}
 
-	if c.MasterPasswordSeed == "" {
+	// The seed is still used for the DB instances created before the master
+	// password store was configured
+	if c.MasterPasswordSeed == "" && c.MasterPasswordStore == nil {
 		return errors.New("Must provide a non-empty MasterPasswordSeed")
 	}
 
+	if c.PreviousMasterPasswordSeed != "" && c.PreviousMasterPasswordSeed == c.MasterPasswordSeed {
+		return errors.New("PreviousMasterPasswordSeed must be different from MasterPasswordSeed")
+	}
+
 	if err := c.Catalog.Validate(); err != nil {
 		return fmt.Errorf("Validating Catalog configuration: %s", err)
 	}
 
+	if c.WindowScheduler != nil {
+		if err := c.WindowScheduler.Validate(); err != nil {
+			return fmt.Errorf("Validating Window Scheduler configuration: %s", err)
+		}
+	}
+
+	if c.SoftDelete != nil {
+		if err := c.SoftDelete.Validate(); err != nil {
+			return fmt.Errorf("Validating Soft Delete configuration: %s", err)
+		}
+	}
+
+	if c.WorkflowStore != nil {
+		if err := c.WorkflowStore.Validate(); err != nil {
+			return fmt.Errorf("Validating Workflow Store configuration: %s", err)
+		}
+	}
+
+	if c.MasterPasswordStore != nil {
+		if err := c.MasterPasswordStore.Validate(); err != nil {
+			return fmt.Errorf("Validating Master Password Store configuration: %s", err)
+		}
+	}
+
+	if c.MasterPasswordRotation != nil {
+		if err := c.MasterPasswordRotation.Validate(); err != nil {
+			return fmt.Errorf("Validating Master Password Rotation configuration: %s", err)
+		}
+	}
+
+	if c.ServiceKeys != nil {
+		if err := c.ServiceKeys.Validate(); err != nil {
+			return fmt.Errorf("Validating Service Keys configuration: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (c WindowSchedulerConfig) Validate() error {
+	if c.WindowDuration < minWindowDuration {
+		return fmt.Errorf("WindowDuration must be at least %d minutes", minWindowDuration)
+	}
+
+	if len(c.BackupWindowRanges) == 0 && len(c.MaintenanceWindowRanges) == 0 {
+		return errors.New("Must provide at least one BackupWindowRanges or MaintenanceWindowRanges")
+	}
+
+	for _, windowRange := range c.BackupWindowRanges {
+		window, err := parseBackupWindow(windowRange)
+		if err != nil {
+			return fmt.Errorf("Invalid BackupWindowRanges '%s': %s", windowRange, err)
+		}
+		if window.duration() < c.WindowDuration {
+			return fmt.Errorf("BackupWindowRanges '%s' is shorter than WindowDuration", windowRange)
+		}
+	}
+
+	for _, windowRange := range c.MaintenanceWindowRanges {
+		window, err := parseMaintenanceWindow(windowRange)
+		if err != nil {
+			return fmt.Errorf("Invalid MaintenanceWindowRanges '%s': %s", windowRange, err)
+		}
+		if window.duration() < c.WindowDuration {
+			return fmt.Errorf("MaintenanceWindowRanges '%s' is shorter than WindowDuration", windowRange)
+		}
+	}
+
+	return nil
+}
+
+func (c SoftDeleteConfig) Validate() error {
+	gracePeriod, err := time.ParseDuration(c.GracePeriod)
+	if err != nil {
+		return fmt.Errorf("Invalid GracePeriod '%s': %s", c.GracePeriod, err)
+	}
+	if gracePeriod <= 0 {
+		return errors.New("GracePeriod must be positive")
+	}
+
+	checkInterval, err := time.ParseDuration(c.CheckInterval)
+	if err != nil {
+		return fmt.Errorf("Invalid CheckInterval '%s': %s", c.CheckInterval, err)
+	}
+	if checkInterval <= 0 {
+		return errors.New("CheckInterval must be positive")
+	}
+
+	return nil
+}
+
+// GracePeriodDuration returns the time soft-deleted instances are kept before
+// being deleted. The configuration must have been validated.
+func (c SoftDeleteConfig) GracePeriodDuration() time.Duration {
+	gracePeriod, _ := time.ParseDuration(c.GracePeriod)
+	return gracePeriod
+}
+
+// CheckIntervalDuration returns how often the soft-deleted instances are
+// checked. The configuration must have been validated.
+func (c SoftDeleteConfig) CheckIntervalDuration() time.Duration {
+	checkInterval, _ := time.ParseDuration(c.CheckInterval)
+	return checkInterval
+}
+
+func (c WorkflowStoreConfig) Validate() error {
+	switch c.Type {
+	case "file":
+		if c.Path == "" {
+			return errors.New("Must provide a non-empty Path")
+		}
+	case "postgres", "mysql":
+		if c.URL == "" {
+			return errors.New("Must provide a non-empty URL")
+		}
+	default:
+		return fmt.Errorf("Invalid Type '%s', must be 'file', 'postgres' or 'mysql'", c.Type)
+	}
+
+	checkInterval, err := time.ParseDuration(c.CheckInterval)
+	if err != nil {
+		return fmt.Errorf("Invalid CheckInterval '%s': %s", c.CheckInterval, err)
+	}
+	if checkInterval <= 0 {
+		return errors.New("CheckInterval must be positive")
+	}
+
+	return nil
+}
+
+// CheckIntervalDuration returns how often the workflows in progress are run.
+// The configuration must have been validated.
+func (c WorkflowStoreConfig) CheckIntervalDuration() time.Duration {
+	checkInterval, _ := time.ParseDuration(c.CheckInterval)
+	return checkInterval
+}
+
+func (c MasterPasswordStoreConfig) Validate() error {
+	if c.Type != "secretsmanager" {
+		return fmt.Errorf("Invalid Type '%s', must be 'secretsmanager'", c.Type)
+	}
+
+	return nil
+}
+
+func (c MasterPasswordRotationConfig) Validate() error {
+	delay, err := time.ParseDuration(c.Delay)
+	if err != nil {
+		return fmt.Errorf("Invalid Delay '%s': %s", c.Delay, err)
+	}
+	if delay < 0 {
+		return errors.New("Delay must not be negative")
+	}
+
+	checkInterval, err := time.ParseDuration(c.CheckInterval)
+	if err != nil {
+		return fmt.Errorf("Invalid CheckInterval '%s': %s", c.CheckInterval, err)
+	}
+	if checkInterval <= 0 {
+		return errors.New("CheckInterval must be positive")
+	}
+
+	return nil
+}
+
+// DelayDuration returns the pause between two changes of master passwords.
+// The configuration must have been validated.
+func (c MasterPasswordRotationConfig) DelayDuration() time.Duration {
+	delay, _ := time.ParseDuration(c.Delay)
+	return delay
+}
+
+// CheckIntervalDuration returns how often the master passwords are checked
+// while some DB instances may still use the previous seed. The configuration
+// must have been validated.
+func (c MasterPasswordRotationConfig) CheckIntervalDuration() time.Duration {
+	checkInterval, _ := time.ParseDuration(c.CheckInterval)
+	return checkInterval
+}
+
+func (c ServiceKeysConfig) Validate() error {
+	if c.ExpiresIn < 0 {
+		return errors.New("ExpiresIn must not be negative")
+	}
+
 	return nil
 }
@@ -0,0 +1,99 @@
+package rdsbroker
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/awsrds"
+)
+
+// hasOwnDBParameterGroup tells whether the DB instances of a plan get a DB
+// parameter group of their own, which users can tune.
+func hasOwnDBParameterGroup(servicePlan ServicePlan) bool {
+	return len(servicePlan.RDSProperties.UserDBParameters) > 0
+}
+
+// dbParameterGroupName returns the DB parameter group the DB instance should
+// use on the plan.
+func (b *RDSBroker) dbParameterGroupName(instanceID string, servicePlan ServicePlan) string {
+	if hasOwnDBParameterGroup(servicePlan) {
+		return b.dbInstanceIdentifier(instanceID)
+	}
+	return servicePlan.RDSProperties.DBParameterGroupName
+}
+
+// createDBParameterGroup copies the DB parameter group of the plan for a new
+// DB instance, with the parameters given by the user.
+func (b *RDSBroker) createDBParameterGroup(instanceID string, servicePlan ServicePlan, dbParameters map[string]interface{}, tags map[string]string) error {
+	name := b.dbParameterGroupName(instanceID, servicePlan)
+
+	b.logger.Info("create-db-parameter-group", lager.Data{instanceIDLogKey: instanceID, "source": servicePlan.RDSProperties.DBParameterGroupName})
+
+	err := b.dbInstance.CopyParameterGroup(servicePlan.RDSProperties.DBParameterGroupName, name, tags)
+	if err != nil && err != awsrds.ErrDBParameterGroupAlreadyExists {
+		return err
+	}
+
+	return b.modifyDBParameterGroup(name, dbParameters)
+}
+
+// validateDBParameterGroupUpdate checks that the DB parameter group of the DB
+// instance, if it has its own, can be kept on the new plan.
+func (b *RDSBroker) validateDBParameterGroupUpdate(instanceID string, servicePlan, previousServicePlan ServicePlan) error {
+	if !hasOwnDBParameterGroup(servicePlan) || !hasOwnDBParameterGroup(previousServicePlan) {
+		return nil
+	}
+
+	source := servicePlan.RDSProperties.DBParameterGroupName
+	previousSource := previousServicePlan.RDSProperties.DBParameterGroupName
+	if source != previousSource {
+		err := fmt.Errorf("DB Instance '%s' has its own DB parameter group, copied from '%s', which cannot be replaced by a copy of '%s'", b.dbInstanceIdentifier(instanceID), previousSource, source)
+		return NewFailureResponse(err, statusUnprocessableEntity, "db-parameter-group-not-updateable")
+	}
+
+	return nil
+}
+
+// updateDBParameterGroup gives the DB instance its own DB parameter group if
+// it moves to a plan with user tunable parameters, and applies the parameters
+// given by the user. The update must have been validated by
+// validateDBParameterGroupUpdate.
+func (b *RDSBroker) updateDBParameterGroup(instanceID string, servicePlan, previousServicePlan ServicePlan, dbParameters map[string]interface{}, tags map[string]string) error {
+	if !hasOwnDBParameterGroup(servicePlan) {
+		return nil
+	}
+
+	if !hasOwnDBParameterGroup(previousServicePlan) {
+		return b.createDBParameterGroup(instanceID, servicePlan, dbParameters, tags)
+	}
+
+	return b.modifyDBParameterGroup(b.dbParameterGroupName(instanceID, servicePlan), dbParameters)
+}
+
+func (b *RDSBroker) modifyDBParameterGroup(name string, dbParameters map[string]interface{}) error {
+	if len(dbParameters) == 0 {
+		return nil
+	}
+
+	parameters := map[string]string{}
+	for parameterName, value := range dbParameters {
+		parameters[parameterName] = formatParameterValue(value)
+	}
+
+	if err := b.dbInstance.ModifyParameterGroup(name, parameters); err != nil {
+		return fmt.Errorf("Could not set the parameters of DB parameter group '%s': %s", name, err)
+	}
+
+	return nil
+}
+
+// formatParameterValue turns a value decoded from JSON into the string RDS
+// expects, without an exponent for large numbers.
+func formatParameterValue(value interface{}) string {
+	if v, ok := value.(float64); ok {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,411 @@
+package rdsbroker
+
+import (
+	"fmt"
+
+	"github.com/frodenas/brokerapi"
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/awsrds"
+	"github.com/alphagov/paas-rds-broker/workflow"
+)
+
+const encryptionChangeWorkflow = "encryption-change"
+
+// encryptionChangeTag marks the DB instances involved in an encryption change,
+// as they may be renamed while it runs.
+const encryptionChangeTag = "Encryption change"
+const encryptionChangeSnapshotType = "encryption-change"
+
+// defaultKmsKeyID is the KMS key used by RDS when a plan encrypts its DB
+// instances without setting a key.
+const defaultKmsKeyID = "alias/aws/rds"
+
+// swappedData is set once the new DB instance has been given the identifier of
+// the original one, after which the encryption change can only go forward.
+const swappedData = "swapped"
+
+// An encryption change snapshots the DB instance, encrypts a copy of the
+// snapshot, restores it as a new DB instance and configures it, then swaps the
+// two DB instances by renaming them before deleting the original one.
+const (
+	encryptionChangeSnapshotStep  = "snapshot"
+	encryptionChangeCopyStep      = "copy"
+	encryptionChangeRestoreStep   = "restore"
+	encryptionChangeConfigureStep = "configure"
+	encryptionChangeRenameStep    = "rename"
+	encryptionChangeCleanupStep   = "cleanup"
+	encryptionChangeRollbackStep  = "rollback"
+)
+
+var snapshotInProgressStatuses = map[string]bool{
+	"creating": true,
+	"copying":  true,
+	"pending":  true,
+}
+
+// startEncryptionChange takes a snapshot of the DB instance and starts the
+// workflow moving it to the encryption settings of the new plan.
+func (b *RDSBroker) startEncryptionChange(instanceID string, servicePlan ServicePlan, details brokerapi.UpdateDetails) error {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(instanceID)
+
+	data, err := b.updateWorkflowData("", servicePlan, details)
+	if err != nil {
+		return err
+	}
+
+	tags := b.dbTags("Created", details.ServiceID, details.PreviousValues.PlanID, details.PreviousValues.OrganizationID, details.PreviousValues.SpaceID, "")
+	tags[instanceIDTag] = instanceID
+	tags[snapshotTypeTag] = encryptionChangeSnapshotType
+
+	snapshotID, err := b.dbInstance.CreateSnapshot(dbInstanceIdentifier, tags)
+	if err != nil {
+		return err
+	}
+	data[snapshotIDData] = snapshotID
+	b.logger.Info("start-encryption-change", lager.Data{instanceIDLogKey: instanceID, "snapshot-id": snapshotID})
+
+	if err := b.dbInstance.AddTags(dbInstanceIdentifier, map[string]string{encryptionChangeTag: snapshotID}); err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("Taking DB Snapshot '%s' before changing the encryption of DB Instance '%s'", snapshotID, dbInstanceIdentifier)
+	return b.workflows.Start(instanceID, encryptionChangeWorkflow, encryptionChangeSnapshotStep, description, data)
+}
+
+func (b *RDSBroker) copyEncryptionChangeSnapshot(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+	snapshotID := w.Data[snapshotIDData]
+
+	dbSnapshot, err := b.findInstanceSnapshot(dbInstanceIdentifier, snapshotID)
+	if err != nil {
+		return "", "", err
+	}
+	if dbSnapshot != nil && snapshotInProgressStatuses[dbSnapshot.Status] {
+		return w.Step, "", nil
+	}
+	if dbSnapshot == nil || dbSnapshot.Status != "available" {
+		return "", "", workflow.Failf("DB Snapshot '%s' failed", snapshotID)
+	}
+
+	servicePlan, _, _, err := b.targetChange(w.Data)
+	if err != nil {
+		return "", "", workflow.Failf("%s", err)
+	}
+	kmsKeyID := servicePlan.RDSProperties.KmsKeyID
+	if kmsKeyID == "" {
+		kmsKeyID = defaultKmsKeyID
+	}
+
+	if err := b.dbInstance.CopySnapshot(snapshotID, encryptedSnapshotID(snapshotID), kmsKeyID, dbSnapshot.Tags); err != nil {
+		return "", "", err
+	}
+
+	return encryptionChangeCopyStep, fmt.Sprintf("Encrypting a copy of DB Snapshot '%s'", snapshotID), nil
+}
+
+func (b *RDSBroker) restoreEncryptedSnapshot(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+	snapshotID := w.Data[snapshotIDData]
+	copyID := encryptedSnapshotID(snapshotID)
+
+	dbSnapshot, err := b.findInstanceSnapshot(dbInstanceIdentifier, copyID)
+	if err != nil {
+		return "", "", err
+	}
+	if dbSnapshot != nil && snapshotInProgressStatuses[dbSnapshot.Status] {
+		return w.Step, "", nil
+	}
+	if dbSnapshot == nil || dbSnapshot.Status != "available" {
+		return "", "", workflow.Failf("Encrypted copy '%s' of DB Snapshot '%s' failed", copyID, snapshotID)
+	}
+
+	servicePlan, updateParameters, details, err := b.targetChange(w.Data)
+	if err != nil {
+		return "", "", workflow.Failf("%s", err)
+	}
+	previousServicePlan, ok := b.catalog.FindServicePlan(details.PreviousValues.PlanID)
+	if !ok {
+		return "", "", workflow.Failf("Service Plan '%s' not found", details.PreviousValues.PlanID)
+	}
+
+	// The groups are changed once the snapshot is taken, and before the new
+	// DB instance is restored into them
+	if err := b.updateGroups(w.InstanceID, servicePlan, previousServicePlan, updateParameters, details); err != nil {
+		return "", "", err
+	}
+
+	// The restored DB instance takes over the tags of the original one
+	tags, err := b.dbInstance.GetTags(dbInstanceIdentifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	restoreDBInstance := b.dbInstanceFromPlan(servicePlan)
+	restoreDBInstance.DBParameterGroupName = b.dbParameterGroupName(w.InstanceID, servicePlan)
+	restoreDBInstance.OptionGroupName = b.optionGroupName(w.InstanceID, servicePlan)
+	restoreDBInstance.Tags = tags
+	if err := b.dbInstance.RestoreFromSnapshot(newDBInstanceIdentifier(dbInstanceIdentifier), copyID, *restoreDBInstance); err != nil {
+		return "", "", err
+	}
+
+	return encryptionChangeRestoreStep, fmt.Sprintf("Restoring DB Instance '%s' from the encrypted DB Snapshot '%s'", newDBInstanceIdentifier(dbInstanceIdentifier), copyID), nil
+}
+
+// configureRestoredDBInstance applies the settings which cannot be given when
+// restoring a snapshot, such as the security groups, to the new DB instance.
+func (b *RDSBroker) configureRestoredDBInstance(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+
+	ready, description, err := b.restoredDBInstanceReady(dbInstanceIdentifier)
+	if err != nil || !ready {
+		return w.Step, description, err
+	}
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	servicePlan, updateParameters, details, err := b.targetChange(w.Data)
+	if err != nil {
+		return "", "", workflow.Failf("%s", err)
+	}
+	modifyDBInstance := b.modifyDBInstance(w.InstanceID, servicePlan, updateParameters, details)
+	// Keep the settings of the original DB instance not given by the plan
+	if modifyDBInstance.BackupRetentionPeriod == 0 {
+		modifyDBInstance.BackupRetentionPeriod = dbInstanceDetails.BackupRetentionPeriod
+	}
+	if modifyDBInstance.PreferredBackupWindow == "" {
+		modifyDBInstance.PreferredBackupWindow = dbInstanceDetails.PreferredBackupWindow
+	}
+	if modifyDBInstance.PreferredMaintenanceWindow == "" {
+		modifyDBInstance.PreferredMaintenanceWindow = dbInstanceDetails.PreferredMaintenanceWindow
+	}
+	if err := b.dbInstance.Modify(newDBInstanceIdentifier(dbInstanceIdentifier), *modifyDBInstance, true); err != nil {
+		return "", "", err
+	}
+
+	return encryptionChangeConfigureStep, fmt.Sprintf("Configuring DB Instance '%s'", newDBInstanceIdentifier(dbInstanceIdentifier)), nil
+}
+
+func (b *RDSBroker) renameOriginalDBInstance(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+
+	ready, description, err := b.restoredDBInstanceReady(dbInstanceIdentifier)
+	if err != nil || !ready {
+		return w.Step, description, err
+	}
+
+	// The extensions are updated on the new DB instance, which can be
+	// reached once configured, so that the original one is left untouched
+	servicePlan, updateParameters, _, err := b.targetChange(w.Data)
+	if err != nil {
+		return "", "", workflow.Failf("%s", err)
+	}
+	if updateParameters.Extensions != nil {
+		if err := b.updateExtensions(w.InstanceID, newDBInstanceIdentifier(dbInstanceIdentifier), servicePlan, updateParameters.Extensions); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := b.dbInstance.Rename(dbInstanceIdentifier, oldDBInstanceIdentifier(dbInstanceIdentifier)); err != nil {
+		return "", "", err
+	}
+
+	return encryptionChangeRenameStep, fmt.Sprintf("Swapping DB Instance '%s' with '%s'", dbInstanceIdentifier, newDBInstanceIdentifier(dbInstanceIdentifier)), nil
+}
+
+// renameRestoredDBInstance gives its identifier to the new DB instance once the
+// original one has been renamed out of the way.
+func (b *RDSBroker) renameRestoredDBInstance(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+
+	if _, err := b.dbInstance.Describe(dbInstanceIdentifier); err != awsrds.ErrDBInstanceDoesNotExist {
+		return w.Step, "", err
+	}
+
+	oldDBInstanceDetails, err := b.dbInstance.Describe(oldDBInstanceIdentifier(dbInstanceIdentifier))
+	if err == awsrds.ErrDBInstanceDoesNotExist {
+		return w.Step, "", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	newDBInstanceDetails, err := b.dbInstance.Describe(newDBInstanceIdentifier(dbInstanceIdentifier))
+	if err != nil {
+		return "", "", err
+	}
+
+	if oldDBInstanceDetails.Status != "available" || newDBInstanceDetails.Status != "available" {
+		return w.Step, "", nil
+	}
+
+	if err := b.dbInstance.Rename(newDBInstanceIdentifier(dbInstanceIdentifier), dbInstanceIdentifier); err != nil {
+		return "", "", workflow.Failf("Could not rename DB Instance '%s': %s", newDBInstanceIdentifier(dbInstanceIdentifier), err)
+	}
+	w.Data[swappedData] = "true"
+
+	return encryptionChangeCleanupStep, "", nil
+}
+
+func (b *RDSBroker) cleanupEncryptionChange(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+	snapshotID := w.Data[snapshotIDData]
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err == awsrds.ErrDBInstanceDoesNotExist {
+		return w.Step, "", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if dbInstanceDetails.Status != "available" {
+		return w.Step, "", nil
+	}
+
+	if err := b.dbInstance.Delete(oldDBInstanceIdentifier(dbInstanceIdentifier), true); err != nil && err != awsrds.ErrDBInstanceDoesNotExist {
+		return "", "", err
+	}
+	b.deleteEncryptionChangeSnapshots(w.InstanceID, snapshotID, encryptedSnapshotID(snapshotID))
+	if err := b.dbInstance.RemoveTags(dbInstanceIdentifier, []string{encryptionChangeTag}); err != nil {
+		return "", "", err
+	}
+
+	description := fmt.Sprintf("Deleting the previous DB Instance '%s'", oldDBInstanceIdentifier(dbInstanceIdentifier))
+
+	// The groups of its own the original DB instance leaves behind are
+	// deleted with it
+	if leftGroups := b.changeLeftGroups(w); len(leftGroups) > 0 {
+		for key, name := range leftGroups {
+			w.Data[key] = name
+		}
+		return deleteGroupsStep, description, nil
+	}
+
+	return workflow.Finished, description, nil
+}
+
+// deleteEncryptionChangeGroups deletes the groups left behind by the original
+// DB instance once it is deleted.
+func (b *RDSBroker) deleteEncryptionChangeGroups(w *workflow.Workflow) (string, string, error) {
+	oldDBInstanceDetails, err := b.dbInstance.Describe(oldDBInstanceIdentifier(b.dbInstanceIdentifier(w.InstanceID)))
+	if err == nil {
+		return w.Step, fmt.Sprintf("DB Instance '%s' status is '%s'", oldDBInstanceDetails.Identifier, oldDBInstanceDetails.Status), nil
+	}
+	if err != awsrds.ErrDBInstanceDoesNotExist {
+		return "", "", err
+	}
+
+	return b.deleteGroups(w)
+}
+
+// rollbackEncryptionChange gives its identifier back to the original DB
+// instance if needed, then deletes everything created by the encryption change.
+// Once the DB instances have been swapped, it finishes the cleanup instead, as
+// the DB instance is then the encrypted one.
+func (b *RDSBroker) rollbackEncryptionChange(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+	snapshotID := w.Data[snapshotIDData]
+	description := fmt.Sprintf("Rolling back the encryption change of DB Instance '%s'", dbInstanceIdentifier)
+
+	if w.Data[swappedData] != "" {
+		b.logger.Info("finish-encryption-change", lager.Data{instanceIDLogKey: w.InstanceID, "reason": w.Failure})
+		// The update has been applied, only the cleanup is left
+		w.Failure = ""
+		return encryptionChangeCleanupStep, fmt.Sprintf("Deleting the previous DB Instance '%s'", oldDBInstanceIdentifier(dbInstanceIdentifier)), nil
+	}
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err == awsrds.ErrDBInstanceDoesNotExist {
+		oldDBInstanceDetails, err := b.dbInstance.Describe(oldDBInstanceIdentifier(dbInstanceIdentifier))
+		if err == awsrds.ErrDBInstanceDoesNotExist {
+			// Being renamed back
+			return w.Step, description, nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if oldDBInstanceDetails.Status == "available" {
+			b.logger.Info("rename-original-db-instance", lager.Data{instanceIDLogKey: w.InstanceID})
+			if err := b.dbInstance.Rename(oldDBInstanceIdentifier(dbInstanceIdentifier), dbInstanceIdentifier); err != nil {
+				return "", "", err
+			}
+		}
+		return w.Step, description, nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if dbInstanceDetails.Status == "renaming" {
+		return w.Step, description, nil
+	}
+
+	b.logger.Info("rollback-encryption-change", lager.Data{instanceIDLogKey: w.InstanceID, "reason": w.Failure})
+
+	if err := b.dbInstance.Delete(newDBInstanceIdentifier(dbInstanceIdentifier), true); err != nil && err != awsrds.ErrDBInstanceDoesNotExist {
+		return "", "", err
+	}
+	b.deleteEncryptionChangeSnapshots(w.InstanceID, snapshotID, encryptedSnapshotID(snapshotID))
+	if err := b.dbInstance.RemoveTags(dbInstanceIdentifier, []string{encryptionChangeTag}); err != nil {
+		return "", "", err
+	}
+
+	return workflow.Finished, fmt.Sprintf("Could not change the encryption of DB Instance '%s': %s. The changes have been rolled back", dbInstanceIdentifier, w.Failure), nil
+}
+
+// restoredDBInstanceReady checks whether the DB instance restored from the
+// encrypted snapshot is available and has no pending modifications.
+func (b *RDSBroker) restoredDBInstanceReady(dbInstanceIdentifier string) (bool, string, error) {
+	newDBInstanceDetails, err := b.dbInstance.Describe(newDBInstanceIdentifier(dbInstanceIdentifier))
+	if err != nil {
+		if err == awsrds.ErrDBInstanceDoesNotExist {
+			return false, "", workflow.Failf("DB Instance '%s' does not exist", newDBInstanceIdentifier(dbInstanceIdentifier))
+		}
+		return false, "", err
+	}
+
+	state := rdsStatus2State[newDBInstanceDetails.Status]
+	if state == "" {
+		return false, "", workflow.Failf("DB Instance '%s' status is '%s'", newDBInstanceIdentifier(dbInstanceIdentifier), newDBInstanceDetails.Status)
+	}
+	if state == brokerapi.LastOperationInProgress || newDBInstanceDetails.PendingModifications {
+		return false, fmt.Sprintf("Preparing DB Instance '%s', its status is '%s'", newDBInstanceIdentifier(dbInstanceIdentifier), newDBInstanceDetails.Status), nil
+	}
+
+	return true, "", nil
+}
+
+func (b *RDSBroker) deleteEncryptionChangeSnapshots(instanceID string, snapshotIDs ...string) {
+	dbSnapshots, err := b.dbInstance.DescribeInstanceSnapshots(b.dbInstanceIdentifier(instanceID))
+	if err != nil {
+		b.logger.Error("delete-encryption-change-snapshots", err, lager.Data{instanceIDLogKey: instanceID})
+		return
+	}
+
+	for _, dbSnapshot := range dbSnapshots {
+		for _, snapshotID := range snapshotIDs {
+			if dbSnapshot.Identifier != snapshotID {
+				continue
+			}
+			logData := lager.Data{instanceIDLogKey: instanceID, "snapshot-id": snapshotID}
+			b.logger.Info("delete-encryption-change-snapshot", logData)
+			if err := b.dbInstance.DeleteSnapshot(snapshotID); err != nil {
+				b.logger.Error("delete-encryption-change-snapshot", err, logData)
+			}
+		}
+	}
+}
+
+func encryptedSnapshotID(snapshotID string) string {
+	return snapshotID + "-encrypted"
+}
+
+func newDBInstanceIdentifier(dbInstanceIdentifier string) string {
+	return dbInstanceIdentifier + "-new"
+}
+
+func oldDBInstanceIdentifier(dbInstanceIdentifier string) string {
+	return dbInstanceIdentifier + "-old"
+}
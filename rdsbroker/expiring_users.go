@@ -0,0 +1,11 @@
+package rdsbroker
+
+import (
+	"github.com/alphagov/paas-rds-broker/sqlengine"
+)
+
+// DropExpiredUsers drops the users of the bindings created with expires_in
+// which have expired, from every DB instance.
+func (b *RDSBroker) DropExpiredUsers() {
+	b.updateDBInstances("drop-expired-users", "dropped", sqlengine.SQLEngine.DropExpiredUsers)
+}
@@ -0,0 +1,106 @@
+package rdsbroker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/awsrds"
+	"github.com/alphagov/paas-rds-broker/sqlengine"
+)
+
+// extensionsTag reports the extensions enabled by the users on a DB instance,
+// separated by spaces as RDS does not accept commas in tag values.
+const extensionsTag = "Extensions"
+
+const extensionsData = "extensions"
+
+// updateExtensions enables the extensions listed by the user in the database
+// of the given DB instance of the service instance, and drops the other
+// extensions allowed by the plan. Extensions not allowed by the plan are left
+// alone.
+func (b *RDSBroker) updateExtensions(instanceID string, dbInstanceIdentifier string, servicePlan ServicePlan, extensions []string) error {
+	extensions = normalizeExtensions(extensions)
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err != nil {
+		return err
+	}
+
+	sqlEngine, err := b.openMasterSQLEngine(instanceID, servicePlan, dbInstanceDetails)
+	if err != nil {
+		return err
+	}
+	defer sqlEngine.Close()
+
+	enabledExtensions, err := sqlEngine.ListExtensions()
+	if err != nil {
+		return err
+	}
+
+	var createExtensions, dropExtensions []string
+	for _, extension := range extensions {
+		if !containsString(enabledExtensions, extension) {
+			createExtensions = append(createExtensions, extension)
+		}
+	}
+	for _, extension := range enabledExtensions {
+		if containsString(servicePlan.RDSProperties.AllowedExtensions, extension) && !containsString(extensions, extension) {
+			dropExtensions = append(dropExtensions, extension)
+		}
+	}
+
+	b.logger.Info("update-extensions", lager.Data{instanceIDLogKey: instanceID, "create": createExtensions, "drop": dropExtensions})
+
+	if len(dropExtensions) > 0 {
+		if err := sqlEngine.DropExtensions(dropExtensions); err != nil {
+			return fmt.Errorf("Could not drop extensions from DB Instance '%s': %s", dbInstanceIdentifier, err)
+		}
+	}
+	if len(createExtensions) > 0 {
+		if err := sqlEngine.CreateExtensions(createExtensions); err != nil {
+			return fmt.Errorf("Could not create extensions on DB Instance '%s': %s", dbInstanceIdentifier, err)
+		}
+	}
+
+	return b.tagExtensions(dbInstanceIdentifier, extensions)
+}
+
+func (b *RDSBroker) tagExtensions(dbInstanceIdentifier string, extensions []string) error {
+	if len(extensions) == 0 {
+		return b.dbInstance.RemoveTags(dbInstanceIdentifier, []string{extensionsTag})
+	}
+
+	return b.dbInstance.AddTags(dbInstanceIdentifier, map[string]string{
+		extensionsTag: strings.Join(extensions, " "),
+	})
+}
+
+// openMasterSQLEngine connects to the database of a DB instance with the
+// master credentials.
+func (b *RDSBroker) openMasterSQLEngine(instanceID string, servicePlan ServicePlan, dbInstanceDetails awsrds.DBInstanceDetails) (sqlengine.SQLEngine, error) {
+	sqlEngine, err := b.sqlProvider.GetSQLEngine(servicePlan.RDSProperties.Engine)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.openAsMaster(sqlEngine, instanceID, dbInstanceDetails.Address, dbInstanceDetails.Port, b.dbNameFromDetails(instanceID, dbInstanceDetails), dbInstanceDetails.MasterUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlEngine, nil
+}
+
+func normalizeExtensions(extensions []string) []string {
+	normalized := []string{}
+	for _, extension := range extensions {
+		if !containsString(normalized, extension) {
+			normalized = append(normalized, extension)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
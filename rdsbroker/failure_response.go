@@ -0,0 +1,34 @@
+package rdsbroker
+
+import (
+	"net/http"
+)
+
+// FailureResponse is an error sent back to the platform with the given status
+// code instead of 500 Internal Server Error by NewHandler.
+type FailureResponse struct {
+	error
+	statusCode   int
+	loggerAction string
+}
+
+// NewFailureResponse returns an error that will be sent to the platform with
+// the given status code. The loggerAction is used as the key when logging it.
+func NewFailureResponse(err error, statusCode int, loggerAction string) *FailureResponse {
+	return &FailureResponse{
+		error:        err,
+		statusCode:   statusCode,
+		loggerAction: loggerAction,
+	}
+}
+
+func (f *FailureResponse) StatusCode() int {
+	if f.statusCode < 400 || f.statusCode > 599 {
+		return http.StatusInternalServerError
+	}
+	return f.statusCode
+}
+
+func (f *FailureResponse) LoggerAction() string {
+	return f.loggerAction
+}
@@ -0,0 +1,167 @@
+package rdsbroker
+
+import (
+	"fmt"
+
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/awsrds"
+	"github.com/alphagov/paas-rds-broker/workflow"
+)
+
+const deleteGroupsWorkflow = "delete-groups"
+
+const deleteGroupsStep = "delete"
+
+// The groups of its own a DB instance leaves behind.
+const (
+	dbParameterGroupData = "db_parameter_group"
+	optionGroupData      = "option_group"
+)
+
+// startGroupsDeletion starts the workflow deleting the DB parameter group and
+// option group of a DB instance once the DB instance itself is deleted, as RDS
+// does not delete groups still in use. It replaces the workflow of the service
+// instance, taking over the groups left behind by a plan change which are still
+// waiting to be deleted. It does nothing if the DB instance only uses the
+// groups of its plan.
+func (b *RDSBroker) startGroupsDeletion(instanceID string, servicePlan ServicePlan) error {
+	data := map[string]string{}
+
+	w, err := b.workflows.Get(instanceID)
+	if err != nil && err != workflow.ErrNotFound {
+		return err
+	}
+	if err == nil && w.Kind == deleteGroupsWorkflow && w.State == workflow.StateInProgress {
+		for key, name := range w.Data {
+			data[key] = name
+		}
+	}
+	if err := b.workflows.Forget(instanceID); err != nil {
+		return err
+	}
+
+	if hasOwnDBParameterGroup(servicePlan) {
+		data[dbParameterGroupData] = b.dbParameterGroupName(instanceID, servicePlan)
+	}
+	if hasOwnOptionGroup(servicePlan) {
+		data[optionGroupData] = b.optionGroupName(instanceID, servicePlan)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	description := fmt.Sprintf("DB Instance '%s' status is 'deleting'", b.dbInstanceIdentifier(instanceID))
+
+	return b.workflows.Start(instanceID, deleteGroupsWorkflow, deleteGroupsStep, description, data)
+}
+
+// leftGroups returns the workflow data naming the groups of its own a DB
+// instance leaves behind when moving to a plan without them.
+func (b *RDSBroker) leftGroups(instanceID string, servicePlan, previousServicePlan ServicePlan) map[string]string {
+	data := map[string]string{}
+	if hasOwnDBParameterGroup(previousServicePlan) && !hasOwnDBParameterGroup(servicePlan) {
+		data[dbParameterGroupData] = b.dbParameterGroupName(instanceID, previousServicePlan)
+	}
+	if hasOwnOptionGroup(previousServicePlan) && !hasOwnOptionGroup(servicePlan) {
+		data[optionGroupData] = b.optionGroupName(instanceID, previousServicePlan)
+	}
+	return data
+}
+
+// changeLeftGroups returns the workflow data naming the groups of its own the
+// DB instance leaves behind with the update recorded by updateWorkflowData.
+func (b *RDSBroker) changeLeftGroups(w *workflow.Workflow) map[string]string {
+	servicePlan, ok := b.catalog.FindServicePlan(w.Data[planIDData])
+	if !ok {
+		return map[string]string{}
+	}
+	previousServicePlan, ok := b.catalog.FindServicePlan(w.Data[previousPlanIDData])
+	if !ok {
+		return map[string]string{}
+	}
+	return b.leftGroups(w.InstanceID, servicePlan, previousServicePlan)
+}
+
+// startLeftGroupsDeletion starts the workflow deleting the groups of its own a
+// DB instance leaves behind when moving to a plan without them, once the DB
+// instance has been moved to the groups of the plan.
+func (b *RDSBroker) startLeftGroupsDeletion(instanceID string, servicePlan, previousServicePlan ServicePlan) error {
+	data := b.leftGroups(instanceID, servicePlan, previousServicePlan)
+	if len(data) == 0 {
+		return nil
+	}
+
+	description := fmt.Sprintf("DB Instance '%s' status is 'modifying'", b.dbInstanceIdentifier(instanceID))
+
+	return b.workflows.Start(instanceID, deleteGroupsWorkflow, deleteGroupsStep, description, data)
+}
+
+// deleteGroups deletes the groups named in the workflow data once the DB
+// instance is deleted, or has stopped using them.
+func (b *RDSBroker) deleteGroups(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err == nil && !groupsReleased(dbInstanceDetails, w.Data) {
+		return w.Step, fmt.Sprintf("DB Instance '%s' status is '%s'", dbInstanceIdentifier, dbInstanceDetails.Status), nil
+	}
+	if err != nil && err != awsrds.ErrDBInstanceDoesNotExist {
+		return "", "", err
+	}
+
+	if err := b.deleteOwnGroups(w.InstanceID, w.Data); err != nil {
+		return "", "", err
+	}
+
+	return workflow.Finished, "", nil
+}
+
+// groupsReleased tells whether a DB instance no longer uses the groups named
+// in the workflow data, once done with its modifications.
+func groupsReleased(dbInstanceDetails awsrds.DBInstanceDetails, data map[string]string) bool {
+	if dbInstanceDetails.Status != "available" || dbInstanceDetails.PendingModifications {
+		return false
+	}
+	if name := data[dbParameterGroupData]; name != "" && dbInstanceDetails.DBParameterGroupName == name {
+		return false
+	}
+	if name := data[optionGroupData]; name != "" && dbInstanceDetails.OptionGroupName == name {
+		return false
+	}
+	return true
+}
+
+// deleteOwnGroups deletes the groups named in the workflow data, if they still
+// exist.
+func (b *RDSBroker) deleteOwnGroups(instanceID string, data map[string]string) error {
+	if name := data[dbParameterGroupData]; name != "" {
+		b.logger.Info("delete-db-parameter-group", lager.Data{instanceIDLogKey: instanceID, "name": name})
+
+		err := b.dbInstance.DeleteParameterGroup(name)
+		if err != nil && err != awsrds.ErrDBParameterGroupDoesNotExist {
+			return err
+		}
+	}
+
+	if name := data[optionGroupData]; name != "" {
+		b.logger.Info("delete-option-group", lager.Data{instanceIDLogKey: instanceID, "name": name})
+
+		err := b.dbInstance.DeleteOptionGroup(name)
+		if err != nil && err != awsrds.ErrOptionGroupDoesNotExist {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteProvisionedGroups deletes the groups of its own created for a DB
+// instance which could not be created. Errors are only logged, so that the
+// error of the provision is the one returned.
+func (b *RDSBroker) deleteProvisionedGroups(instanceID string, servicePlan ServicePlan) {
+	data := b.leftGroups(instanceID, ServicePlan{}, servicePlan)
+	if err := b.deleteOwnGroups(instanceID, data); err != nil {
+		b.logger.Error("delete-provisioned-groups", err, lager.Data{instanceIDLogKey: instanceID})
+	}
+}
@@ -0,0 +1,214 @@
+package rdsbroker
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alphagov/paas-rds-broker/awsrds"
+	"github.com/alphagov/paas-rds-broker/secretstore"
+	"github.com/alphagov/paas-rds-broker/sqlengine"
+	"github.com/alphagov/paas-rds-broker/utils"
+)
+
+// masterPasswordVersionTag records how the master password of a DB instance
+// is derived from the seed. DB instances without it use the first version.
+const masterPasswordVersionTag = "Master password version"
+
+// masterPasswordVersions derive master passwords from the seed and the
+// instance ID, the latest one being used by new DB instances. Versions must
+// never be changed or removed, as existing DB instances depend on them.
+var masterPasswordVersions = []func(seed, instanceID string) string{
+	func(seed, instanceID string) string {
+		return utils.GetMD5B64(seed+instanceID, masterPasswordLength)
+	},
+	func(seed, instanceID string) string {
+		return utils.GetHMACSHA256B64(seed, instanceID, masterPasswordLength)
+	},
+}
+
+var latestMasterPasswordVersion = strconv.Itoa(len(masterPasswordVersions))
+
+// masterPasswordCandidate is a master password a DB instance may have, with
+// the version and seed it is derived with, or no version when it is kept in
+// the secret store.
+type masterPasswordCandidate struct {
+	version      string
+	previousSeed bool
+	password     string
+}
+
+// setNewMasterPassword sets the master password of a new DB instance. With a
+// secret store, it is random and kept in the store, tagged with the instance,
+// and the stored one is reused when a provision is retried. Otherwise it is
+// derived from the seed with the latest version.
+func (b *RDSBroker) setNewMasterPassword(instanceID string, dbInstanceDetails *awsrds.DBInstanceDetails, tags map[string]string) error {
+	if b.secretStore == nil {
+		dbInstanceDetails.MasterUserPassword = b.seededMasterPassword(b.masterPasswordSeed, instanceID, latestMasterPasswordVersion)
+		dbInstanceDetails.Tags[masterPasswordVersionTag] = latestMasterPasswordVersion
+		return nil
+	}
+
+	secretTags := map[string]string{instanceIDTag: instanceID}
+	for key, value := range tags {
+		secretTags[key] = value
+	}
+
+	name := b.dbInstanceIdentifier(instanceID)
+	masterPassword := utils.RandomAlphaNum(masterPasswordLength)
+	err := b.secretStore.Create(name, masterPassword, secretTags)
+	if err == secretstore.ErrSecretAlreadyExists {
+		masterPassword, err = b.secretStore.Get(name)
+	}
+	if err != nil {
+		return err
+	}
+	dbInstanceDetails.MasterUserPassword = masterPassword
+
+	return nil
+}
+
+// openAsMaster connects to the database of a DB instance with its master
+// credentials. While the seed is being rotated, the password derived from the
+// previous seed is tried when login fails.
+func (b *RDSBroker) openAsMaster(sqlEngine sqlengine.SQLEngine, instanceID string, address string, port int64, dbName string, username string) error {
+	masterPasswords, err := b.masterPasswords(instanceID)
+	if err != nil {
+		return err
+	}
+
+	for _, masterPassword := range masterPasswords {
+		err = sqlEngine.Open(address, port, dbName, username, masterPassword)
+		if err != sqlengine.LoginFailedError {
+			return err
+		}
+	}
+
+	return err
+}
+
+// masterPasswords returns the master password of a DB instance, kept in the
+// secret store or else derived from the seed with the version of the instance,
+// followed by the one derived from the previous seed, if any.
+func (b *RDSBroker) masterPasswords(instanceID string) ([]string, error) {
+	storedPassword, stored, err := b.storedMasterPassword(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if stored {
+		return []string{storedPassword}, nil
+	}
+
+	version, err := b.dbInstance.GetTag(b.dbInstanceIdentifier(instanceID), masterPasswordVersionTag)
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		version = "1"
+	}
+	if !validMasterPasswordVersion(version) {
+		return nil, fmt.Errorf("Unknown master password version '%s' of DB Instance '%s'", version, b.dbInstanceIdentifier(instanceID))
+	}
+
+	masterPasswords := []string{b.seededMasterPassword(b.masterPasswordSeed, instanceID, version)}
+	if b.previousMasterPasswordSeed != "" {
+		masterPasswords = append(masterPasswords, b.seededMasterPassword(b.previousMasterPasswordSeed, instanceID, version))
+	}
+
+	return masterPasswords, nil
+}
+
+// storedMasterPassword returns the master password of a DB instance kept in
+// the secret store, if any. The DB instances created before the secret store
+// was configured have no secret, and keep the password derived from the seed.
+func (b *RDSBroker) storedMasterPassword(instanceID string) (string, bool, error) {
+	if b.secretStore == nil {
+		return "", false, nil
+	}
+
+	masterPassword, err := b.secretStore.Get(b.dbInstanceIdentifier(instanceID))
+	if err == secretstore.ErrSecretDoesNotExist && b.masterPasswordSeed != "" {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return masterPassword, true, nil
+}
+
+// masterPasswordCandidates lists the master passwords a DB instance may have,
+// in the order they should be tried, and the one it should have: the stored
+// one, or the one derived from the seed with the latest version. The version
+// of the DB instance comes first, then the other versions from the latest,
+// then the same versions derived from the previous seed.
+func (b *RDSBroker) masterPasswordCandidates(instanceID string, tags map[string]string) ([]masterPasswordCandidate, masterPasswordCandidate, error) {
+	storedPassword, stored, err := b.storedMasterPassword(instanceID)
+	if err != nil {
+		return nil, masterPasswordCandidate{}, err
+	}
+	if stored {
+		candidate := masterPasswordCandidate{password: storedPassword}
+		return []masterPasswordCandidate{candidate}, candidate, nil
+	}
+
+	version := tags[masterPasswordVersionTag]
+	if !validMasterPasswordVersion(version) {
+		version = "1"
+	}
+
+	versions := []string{version}
+	for i := len(masterPasswordVersions); i >= 1; i-- {
+		if otherVersion := strconv.Itoa(i); otherVersion != version {
+			versions = append(versions, otherVersion)
+		}
+	}
+
+	candidates := []masterPasswordCandidate{}
+	for _, candidateVersion := range versions {
+		candidates = append(candidates, masterPasswordCandidate{
+			version:  candidateVersion,
+			password: b.seededMasterPassword(b.masterPasswordSeed, instanceID, candidateVersion),
+		})
+	}
+	if b.previousMasterPasswordSeed != "" {
+		for _, candidateVersion := range versions {
+			candidates = append(candidates, masterPasswordCandidate{
+				version:      candidateVersion,
+				previousSeed: true,
+				password:     b.seededMasterPassword(b.previousMasterPasswordSeed, instanceID, candidateVersion),
+			})
+		}
+	}
+
+	target := masterPasswordCandidate{
+		version:  latestMasterPasswordVersion,
+		password: b.seededMasterPassword(b.masterPasswordSeed, instanceID, latestMasterPasswordVersion),
+	}
+
+	return candidates, target, nil
+}
+
+func (b *RDSBroker) seededMasterPassword(seed string, instanceID string, version string) string {
+	index, _ := strconv.Atoi(version)
+	return masterPasswordVersions[index-1](seed, instanceID)
+}
+
+func validMasterPasswordVersion(version string) bool {
+	index, err := strconv.Atoi(version)
+	return err == nil && index >= 1 && index <= len(masterPasswordVersions)
+}
+
+// deleteMasterPassword removes the master password of a deleted DB instance
+// from the secret store, if any.
+func (b *RDSBroker) deleteMasterPassword(instanceID string) error {
+	if b.secretStore == nil {
+		return nil
+	}
+
+	err := b.secretStore.Delete(b.dbInstanceIdentifier(instanceID))
+	if err == secretstore.ErrSecretDoesNotExist {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,141 @@
+package rdsbroker
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/awsrds"
+)
+
+// hasOwnOptionGroup tells whether the DB instances of a plan get an option
+// group of their own, in which users can enable options.
+func hasOwnOptionGroup(servicePlan ServicePlan) bool {
+	return len(servicePlan.RDSProperties.UserOptions) > 0
+}
+
+// optionGroupName returns the option group the DB instance should use on the
+// plan.
+func (b *RDSBroker) optionGroupName(instanceID string, servicePlan ServicePlan) string {
+	if hasOwnOptionGroup(servicePlan) {
+		return b.dbInstanceIdentifier(instanceID)
+	}
+	return servicePlan.RDSProperties.OptionGroupName
+}
+
+// createOptionGroup copies the option group of the plan for a new DB instance,
+// or creates an empty one if the plan has none, with the options enabled by
+// the user.
+func (b *RDSBroker) createOptionGroup(instanceID string, servicePlan ServicePlan, options map[string]map[string]interface{}, tags map[string]string) error {
+	name := b.optionGroupName(instanceID, servicePlan)
+	source := servicePlan.RDSProperties.OptionGroupName
+	engine := servicePlan.RDSProperties.Engine
+	majorVersion := majorEngineVersion(servicePlan.RDSProperties.EngineVersion)
+
+	b.logger.Info("create-option-group", lager.Data{instanceIDLogKey: instanceID, "source": source, "engine": engine, "major-version": majorVersion})
+
+	var err error
+	if source != "" {
+		err = b.dbInstance.CopyOptionGroup(source, name, tags)
+	} else {
+		err = b.dbInstance.CreateOptionGroup(name, engine, majorVersion, tags)
+	}
+	if err != nil && err != awsrds.ErrOptionGroupAlreadyExists {
+		return err
+	}
+
+	return b.modifyOptionGroup(name, servicePlan, options, false)
+}
+
+// validateOptionGroupUpdate checks that the option group of the DB instance,
+// if it has its own, can be kept on the new plan.
+func (b *RDSBroker) validateOptionGroupUpdate(instanceID string, servicePlan, previousServicePlan ServicePlan) error {
+	if !hasOwnOptionGroup(servicePlan) || !hasOwnOptionGroup(previousServicePlan) {
+		return nil
+	}
+
+	engine := servicePlan.RDSProperties.Engine
+	majorVersion := majorEngineVersion(servicePlan.RDSProperties.EngineVersion)
+	previousEngine := previousServicePlan.RDSProperties.Engine
+	previousMajorVersion := majorEngineVersion(previousServicePlan.RDSProperties.EngineVersion)
+	if engine != previousEngine || majorVersion != previousMajorVersion {
+		err := fmt.Errorf("DB Instance '%s' has its own option group, for %s %s, which cannot be used with %s %s", b.dbInstanceIdentifier(instanceID), previousEngine, previousMajorVersion, engine, majorVersion)
+		return NewFailureResponse(err, statusUnprocessableEntity, "option-group-not-updateable")
+	}
+
+	source := servicePlan.RDSProperties.OptionGroupName
+	previousSource := previousServicePlan.RDSProperties.OptionGroupName
+	if source != previousSource {
+		err := fmt.Errorf("DB Instance '%s' has its own option group, copied from '%s', which cannot be replaced by a copy of '%s'", b.dbInstanceIdentifier(instanceID), previousSource, source)
+		return NewFailureResponse(err, statusUnprocessableEntity, "option-group-not-updateable")
+	}
+
+	return nil
+}
+
+// updateOptionGroup gives the DB instance its own option group if it moves to
+// a plan with user options, and enables the options given by the user,
+// disabling the other ones. The update must have been validated by
+// validateOptionGroupUpdate.
+func (b *RDSBroker) updateOptionGroup(instanceID string, servicePlan, previousServicePlan ServicePlan, options map[string]map[string]interface{}, tags map[string]string) error {
+	if !hasOwnOptionGroup(servicePlan) {
+		return nil
+	}
+
+	if !hasOwnOptionGroup(previousServicePlan) {
+		return b.createOptionGroup(instanceID, servicePlan, options, tags)
+	}
+
+	if options == nil {
+		return nil
+	}
+
+	return b.modifyOptionGroup(b.optionGroupName(instanceID, servicePlan), servicePlan, options, true)
+}
+
+// modifyOptionGroup enables the given options, with the port and VPC security
+// groups set by the plan. The other options allowed by the plan are disabled
+// if removeOthers is set.
+func (b *RDSBroker) modifyOptionGroup(name string, servicePlan ServicePlan, options map[string]map[string]interface{}, removeOthers bool) error {
+	optionNames := make([]string, 0, len(options))
+	for optionName := range options {
+		optionNames = append(optionNames, optionName)
+	}
+	sort.Strings(optionNames)
+
+	optionsToInclude := []awsrds.DBOption{}
+	for _, optionName := range optionNames {
+		constraints := servicePlan.RDSProperties.UserOptions[optionName]
+		option := awsrds.DBOption{
+			Name:                optionName,
+			Settings:            map[string]string{},
+			Port:                constraints.Port,
+			VpcSecurityGroupIds: constraints.VpcSecurityGroupIDs,
+		}
+		for settingName, value := range options[optionName] {
+			option.Settings[settingName] = formatParameterValue(value)
+		}
+		optionsToInclude = append(optionsToInclude, option)
+	}
+
+	optionsToRemove := []string{}
+	if removeOthers {
+		for optionName := range servicePlan.RDSProperties.UserOptions {
+			if _, ok := options[optionName]; !ok {
+				optionsToRemove = append(optionsToRemove, optionName)
+			}
+		}
+		sort.Strings(optionsToRemove)
+	}
+
+	if len(optionsToInclude) == 0 && len(optionsToRemove) == 0 {
+		return nil
+	}
+
+	if err := b.dbInstance.ModifyOptionGroup(name, optionsToInclude, optionsToRemove); err != nil {
+		return fmt.Errorf("Could not set the options of option group '%s': %s", name, err)
+	}
+
+	return nil
+}
@@ -2,28 +2,43 @@ package rdsbroker
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
 )
 
 type ProvisionParameters struct {
-	BackupRetentionPeriod      int64
-	CharacterSetName           string
-	DBName                     string
-	PreferredBackupWindow      string
-	PreferredMaintenanceWindow string
-	SkipFinalSnapshot          string `mapstructure:"skip_final_snapshot"`
+	BackupRetentionPeriod      int64                             `mapstructure:"backup_retention_period" description:"The number of days that Amazon RDS should retain automatic backups of the DB instance"`
+	CharacterSetName           string                            `mapstructure:"character_set_name" description:"For supported engines, the CharacterSet the DB instance should be associated with"`
+	DBName                     string                            `mapstructure:"db_name" description:"The name of the database to be provisioned"`
+	DBParameters               map[string]interface{}            `mapstructure:"db_parameters" description:"Values of the DB parameters allowed by the plan, set in the DB parameter group of the instance"`
+	Extensions                 []string                          `mapstructure:"extensions" description:"The PostgreSQL extensions to enable in the database, among the ones allowed by the plan"`
+	Options                    map[string]map[string]interface{} `mapstructure:"options" description:"The options to enable in the option group of the instance, among the ones allowed by the plan, with their settings"`
+	PreferredBackupWindow      string                            `mapstructure:"preferred_backup_window" description:"The daily time range (hh24:mi-hh24:mi, UTC) during which automated backups are created"`
+	PreferredMaintenanceWindow string                            `mapstructure:"preferred_maintenance_window" description:"The weekly time range (ddd:hh24:mi-ddd:hh24:mi, UTC) during which system maintenance can occur"`
+	SkipFinalSnapshot          string                            `mapstructure:"skip_final_snapshot" description:"Whether to skip the final DB snapshot when the DB instance is deleted"`
 }
 
 type UpdateParameters struct {
-	ApplyImmediately           bool
-	BackupRetentionPeriod      int64
-	PreferredBackupWindow      string
-	PreferredMaintenanceWindow string
-	SkipFinalSnapshot          string `mapstructure:"skip_final_snapshot"`
+	ApplyImmediately           bool                              `mapstructure:"apply_immediately" description:"Apply the modifications as soon as possible instead of during the next maintenance window"`
+	BackupRetentionPeriod      int64                             `mapstructure:"backup_retention_period" description:"The number of days that Amazon RDS should retain automatic backups of the DB instance"`
+	ConfirmEncryptionChange    bool                              `mapstructure:"confirm_encryption_change" description:"Confirm the move to a plan with different encryption settings. The writes made after the DB snapshot of the change is taken are lost, and the DB instance is unavailable while it is swapped with the encrypted one"`
+	DBParameters               map[string]interface{}            `mapstructure:"db_parameters" description:"Values of the DB parameters allowed by the plan, set in the DB parameter group of the instance. Static parameters only apply once the instance is rebooted"`
+	Extensions                 []string                          `mapstructure:"extensions" description:"The PostgreSQL extensions enabled in the database, among the ones allowed by the plan. Allowed extensions not listed are dropped"`
+	Options                    map[string]map[string]interface{} `mapstructure:"options" description:"The options enabled in the option group of the instance, among the ones allowed by the plan, with their settings. Allowed options not listed are disabled"`
+	PreferredBackupWindow      string                            `mapstructure:"preferred_backup_window" description:"The daily time range (hh24:mi-hh24:mi, UTC) during which automated backups are created"`
+	PreferredMaintenanceWindow string                            `mapstructure:"preferred_maintenance_window" description:"The weekly time range (ddd:hh24:mi-ddd:hh24:mi, UTC) during which system maintenance can occur"`
+	SkipFinalSnapshot          string                            `mapstructure:"skip_final_snapshot" description:"Whether to skip the final DB snapshot when the DB instance is deleted"`
+	TakeSnapshot               bool                              `mapstructure:"take_snapshot" description:"Take a manual DB snapshot of the instance instead of modifying it. The oldest manual snapshots are deleted past the limit of the plan"`
 }
 
 type BindParameters struct {
-	// This is currently empty, but preserved to make it easier to add
-	// bind-time parameters in future.
+	ConnectionLimit                 int64 `mapstructure:"connection_limit" description:"The maximum number of connections the user of the binding may open at once"`
+	ExpiresIn                       int64 `mapstructure:"expires_in" description:"The number of seconds after which the credentials of the binding expire. They never expire when not set"`
+	IdleInTransactionSessionTimeout int64 `mapstructure:"idle_in_transaction_session_timeout" description:"The number of milliseconds after which the sessions of the binding idle in a transaction are terminated"`
+	StatementTimeout                int64 `mapstructure:"statement_timeout" description:"The number of milliseconds after which the statements of the binding are aborted"`
 }
 
 func Validate_SkipFinalSnapshot(SkipFinalSnapshot string) error {
@@ -35,9 +50,79 @@ func Validate_SkipFinalSnapshot(SkipFinalSnapshot string) error {
 }
 
 func (pp *ProvisionParameters) Validate() error {
-	return Validate_SkipFinalSnapshot(pp.SkipFinalSnapshot)
+	if err := Validate_SkipFinalSnapshot(pp.SkipFinalSnapshot); err != nil {
+		return err
+	}
+	return ValidateWindows(pp.PreferredBackupWindow, pp.PreferredMaintenanceWindow)
 }
 
 func (pp *UpdateParameters) Validate() error {
-	return Validate_SkipFinalSnapshot(pp.SkipFinalSnapshot)
-}
\ No newline at end of file
+	if err := Validate_SkipFinalSnapshot(pp.SkipFinalSnapshot); err != nil {
+		return err
+	}
+	return ValidateWindows(pp.PreferredBackupWindow, pp.PreferredMaintenanceWindow)
+}
+
+// AcceptedParameters returns the snake_case names of the parameters that can
+// be set on one of the parameters structs, in alphabetical order.
+func AcceptedParameters(parameters interface{}) []string {
+	names := []string{}
+	parametersType := reflect.TypeOf(parameters)
+	for i := 0; i < parametersType.NumField(); i++ {
+		field := parametersType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		names = append(names, parameterName(field))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NormalizeParameters renames the user parameters still using the deprecated
+// casing (e.g. "BackupRetentionPeriod") to their snake_case name. It returns
+// the renamed parameters along with the deprecated names that were found.
+// Unknown names are kept as they are.
+func NormalizeParameters(parameters interface{}, userParameters map[string]interface{}) (map[string]interface{}, []string, error) {
+	normalized := make(map[string]interface{}, len(userParameters))
+	deprecated := []string{}
+
+	names := make([]string, 0, len(userParameters))
+	for name := range userParameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, ok := findParameterField(parameters, name)
+		if !ok || parameterName(field) == name {
+			normalized[name] = userParameters[name]
+			continue
+		}
+
+		canonicalName := parameterName(field)
+		_, given := userParameters[canonicalName]
+		_, renamed := normalized[canonicalName]
+		if given || renamed {
+			return nil, nil, fmt.Errorf("%s and %s refer to the same parameter", name, canonicalName)
+		}
+		normalized[canonicalName] = userParameters[name]
+		deprecated = append(deprecated, name)
+	}
+
+	return normalized, deprecated, nil
+}
+
+// DecodeParameters decodes user parameters into one of the parameters
+// structs, failing on any parameter that does not map to one of its fields.
+func DecodeParameters(userParameters map[string]interface{}, parameters interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      parameters,
+	})
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(userParameters)
+}
@@ -0,0 +1,104 @@
+package rdsbroker_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/alphagov/paas-rds-broker/rdsbroker"
+)
+
+var _ = Describe("Parameters", func() {
+	Describe("AcceptedParameters", func() {
+		It("returns the snake_case parameter names", func() {
+			Expect(AcceptedParameters(UpdateParameters{})).To(Equal([]string{
+				"apply_immediately",
+				"backup_retention_period",
+				"confirm_encryption_change",
+				"db_parameters",
+				"extensions",
+				"options",
+				"preferred_backup_window",
+				"preferred_maintenance_window",
+				"skip_final_snapshot",
+				"take_snapshot",
+			}))
+		})
+
+		It("returns the names of the bind parameters", func() {
+			Expect(AcceptedParameters(BindParameters{})).To(Equal([]string{
+				"connection_limit",
+				"expires_in",
+				"idle_in_transaction_session_timeout",
+				"statement_timeout",
+			}))
+		})
+	})
+
+	Describe("NormalizeParameters", func() {
+		It("keeps snake_case parameters as they are", func() {
+			parameters, deprecated, err := NormalizeParameters(ProvisionParameters{}, map[string]interface{}{
+				"backup_retention_period": 7,
+				"db_name":                 "mydb",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deprecated).To(BeEmpty())
+			Expect(parameters).To(Equal(map[string]interface{}{
+				"backup_retention_period": 7,
+				"db_name":                 "mydb",
+			}))
+		})
+
+		It("renames parameters using the deprecated casing", func() {
+			parameters, deprecated, err := NormalizeParameters(ProvisionParameters{}, map[string]interface{}{
+				"BackupRetentionPeriod": 7,
+				"dbname":                "mydb",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deprecated).To(Equal([]string{"BackupRetentionPeriod", "dbname"}))
+			Expect(parameters).To(Equal(map[string]interface{}{
+				"backup_retention_period": 7,
+				"db_name":                 "mydb",
+			}))
+		})
+
+		It("keeps unknown parameters", func() {
+			parameters, deprecated, err := NormalizeParameters(ProvisionParameters{}, map[string]interface{}{"unknown": true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deprecated).To(BeEmpty())
+			Expect(parameters).To(HaveKeyWithValue("unknown", true))
+		})
+
+		It("fails when a parameter is given twice", func() {
+			_, _, err := NormalizeParameters(ProvisionParameters{}, map[string]interface{}{
+				"BackupRetentionPeriod":   7,
+				"backup_retention_period": 7,
+			})
+			Expect(err).To(MatchError("BackupRetentionPeriod and backup_retention_period refer to the same parameter"))
+		})
+	})
+
+	Describe("DecodeParameters", func() {
+		It("decodes snake_case parameters", func() {
+			parameters := UpdateParameters{}
+			err := DecodeParameters(map[string]interface{}{
+				"apply_immediately":       true,
+				"backup_retention_period": float64(7),
+			}, &parameters)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parameters.ApplyImmediately).To(BeTrue())
+			Expect(parameters.BackupRetentionPeriod).To(Equal(int64(7)))
+		})
+
+		It("fails on unknown parameters", func() {
+			parameters := UpdateParameters{}
+			err := DecodeParameters(map[string]interface{}{"unknown": true}, &parameters)
+			Expect(err).To(MatchError(ContainSubstring("invalid keys: unknown")))
+		})
+
+		It("fails on type mismatches", func() {
+			parameters := UpdateParameters{}
+			err := DecodeParameters(map[string]interface{}{"apply_immediately": "yes"}, &parameters)
+			Expect(err).To(MatchError(ContainSubstring("'apply_immediately' expected type 'bool'")))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package rdsbroker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/frodenas/brokerapi"
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/workflow"
+)
+
+const postProvisionWorkflow = "post-provision"
+
+// The steps of the post-provision workflow are kept apart so that a retry does
+// not run again what has already succeeded.
+const (
+	postProvisionSQLStep        = "sql"
+	postProvisionExtensionsStep = "extensions"
+	postProvisionTagStep        = "tag"
+)
+
+// startPostProvision starts the workflow running the post-provision SQL of the
+// plan and enabling the extensions requested by the user once the new DB
+// instance is available.
+func (b *RDSBroker) startPostProvision(instanceID string, servicePlan ServicePlan, extensions []string) error {
+	description := fmt.Sprintf("Creating DB Instance '%s'", b.dbInstanceIdentifier(instanceID))
+	data := map[string]string{planIDData: servicePlan.ID}
+	if len(extensions) > 0 {
+		data[extensionsData] = strings.Join(normalizeExtensions(extensions), " ")
+	}
+
+	return b.workflows.Start(instanceID, postProvisionWorkflow, postProvisionSQLStep, description, data)
+}
+
+func (b *RDSBroker) runPostProvisionSQL(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+
+	servicePlan, ok := b.catalog.FindServicePlan(w.Data[planIDData])
+	if !ok {
+		return "", "", workflow.Failf("Service Plan '%s' not found", w.Data[planIDData])
+	}
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, ok := rdsStatus2State[dbInstanceDetails.Status]
+	if !ok {
+		return "", "", workflow.Failf("DB Instance '%s' status is '%s'", dbInstanceIdentifier, dbInstanceDetails.Status)
+	}
+	if state == brokerapi.LastOperationInProgress {
+		return w.Step, fmt.Sprintf("DB Instance '%s' status is '%s'", dbInstanceIdentifier, dbInstanceDetails.Status), nil
+	}
+
+	next := workflow.Finished
+	if w.Data[extensionsData] != "" {
+		next = postProvisionExtensionsStep
+	}
+
+	statements := servicePlan.RDSProperties.PostProvisionSQL
+	if len(statements) == 0 {
+		return next, "", nil
+	}
+
+	sqlEngine, err := b.openMasterSQLEngine(w.InstanceID, servicePlan, dbInstanceDetails)
+	if err != nil {
+		return "", "", fmt.Errorf("Could not connect to DB Instance '%s' to run the post-provision SQL: %s", dbInstanceIdentifier, err)
+	}
+	defer sqlEngine.Close()
+
+	b.logger.Info("run-post-provision-sql", lager.Data{instanceIDLogKey: w.InstanceID, "statements": len(statements)})
+
+	if err := sqlEngine.ExecuteStatements(statements); err != nil {
+		return "", "", fmt.Errorf("Could not run the post-provision SQL on DB Instance '%s': %s", dbInstanceIdentifier, err)
+	}
+
+	return next, "", nil
+}
+
+func (b *RDSBroker) createPostProvisionExtensions(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+
+	servicePlan, ok := b.catalog.FindServicePlan(w.Data[planIDData])
+	if !ok {
+		return "", "", workflow.Failf("Service Plan '%s' not found", w.Data[planIDData])
+	}
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	sqlEngine, err := b.openMasterSQLEngine(w.InstanceID, servicePlan, dbInstanceDetails)
+	if err != nil {
+		return "", "", fmt.Errorf("Could not connect to DB Instance '%s' to create extensions: %s", dbInstanceIdentifier, err)
+	}
+	defer sqlEngine.Close()
+
+	extensions := strings.Fields(w.Data[extensionsData])
+
+	b.logger.Info("create-extensions", lager.Data{instanceIDLogKey: w.InstanceID, "extensions": extensions})
+
+	if err := sqlEngine.CreateExtensions(extensions); err != nil {
+		return "", "", fmt.Errorf("Could not create extensions on DB Instance '%s': %s", dbInstanceIdentifier, err)
+	}
+
+	return postProvisionTagStep, "", nil
+}
+
+func (b *RDSBroker) tagPostProvisionExtensions(w *workflow.Workflow) (string, string, error) {
+	if err := b.tagExtensions(b.dbInstanceIdentifier(w.InstanceID), strings.Fields(w.Data[extensionsData])); err != nil {
+		return "", "", err
+	}
+
+	return workflow.Finished, "", nil
+}
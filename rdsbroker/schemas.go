@@ -0,0 +1,532 @@
+package rdsbroker
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const jsonSchemaVersion = "http://json-schema.org/draft-04/schema#"
+
+const minBackupRetentionPeriod = 0
+const maxBackupRetentionPeriod = 35
+
+var dbNameMaxLength = map[string]int{
+	"mariadb":  64,
+	"mysql":    64,
+	"postgres": 63,
+}
+
+// ServiceSchemas are the JSON schemas of the parameters of a plan.
+type ServiceSchemas struct {
+	Instance ServiceInstanceSchema `json:"service_instance"`
+	Binding  ServiceBindingSchema  `json:"service_binding"`
+}
+
+type ServiceInstanceSchema struct {
+	Create *Schema `json:"create,omitempty"`
+	Update *Schema `json:"update,omitempty"`
+}
+
+type ServiceBindingSchema struct {
+	Create *Schema `json:"create,omitempty"`
+}
+
+type Schema struct {
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
+type ParametersError struct {
+	Errors   []string
+	Accepted []string
+}
+
+func (e ParametersError) Error() string {
+	message := "Invalid parameters: " + strings.Join(e.Errors, "; ")
+	if e.Accepted != nil {
+		accepted := "none"
+		if len(e.Accepted) > 0 {
+			accepted = strings.Join(e.Accepted, ", ")
+		}
+		message += ". Accepted parameters: " + accepted
+	}
+	return message
+}
+
+func (b *RDSBroker) planSchemas(servicePlan ServicePlan) *ServiceSchemas {
+	schemas := &ServiceSchemas{}
+
+	if b.userParametersAllowed(ProvisionParameters{}, servicePlan) {
+		schemas.Instance.Create = &Schema{
+			Parameters: ParametersSchema(ProvisionParameters{}, servicePlan),
+		}
+	}
+
+	if b.userParametersAllowed(UpdateParameters{}, servicePlan) {
+		schemas.Instance.Update = &Schema{
+			Parameters: ParametersSchema(UpdateParameters{}, servicePlan),
+		}
+	}
+
+	if b.userParametersAllowed(BindParameters{}, servicePlan) {
+		schemas.Binding.Create = &Schema{
+			Parameters: ParametersSchema(BindParameters{}, servicePlan),
+		}
+	}
+
+	if schemas.Instance.Create == nil && schemas.Instance.Update == nil && schemas.Binding.Create == nil {
+		return nil
+	}
+
+	return schemas
+}
+
+// userParametersAllowed tells whether users may send parameters of the given
+// kind to the plan. Plans with their own allow-list for that kind take
+// precedence over the broker wide setting.
+func (b *RDSBroker) userParametersAllowed(parameters interface{}, servicePlan ServicePlan) bool {
+	if _, ok := servicePlan.UserParameters.constraintsFor(parameters); ok {
+		return true
+	}
+
+	switch parameters.(type) {
+	case ProvisionParameters:
+		return b.allowUserProvisionParameters
+	case UpdateParameters:
+		return b.allowUserUpdateParameters
+	case BindParameters:
+		return b.allowUserBindParameters
+	}
+
+	return false
+}
+
+// ParametersSchema builds the JSON schema advertised in the catalog for one
+// of the parameters structs, narrowed down by the limits of the given plan.
+// If the plan has an allow-list for this kind of parameters, only those
+// parameters are described. Parameters not described are always rejected.
+func ParametersSchema(parameters interface{}, servicePlan ServicePlan) map[string]interface{} {
+	properties := map[string]interface{}{}
+	limits := planParameterLimits(servicePlan)
+	constraints, restricted := servicePlan.UserParameters.constraintsFor(parameters)
+
+	parametersType := reflect.TypeOf(parameters)
+	for i := 0; i < parametersType.NumField(); i++ {
+		field := parametersType.Field(i)
+		if field.PkgPath != "" || !planSupportsParameter(servicePlan, field) {
+			continue
+		}
+
+		property := map[string]interface{}{
+			"type": jsonSchemaType(field.Type),
+		}
+
+		if description := field.Tag.Get("description"); description != "" {
+			property["description"] = description
+		}
+
+		for key, value := range limits[field.Name] {
+			property[key] = value
+		}
+
+		if restricted {
+			constraint, ok := findParameterConstraints(constraints, field)
+			if !ok {
+				continue
+			}
+			constraint.applyTo(property)
+		}
+
+		properties[parameterName(field)] = property
+	}
+
+	return map[string]interface{}{
+		"$schema":              jsonSchemaVersion,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func (up *UserParameters) constraintsFor(parameters interface{}) (map[string]ParameterConstraints, bool) {
+	if up == nil {
+		return nil, false
+	}
+
+	var constraints map[string]ParameterConstraints
+	switch parameters.(type) {
+	case ProvisionParameters:
+		constraints = up.Provision
+	case UpdateParameters:
+		constraints = up.Update
+	case BindParameters:
+		constraints = up.Bind
+	}
+
+	return constraints, constraints != nil
+}
+
+// applyTo narrows down a property by the constraints of a plan. They can only
+// make the limits the property already has stricter, never loosen them.
+func (pc ParameterConstraints) applyTo(property map[string]interface{}) {
+	if pc.Minimum != nil {
+		if minimum, ok := toFloat(property["minimum"]); !ok || float64(*pc.Minimum) > minimum {
+			property["minimum"] = *pc.Minimum
+		}
+	}
+
+	if pc.Maximum != nil {
+		if maximum, ok := toFloat(property["maximum"]); !ok || float64(*pc.Maximum) < maximum {
+			property["maximum"] = *pc.Maximum
+		}
+	}
+
+	if len(pc.Enum) > 0 {
+		enum := pc.Enum
+		if existing, ok := property["enum"].([]string); ok {
+			enum = []string{}
+			for _, value := range pc.Enum {
+				if containsString(existing, value) {
+					enum = append(enum, value)
+				}
+			}
+		}
+		property["enum"] = enum
+	}
+
+	if pc.Pattern != "" {
+		if existing, ok := property["pattern"].(string); !ok || existing == pc.Pattern {
+			property["pattern"] = pc.Pattern
+		} else {
+			// Values must match both patterns
+			allOf, _ := property["allOf"].([]interface{})
+			property["allOf"] = append(allOf, map[string]interface{}{
+				"type":    property["type"],
+				"pattern": pc.Pattern,
+			})
+		}
+	}
+}
+
+// findParameterField looks up the field of a parameters struct by the name
+// used in the broker configuration. Names are matched regardless of case and
+// underscores, so that both "backup_retention_period" and
+// "BackupRetentionPeriod" refer to the same parameter.
+func findParameterField(parameters interface{}, name string) (reflect.StructField, bool) {
+	parametersType := reflect.TypeOf(parameters)
+	for i := 0; i < parametersType.NumField(); i++ {
+		field := parametersType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if normalizeParameterName(field.Name) == normalizeParameterName(name) ||
+			normalizeParameterName(parameterName(field)) == normalizeParameterName(name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func findParameterConstraints(constraints map[string]ParameterConstraints, field reflect.StructField) (ParameterConstraints, bool) {
+	for name, constraint := range constraints {
+		if normalizeParameterName(name) == normalizeParameterName(field.Name) ||
+			normalizeParameterName(name) == normalizeParameterName(parameterName(field)) {
+			return constraint, true
+		}
+	}
+	return ParameterConstraints{}, false
+}
+
+func normalizeParameterName(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+// planSupportsParameter tells whether a parameter applies to the plan at all.
+func planSupportsParameter(servicePlan ServicePlan, field reflect.StructField) bool {
+	switch field.Name {
+	case "Extensions":
+		return len(servicePlan.RDSProperties.AllowedExtensions) > 0
+	case "DBParameters":
+		return hasOwnDBParameterGroup(servicePlan)
+	case "Options":
+		return hasOwnOptionGroup(servicePlan)
+	case "ExpiresIn", "ConnectionLimit":
+		// IAM authentication tokens are short-lived already
+		return !servicePlan.RDSProperties.IAMDatabaseAuthentication
+	case "StatementTimeout", "IdleInTransactionSessionTimeout":
+		return !servicePlan.RDSProperties.IAMDatabaseAuthentication && strings.ToLower(servicePlan.RDSProperties.Engine) == "postgres"
+	}
+	return true
+}
+
+func planParameterLimits(servicePlan ServicePlan) map[string]map[string]interface{} {
+	limits := map[string]map[string]interface{}{
+		"BackupRetentionPeriod": {
+			"minimum": minBackupRetentionPeriod,
+			"maximum": maxBackupRetentionPeriod,
+		},
+		"DBName": {
+			"pattern": "^[A-Za-z][A-Za-z0-9_]*$",
+		},
+		"ConnectionLimit": {
+			"minimum": 1,
+		},
+		"ExpiresIn": {
+			"minimum": 1,
+		},
+		"IdleInTransactionSessionTimeout": {
+			"minimum": 1,
+		},
+		"StatementTimeout": {
+			"minimum": 1,
+		},
+		"Extensions": {
+			"items": map[string]interface{}{
+				"type": "string",
+				"enum": servicePlan.RDSProperties.AllowedExtensions,
+			},
+		},
+		"SkipFinalSnapshot": {
+			"enum": []string{"true", "false"},
+		},
+	}
+
+	dbParameters := map[string]interface{}{}
+	for name, constraint := range servicePlan.RDSProperties.UserDBParameters {
+		property := map[string]interface{}{"type": constraint.dbParameterType()}
+		constraint.applyTo(property)
+		dbParameters[name] = property
+	}
+	limits["DBParameters"] = map[string]interface{}{
+		"properties":           dbParameters,
+		"additionalProperties": false,
+	}
+
+	options := map[string]interface{}{}
+	for name, option := range servicePlan.RDSProperties.UserOptions {
+		settings := map[string]interface{}{}
+		for settingName, constraint := range option.Settings {
+			property := map[string]interface{}{"type": constraint.dbParameterType()}
+			constraint.applyTo(property)
+			settings[settingName] = property
+		}
+		options[name] = map[string]interface{}{
+			"type":                 "object",
+			"properties":           settings,
+			"additionalProperties": false,
+		}
+	}
+	limits["Options"] = map[string]interface{}{
+		"properties":           options,
+		"additionalProperties": false,
+	}
+
+	if maxLength, ok := dbNameMaxLength[strings.ToLower(servicePlan.RDSProperties.Engine)]; ok {
+		limits["DBName"]["maxLength"] = maxLength
+	}
+
+	return limits
+}
+
+func parameterName(field reflect.StructField) string {
+	if tag := field.Tag.Get("mapstructure"); tag != "" {
+		return strings.Split(tag, ",")[0]
+	}
+	return field.Name
+}
+
+func jsonSchemaType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return "string"
+}
+
+// ValidateParameters checks user provided parameters against a schema built
+// by ParametersSchema. Parameters not described by the schema are ignored,
+// unless the schema does not allow additional properties.
+func ValidateParameters(schema map[string]interface{}, parameters map[string]interface{}) error {
+	properties, _ := schema["properties"].(map[string]interface{})
+	additionalProperties, ok := schema["additionalProperties"].(bool)
+	if !ok {
+		additionalProperties = true
+	}
+
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	var accepted []string
+	for _, name := range names {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			if !additionalProperties {
+				errs = append(errs, fmt.Sprintf("%s is not allowed", name))
+				accepted = acceptedProperties(properties)
+			}
+			continue
+		}
+		if err := validateProperty(property, parameters[name]); err != nil {
+			errs = append(errs, fmt.Sprintf("%s %s", name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return ParametersError{Errors: errs, Accepted: accepted}
+	}
+
+	return nil
+}
+
+func acceptedProperties(properties map[string]interface{}) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateProperty(property map[string]interface{}, value interface{}) error {
+	if allOf, ok := property["allOf"].([]interface{}); ok {
+		for _, schema := range allOf {
+			if schema, ok := schema.(map[string]interface{}); ok {
+				if err := validateProperty(schema, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	switch property["type"] {
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("must be a boolean, got %s", describeValue(value))
+		}
+	case "integer":
+		number, ok := toFloat(value)
+		if !ok || number != math.Trunc(number) {
+			return fmt.Errorf("must be an integer, got %s", describeValue(value))
+		}
+		if minimum, ok := toFloat(property["minimum"]); ok && number < minimum {
+			return fmt.Errorf("must be greater than or equal to %v", property["minimum"])
+		}
+		if maximum, ok := toFloat(property["maximum"]); ok && number > maximum {
+			return fmt.Errorf("must be less than or equal to %v", property["maximum"])
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("must be an array, got %s", describeValue(value))
+		}
+		if itemProperty, ok := property["items"].(map[string]interface{}); ok {
+			for i, item := range items {
+				if err := validateProperty(itemProperty, item); err != nil {
+					return fmt.Errorf("item %d %s", i, err)
+				}
+			}
+		}
+	case "object":
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("must be an object, got %s", describeValue(value))
+		}
+		properties, _ := property["properties"].(map[string]interface{})
+		additionalProperties, ok := property["additionalProperties"].(bool)
+		if !ok {
+			additionalProperties = true
+		}
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			valueProperty, ok := properties[name].(map[string]interface{})
+			if !ok {
+				if !additionalProperties {
+					return fmt.Errorf("entry '%s' is not allowed", name)
+				}
+				continue
+			}
+			if err := validateProperty(valueProperty, values[name]); err != nil {
+				return fmt.Errorf("entry '%s' %s", name, err)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string, got %s", describeValue(value))
+		}
+		if maxLength, ok := property["maxLength"].(int); ok && len(str) > maxLength {
+			return fmt.Errorf("must be at most %d characters long", maxLength)
+		}
+		if pattern, ok := property["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(str) {
+			return fmt.Errorf("must match the pattern '%s'", pattern)
+		}
+		if enum, ok := property["enum"].([]string); ok && !containsString(enum, str) {
+			return fmt.Errorf("must be one of '%s'", strings.Join(enum, "', '"))
+		}
+	}
+
+	return nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func describeValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case string:
+		return "a string"
+	case float32, float64, int, int32, int64:
+		return "a number"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package rdsbroker_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/alphagov/paas-rds-broker/rdsbroker"
+)
+
+var _ = Describe("Parameters Schemas", func() {
+	var (
+		servicePlan ServicePlan
+	)
+
+	BeforeEach(func() {
+		servicePlan = ServicePlan{
+			ID: "Plan-1",
+			RDSProperties: RDSProperties{
+				Engine: "postgres",
+			},
+		}
+	})
+
+	Describe("ParametersSchema", func() {
+		It("describes every parameter with its JSON type", func() {
+			schema := ParametersSchema(ProvisionParameters{}, servicePlan)
+			Expect(schema["type"]).To(Equal("object"))
+			Expect(schema["additionalProperties"]).To(BeFalse())
+
+			properties := schema["properties"].(map[string]interface{})
+			Expect(properties).To(HaveLen(6))
+			Expect(properties["backup_retention_period"]).To(HaveKeyWithValue("type", "integer"))
+			Expect(properties["db_name"]).To(HaveKeyWithValue("type", "string"))
+			Expect(properties["skip_final_snapshot"]).To(HaveKeyWithValue("enum", []string{"true", "false"}))
+		})
+
+		It("includes the parameters descriptions", func() {
+			properties := ParametersSchema(UpdateParameters{}, servicePlan)["properties"].(map[string]interface{})
+			Expect(properties["apply_immediately"]).To(HaveKeyWithValue("type", "boolean"))
+			Expect(properties["apply_immediately"]).To(HaveKey("description"))
+		})
+
+		It("applies the limits of the plan engine", func() {
+			properties := ParametersSchema(ProvisionParameters{}, servicePlan)["properties"].(map[string]interface{})
+			Expect(properties["db_name"]).To(HaveKeyWithValue("maxLength", 63))
+
+			servicePlan.RDSProperties.Engine = "mysql"
+			properties = ParametersSchema(ProvisionParameters{}, servicePlan)["properties"].(map[string]interface{})
+			Expect(properties["db_name"]).To(HaveKeyWithValue("maxLength", 64))
+		})
+
+		It("only describes the extensions when the plan allows some", func() {
+			properties := ParametersSchema(UpdateParameters{}, servicePlan)["properties"].(map[string]interface{})
+			Expect(properties).ToNot(HaveKey("extensions"))
+
+			servicePlan.RDSProperties.AllowedExtensions = []string{"postgis", "pg_trgm"}
+			properties = ParametersSchema(UpdateParameters{}, servicePlan)["properties"].(map[string]interface{})
+			Expect(properties["extensions"]).To(HaveKeyWithValue("type", "array"))
+			Expect(properties["extensions"]).To(HaveKeyWithValue("items", map[string]interface{}{
+				"type": "string",
+				"enum": []string{"postgis", "pg_trgm"},
+			}))
+		})
+	})
+
+	Context("when the plan has an allow-list of user parameters", func() {
+		BeforeEach(func() {
+			maximum := int64(7)
+			servicePlan.UserParameters = &UserParameters{
+				Provision: map[string]ParameterConstraints{
+					"backup_retention_period": ParameterConstraints{Maximum: &maximum},
+				},
+			}
+		})
+
+		It("only describes the allowed parameters with the plan bounds", func() {
+			schema := ParametersSchema(ProvisionParameters{}, servicePlan)
+			Expect(schema["additionalProperties"]).To(BeFalse())
+
+			properties := schema["properties"].(map[string]interface{})
+			Expect(properties).To(HaveLen(1))
+			Expect(properties["backup_retention_period"]).To(HaveKeyWithValue("minimum", 0))
+			Expect(properties["backup_retention_period"]).To(HaveKeyWithValue("maximum", int64(7)))
+		})
+
+		It("rejects parameters that are not allowed", func() {
+			schema := ParametersSchema(ProvisionParameters{}, servicePlan)
+			err := ValidateParameters(schema, map[string]interface{}{"db_name": "mydb"})
+			Expect(err).To(MatchError("Invalid parameters: db_name is not allowed. Accepted parameters: backup_retention_period"))
+		})
+
+		It("keeps the built-in limits the plan bounds are looser than", func() {
+			minimum, maximum := int64(-1), int64(90)
+			servicePlan.UserParameters.Provision["backup_retention_period"] = ParameterConstraints{Minimum: &minimum, Maximum: &maximum}
+
+			properties := ParametersSchema(ProvisionParameters{}, servicePlan)["properties"].(map[string]interface{})
+			Expect(properties["backup_retention_period"]).To(HaveKeyWithValue("minimum", 0))
+			Expect(properties["backup_retention_period"]).To(HaveKeyWithValue("maximum", 35))
+		})
+
+		It("requires values to match both the built-in pattern and the plan's", func() {
+			servicePlan.UserParameters.Provision["db_name"] = ParameterConstraints{Pattern: "^app_"}
+			schema := ParametersSchema(ProvisionParameters{}, servicePlan)
+
+			Expect(ValidateParameters(schema, map[string]interface{}{"db_name": "app_db"})).To(Succeed())
+			Expect(ValidateParameters(schema, map[string]interface{}{"db_name": "mydb"})).To(MatchError(ContainSubstring("db_name must match the pattern '^app_'")))
+			Expect(ValidateParameters(schema, map[string]interface{}{"db_name": "app_my-db"})).To(MatchError(ContainSubstring("db_name must match the pattern '^[A-Za-z][A-Za-z0-9_]*$'")))
+		})
+
+		It("only keeps the enum values allowed by both the built-in limits and the plan", func() {
+			servicePlan.UserParameters.Provision["skip_final_snapshot"] = ParameterConstraints{Enum: []string{"false", "maybe"}}
+
+			properties := ParametersSchema(ProvisionParameters{}, servicePlan)["properties"].(map[string]interface{})
+			Expect(properties["skip_final_snapshot"]).To(HaveKeyWithValue("enum", []string{"false"}))
+		})
+
+		It("does not restrict the other kinds of parameters", func() {
+			schema := ParametersSchema(UpdateParameters{}, servicePlan)
+			Expect(schema["properties"]).To(HaveLen(7))
+		})
+	})
+
+	Describe("ValidateParameters", func() {
+		var (
+			schema map[string]interface{}
+		)
+
+		BeforeEach(func() {
+			schema = ParametersSchema(ProvisionParameters{}, servicePlan)
+		})
+
+		It("accepts valid parameters", func() {
+			err := ValidateParameters(schema, map[string]interface{}{
+				"backup_retention_period": float64(7),
+				"db_name":                 "mydb",
+				"skip_final_snapshot":     "false",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("rejects parameters not described by the schema", func() {
+			err := ValidateParameters(schema, map[string]interface{}{"unknown": true})
+			Expect(err).To(MatchError("Invalid parameters: unknown is not allowed. Accepted parameters: backup_retention_period, character_set_name, db_name, preferred_backup_window, preferred_maintenance_window, skip_final_snapshot"))
+		})
+
+		It("rejects values out of bounds", func() {
+			err := ValidateParameters(schema, map[string]interface{}{"backup_retention_period": float64(36)})
+			Expect(err).To(MatchError("Invalid parameters: backup_retention_period must be less than or equal to 35"))
+		})
+
+		It("rejects non integer numbers", func() {
+			err := ValidateParameters(schema, map[string]interface{}{"backup_retention_period": 1.5})
+			Expect(err).To(MatchError("Invalid parameters: backup_retention_period must be an integer, got a number"))
+		})
+
+		It("rejects strings not matching the pattern", func() {
+			err := ValidateParameters(schema, map[string]interface{}{"db_name": "1-db"})
+			Expect(err).To(MatchError(ContainSubstring("db_name must match the pattern")))
+		})
+
+		It("rejects array items not matching their schema", func() {
+			servicePlan.RDSProperties.AllowedExtensions = []string{"postgis", "pg_trgm"}
+			schema = ParametersSchema(ProvisionParameters{}, servicePlan)
+
+			Expect(ValidateParameters(schema, map[string]interface{}{
+				"extensions": []interface{}{"pg_trgm"},
+			})).To(Succeed())
+
+			err := ValidateParameters(schema, map[string]interface{}{
+				"extensions": []interface{}{"postgis", "plperlu"},
+			})
+			Expect(err).To(MatchError("Invalid parameters: extensions item 1 must be one of 'postgis', 'pg_trgm'"))
+
+			err = ValidateParameters(schema, map[string]interface{}{"extensions": "postgis"})
+			Expect(err).To(MatchError("Invalid parameters: extensions must be an array, got a string"))
+		})
+
+		It("reports all the invalid fields", func() {
+			err := ValidateParameters(schema, map[string]interface{}{
+				"db_name":             false,
+				"skip_final_snapshot": "maybe",
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ParametersError).Errors).To(Equal([]string{
+				"db_name must be a string, got a boolean",
+				"skip_final_snapshot must be one of 'true', 'false'",
+			}))
+		})
+	})
+})
@@ -0,0 +1,124 @@
+package rdsbroker
+
+import (
+	"errors"
+	"time"
+
+	"github.com/frodenas/brokerapi"
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/awsrds"
+	"github.com/alphagov/paas-rds-broker/sqlengine"
+)
+
+var ErrServiceKeyDoesNotExist = errors.New("the service key does not exist")
+
+// ServiceKey is a service key of an instance, with the user of its own it was
+// given.
+type ServiceKey struct {
+	BindingID string     `json:"binding_id"`
+	Username  string     `json:"username"`
+	ReadOnly  bool       `json:"read_only"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// serviceKeyUserOptions gives the user of a service key the restrictions of
+// the service keys policy on top of the options of the binding. The earliest
+// expiry wins.
+func serviceKeyUserOptions(options sqlengine.UserOptions, serviceKeys ServiceKeysConfig) sqlengine.UserOptions {
+	options.ServiceKey = true
+	options.ReadOnly = serviceKeys.ReadOnly
+
+	if serviceKeys.ExpiresIn > 0 {
+		validUntil := time.Now().UTC().Truncate(time.Second).Add(time.Duration(serviceKeys.ExpiresIn) * time.Second)
+		if options.ValidUntil.IsZero() || validUntil.Before(options.ValidUntil) {
+			options.ValidUntil = validUntil
+		}
+	}
+
+	return options
+}
+
+// ServiceKeys returns the service keys of an instance given a user of their
+// own.
+func (b *RDSBroker) ServiceKeys(instanceID string) ([]ServiceKey, error) {
+	sqlEngine, err := b.openInstanceAsMaster(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlEngine.Close()
+
+	users, err := sqlEngine.ListServiceKeyUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceKeys := []ServiceKey{}
+	for _, user := range users {
+		serviceKey := ServiceKey{
+			BindingID: user.BindingID,
+			Username:  user.Username,
+			ReadOnly:  user.ReadOnly,
+			CreatedAt: user.CreatedAt,
+		}
+		if !user.ExpiresAt.IsZero() {
+			expiresAt := user.ExpiresAt
+			serviceKey.ExpiresAt = &expiresAt
+		}
+		serviceKeys = append(serviceKeys, serviceKey)
+	}
+
+	return serviceKeys, nil
+}
+
+// RevokeServiceKey drops the user of a service key of an instance, so that its
+// credentials can no longer be used. The service key itself is deleted from
+// the platform as usual.
+func (b *RDSBroker) RevokeServiceKey(instanceID, bindingID string) error {
+	sqlEngine, err := b.openInstanceAsMaster(instanceID)
+	if err != nil {
+		return err
+	}
+	defer sqlEngine.Close()
+
+	users, err := sqlEngine.ListServiceKeyUsers()
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if user.BindingID == bindingID {
+			b.logger.Info("revoke-service-key", lager.Data{
+				instanceIDLogKey: instanceID,
+				bindingIDLogKey:  bindingID,
+			})
+			return sqlEngine.DropUser(bindingID)
+		}
+	}
+
+	return ErrServiceKeyDoesNotExist
+}
+
+// openInstanceAsMaster connects to the database of an instance as master.
+func (b *RDSBroker) openInstanceAsMaster(instanceID string) (sqlengine.SQLEngine, error) {
+	dbInstanceDetails, err := b.dbInstance.Describe(b.dbInstanceIdentifier(instanceID))
+	if err != nil {
+		if err == awsrds.ErrDBInstanceDoesNotExist {
+			return nil, brokerapi.ErrInstanceDoesNotExist
+		}
+		return nil, err
+	}
+
+	sqlEngine, err := b.sqlProvider.GetSQLEngine(dbInstanceDetails.Engine)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := b.dbNameFromDetails(instanceID, dbInstanceDetails)
+	if err := b.openAsMaster(sqlEngine, instanceID, dbInstanceDetails.Address, dbInstanceDetails.Port, dbName, dbInstanceDetails.MasterUsername); err != nil {
+		return nil, err
+	}
+
+	return sqlEngine, nil
+}
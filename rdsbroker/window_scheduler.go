@@ -0,0 +1,108 @@
+package rdsbroker
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// WindowScheduler spreads the backup and maintenance windows of the instances
+// across the ranges configured by the operator, so that they do not all go
+// through maintenance at the same time.
+type WindowScheduler struct {
+	backupSlots      []timeWindow
+	maintenanceSlots []timeWindow
+}
+
+func NewWindowScheduler(config WindowSchedulerConfig) *WindowScheduler {
+	scheduler := &WindowScheduler{}
+
+	for _, windowRange := range config.BackupWindowRanges {
+		if window, err := parseBackupWindow(windowRange); err == nil {
+			scheduler.backupSlots = append(scheduler.backupSlots, windowSlots(window, config.WindowDuration)...)
+		}
+	}
+
+	for _, windowRange := range config.MaintenanceWindowRanges {
+		if window, err := parseMaintenanceWindow(windowRange); err == nil {
+			scheduler.maintenanceSlots = append(scheduler.maintenanceSlots, windowSlots(window, config.WindowDuration)...)
+		}
+	}
+
+	return scheduler
+}
+
+// Schedule returns the backup and maintenance windows of an instance. Windows
+// already set are kept as they are. The other ones are picked from the
+// configured ranges using a hash of the instance ID, so that the same instance
+// always gets the same windows, avoiding any overlap between them. An empty
+// window is returned if there is no range configured for it.
+func (s *WindowScheduler) Schedule(instanceID string, backupWindow string, maintenanceWindow string) (string, string) {
+	if backupWindow == "" {
+		maintenance, err := parseMaintenanceWindow(maintenanceWindow)
+		hasMaintenance := err == nil
+		slot, ok := pickSlot(s.backupSlots, "backup:"+instanceID, func(slot timeWindow) bool {
+			return !hasMaintenance || !windowsOverlap(slot, maintenance)
+		})
+		if ok {
+			backupWindow = formatBackupWindow(slot)
+		}
+	}
+
+	if maintenanceWindow == "" {
+		backup, err := parseBackupWindow(backupWindow)
+		hasBackup := err == nil
+		slot, ok := pickSlot(s.maintenanceSlots, "maintenance:"+instanceID, func(slot timeWindow) bool {
+			return !hasBackup || !windowsOverlap(backup, slot)
+		})
+		if ok {
+			maintenanceWindow = formatMaintenanceWindow(slot)
+		}
+	}
+
+	return backupWindow, maintenanceWindow
+}
+
+func windowSlots(window timeWindow, duration int) []timeWindow {
+	var slots []timeWindow
+	for start := window.start; start+duration <= window.end; start += duration {
+		slots = append(slots, timeWindow{start: start, end: start + duration})
+	}
+	return slots
+}
+
+func pickSlot(slots []timeWindow, key string, accept func(timeWindow) bool) (timeWindow, bool) {
+	if len(slots) == 0 {
+		return timeWindow{}, false
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	first := int(hash.Sum64() % uint64(len(slots)))
+
+	for i := 0; i < len(slots); i++ {
+		slot := slots[(first+i)%len(slots)]
+		if accept(slot) {
+			return slot, true
+		}
+	}
+
+	return timeWindow{}, false
+}
+
+func formatBackupWindow(window timeWindow) string {
+	return fmt.Sprintf("%s-%s", formatTimeOfDay(window.start), formatTimeOfDay(window.end))
+}
+
+func formatMaintenanceWindow(window timeWindow) string {
+	return fmt.Sprintf("%s-%s", formatTimeOfWeek(window.start), formatTimeOfWeek(window.end))
+}
+
+func formatTimeOfWeek(minutes int) string {
+	minutes = minutes % minutesPerWeek
+	return fmt.Sprintf("%s:%s", weekDays[minutes/minutesPerDay], formatTimeOfDay(minutes))
+}
+
+func formatTimeOfDay(minutes int) string {
+	minutes = minutes % minutesPerDay
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,87 @@
+package rdsbroker_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/alphagov/paas-rds-broker/rdsbroker"
+)
+
+var _ = Describe("WindowScheduler", func() {
+	var (
+		config    WindowSchedulerConfig
+		scheduler *WindowScheduler
+	)
+
+	BeforeEach(func() {
+		config = WindowSchedulerConfig{
+			BackupWindowRanges:      []string{"01:00-03:00"},
+			MaintenanceWindowRanges: []string{"mon:02:00-mon:04:00", "wed:22:00-thu:01:00"},
+			WindowDuration:          30,
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheduler = NewWindowScheduler(config)
+	})
+
+	It("picks windows inside the configured ranges", func() {
+		backupWindows := map[string]bool{}
+		maintenanceWindows := map[string]bool{}
+		for i := 0; i < 100; i++ {
+			backupWindow, maintenanceWindow := scheduler.Schedule(fmt.Sprintf("instance-%d", i), "", "")
+			backupWindows[backupWindow] = true
+			maintenanceWindows[maintenanceWindow] = true
+			Expect(ValidateWindows(backupWindow, maintenanceWindow)).To(Succeed())
+		}
+
+		Expect(backupWindows).To(Equal(map[string]bool{
+			"01:00-01:30": true,
+			"01:30-02:00": true,
+			"02:00-02:30": true,
+			"02:30-03:00": true,
+		}))
+		Expect(maintenanceWindows).To(HaveLen(10))
+		Expect(maintenanceWindows).To(HaveKey("wed:23:30-thu:00:00"))
+		Expect(maintenanceWindows).To(HaveKey("thu:00:30-thu:01:00"))
+	})
+
+	It("always gives the same windows to an instance", func() {
+		backupWindow, maintenanceWindow := scheduler.Schedule("instance-id", "", "")
+		for i := 0; i < 10; i++ {
+			otherBackupWindow, otherMaintenanceWindow := scheduler.Schedule("instance-id", "", "")
+			Expect(otherBackupWindow).To(Equal(backupWindow))
+			Expect(otherMaintenanceWindow).To(Equal(maintenanceWindow))
+		}
+	})
+
+	It("keeps the windows already set", func() {
+		backupWindow, maintenanceWindow := scheduler.Schedule("instance-id", "05:00-05:30", "sun:04:00-sun:04:30")
+		Expect(backupWindow).To(Equal("05:00-05:30"))
+		Expect(maintenanceWindow).To(Equal("sun:04:00-sun:04:30"))
+	})
+
+	It("does not overlap the windows already set", func() {
+		for i := 0; i < 100; i++ {
+			backupWindow, _ := scheduler.Schedule(fmt.Sprintf("instance-%d", i), "", "mon:01:00-mon:02:30")
+			Expect(backupWindow).To(Equal("02:30-03:00"))
+
+			_, maintenanceWindow := scheduler.Schedule(fmt.Sprintf("instance-%d", i), "02:00-04:00", "")
+			Expect(maintenanceWindow).ToNot(HavePrefix("mon:"))
+		}
+	})
+
+	Context("when there is no range for a window", func() {
+		BeforeEach(func() {
+			config.BackupWindowRanges = nil
+		})
+
+		It("leaves it empty", func() {
+			backupWindow, maintenanceWindow := scheduler.Schedule("instance-id", "", "")
+			Expect(backupWindow).To(BeEmpty())
+			Expect(maintenanceWindow).ToNot(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,179 @@
+package rdsbroker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const minutesPerDay = 24 * 60
+const minutesPerWeek = 7 * minutesPerDay
+
+// minWindowDuration is the shortest backup or maintenance window, in minutes,
+// accepted by RDS.
+const minWindowDuration = 30
+
+var weekDays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// timeWindow is a time range in minutes since the start of the day (backup
+// windows) or of the week (maintenance windows). Windows wrapping around
+// midnight or the end of the week have an end past the period.
+type timeWindow struct {
+	start int
+	end   int
+}
+
+func (w timeWindow) duration() int {
+	return w.end - w.start
+}
+
+func (w timeWindow) overlaps(other timeWindow) bool {
+	return w.start < other.end && other.start < w.end
+}
+
+// parseBackupWindow parses a daily time range in the hh24:mi-hh24:mi format.
+func parseBackupWindow(window string) (timeWindow, error) {
+	times := strings.Split(window, "-")
+	if len(times) != 2 {
+		return timeWindow{}, fmt.Errorf("must be a daily time range in UTC using the format hh24:mi-hh24:mi, e.g. 03:00-03:30, got '%s'", window)
+	}
+
+	start, err := parseTimeOfDay(times[0])
+	if err != nil {
+		return timeWindow{}, err
+	}
+
+	end, err := parseTimeOfDay(times[1])
+	if err != nil {
+		return timeWindow{}, err
+	}
+
+	if end < start {
+		end += minutesPerDay
+	}
+
+	return timeWindow{start: start, end: end}, nil
+}
+
+// parseMaintenanceWindow parses a weekly time range in the
+// ddd:hh24:mi-ddd:hh24:mi format.
+func parseMaintenanceWindow(window string) (timeWindow, error) {
+	times := strings.Split(window, "-")
+	if len(times) != 2 {
+		return timeWindow{}, fmt.Errorf("must be a weekly time range in UTC using the format ddd:hh24:mi-ddd:hh24:mi, e.g. sun:04:00-sun:04:30, got '%s'", window)
+	}
+
+	start, err := parseTimeOfWeek(times[0])
+	if err != nil {
+		return timeWindow{}, err
+	}
+
+	end, err := parseTimeOfWeek(times[1])
+	if err != nil {
+		return timeWindow{}, err
+	}
+
+	if end < start {
+		end += minutesPerWeek
+	}
+
+	return timeWindow{start: start, end: end}, nil
+}
+
+// ValidateWindows checks the format and the duration of the backup and
+// maintenance windows, and that they do not overlap. Empty windows are left
+// for RDS to choose and are not checked.
+func ValidateWindows(backupWindow string, maintenanceWindow string) error {
+	var errs []string
+
+	var backup, maintenance *timeWindow
+	if backupWindow != "" {
+		window, err := parseBackupWindow(backupWindow)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("preferred_backup_window %s", err))
+		} else if window.duration() < minWindowDuration {
+			errs = append(errs, fmt.Sprintf("preferred_backup_window must be at least %d minutes long, got '%s'", minWindowDuration, backupWindow))
+		} else {
+			backup = &window
+		}
+	}
+
+	if maintenanceWindow != "" {
+		window, err := parseMaintenanceWindow(maintenanceWindow)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("preferred_maintenance_window %s", err))
+		} else if window.duration() < minWindowDuration {
+			errs = append(errs, fmt.Sprintf("preferred_maintenance_window must be at least %d minutes long, got '%s'", minWindowDuration, maintenanceWindow))
+		} else {
+			maintenance = &window
+		}
+	}
+
+	if backup != nil && maintenance != nil && windowsOverlap(*backup, *maintenance) {
+		errs = append(errs, fmt.Sprintf("preferred_backup_window '%s' overlaps preferred_maintenance_window '%s', backups run every day so they must not happen during maintenance", backupWindow, maintenanceWindow))
+	}
+
+	if len(errs) > 0 {
+		return ParametersError{Errors: errs}
+	}
+
+	return nil
+}
+
+// windowsOverlap tells whether the daily backup window falls, on any day of
+// the week, within the weekly maintenance window.
+func windowsOverlap(backup timeWindow, maintenance timeWindow) bool {
+	for day := -1; day <= 14; day++ {
+		dailyBackup := timeWindow{
+			start: day*minutesPerDay + backup.start,
+			end:   day*minutesPerDay + backup.end,
+		}
+		if dailyBackup.overlaps(maintenance) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTimeOfWeek(value string) (int, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("must be a weekly time range in UTC using the format ddd:hh24:mi-ddd:hh24:mi, e.g. sun:04:00-sun:04:30, got '%s'", value)
+	}
+
+	day := -1
+	for i, weekDay := range weekDays {
+		if strings.ToLower(parts[0]) == weekDay {
+			day = i
+		}
+	}
+	if day < 0 {
+		return 0, fmt.Errorf("has an unknown day of the week '%s', expected one of %s", parts[0], strings.Join(weekDays, ", "))
+	}
+
+	minutes, err := parseTimeOfDay(parts[1])
+	if err != nil {
+		return 0, err
+	}
+
+	return day*minutesPerDay + minutes, nil
+}
+
+func parseTimeOfDay(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("has an invalid time '%s', expected hh24:mi", value)
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, fmt.Errorf("has an invalid time '%s', hours must be between 00 and 23", value)
+	}
+
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("has an invalid time '%s', minutes must be between 00 and 59", value)
+	}
+
+	return hours*60 + minutes, nil
+}
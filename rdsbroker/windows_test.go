@@ -0,0 +1,70 @@
+package rdsbroker_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/alphagov/paas-rds-broker/rdsbroker"
+)
+
+var _ = Describe("Windows", func() {
+	Describe("ValidateWindows", func() {
+		It("accepts empty windows", func() {
+			Expect(ValidateWindows("", "")).To(Succeed())
+		})
+
+		It("accepts valid windows", func() {
+			Expect(ValidateWindows("03:00-03:30", "sun:04:00-sun:04:30")).To(Succeed())
+		})
+
+		It("handles windows wrapping around midnight and the end of the week", func() {
+			Expect(ValidateWindows("23:45-00:15", "sun:23:00-mon:01:00")).ToNot(Succeed())
+			Expect(ValidateWindows("23:45-00:15", "sun:22:00-mon:23:30")).ToNot(Succeed())
+			Expect(ValidateWindows("23:45-00:15", "sun:01:00-sun:02:00")).To(Succeed())
+			Expect(ValidateWindows("22:00-23:00", "sun:23:30-mon:00:30")).To(Succeed())
+		})
+
+		It("accepts any casing for the days of the week", func() {
+			Expect(ValidateWindows("", "Sun:04:00-SUN:04:30")).To(Succeed())
+		})
+
+		It("rejects badly formatted backup windows", func() {
+			err := ValidateWindows("3am-4am", "")
+			Expect(err).To(MatchError("Invalid parameters: preferred_backup_window has an invalid time '3am', expected hh24:mi"))
+
+			err = ValidateWindows("03:00", "")
+			Expect(err).To(MatchError("Invalid parameters: preferred_backup_window must be a daily time range in UTC using the format hh24:mi-hh24:mi, e.g. 03:00-03:30, got '03:00'"))
+		})
+
+		It("rejects times out of range", func() {
+			err := ValidateWindows("24:00-00:30", "")
+			Expect(err).To(MatchError("Invalid parameters: preferred_backup_window has an invalid time '24:00', hours must be between 00 and 23"))
+
+			err = ValidateWindows("", "sun:04:60-sun:05:30")
+			Expect(err).To(MatchError("Invalid parameters: preferred_maintenance_window has an invalid time '04:60', minutes must be between 00 and 59"))
+		})
+
+		It("rejects unknown days of the week", func() {
+			err := ValidateWindows("", "sunday:04:00-sunday:04:30")
+			Expect(err).To(MatchError("Invalid parameters: preferred_maintenance_window has an unknown day of the week 'sunday', expected one of mon, tue, wed, thu, fri, sat, sun"))
+		})
+
+		It("rejects windows shorter than 30 minutes", func() {
+			err := ValidateWindows("03:00-03:15", "sun:04:00-sun:04:29")
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ParametersError).Errors).To(Equal([]string{
+				"preferred_backup_window must be at least 30 minutes long, got '03:00-03:15'",
+				"preferred_maintenance_window must be at least 30 minutes long, got 'sun:04:00-sun:04:29'",
+			}))
+		})
+
+		It("rejects overlapping windows", func() {
+			err := ValidateWindows("04:15-04:45", "wed:04:00-wed:04:30")
+			Expect(err).To(MatchError("Invalid parameters: preferred_backup_window '04:15-04:45' overlaps preferred_maintenance_window 'wed:04:00-wed:04:30', backups run every day so they must not happen during maintenance"))
+		})
+
+		It("accepts adjacent windows", func() {
+			Expect(ValidateWindows("03:30-04:00", "wed:04:00-wed:04:30")).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,353 @@
+package rdsbroker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/frodenas/brokerapi"
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/awsrds"
+	"github.com/alphagov/paas-rds-broker/workflow"
+)
+
+const preChangeSnapshotWorkflow = "pre-change-snapshot"
+
+const (
+	preChangeSnapshotStep = "snapshot"
+	preChangeModifyStep   = "modify"
+)
+
+const manualSnapshotWorkflow = "manual-snapshot"
+
+const manualSnapshotStep = "snapshot"
+
+// The data of the workflows applying an update once a snapshot is available.
+const (
+	snapshotIDData     = "snapshot_id"
+	serviceIDData      = "service_id"
+	planIDData         = "plan_id"
+	previousPlanIDData = "previous_plan_id"
+	parametersData     = "parameters"
+)
+
+var ErrOperationInProgress = errors.New("another operation is in progress for this instance")
+
+// RunWorkflows runs the next step of every workflow in progress, so that they
+// carry on when the platform does not poll LastOperation.
+func (b *RDSBroker) RunWorkflows() {
+	b.workflows.AdvanceAll()
+}
+
+func (b *RDSBroker) registerWorkflows() {
+	b.workflows.Register(preChangeSnapshotWorkflow, workflow.Definition{
+		Steps: map[string]workflow.Step{
+			preChangeSnapshotStep: b.waitForPreChangeSnapshot,
+			preChangeModifyStep: func(w *workflow.Workflow) (string, string, error) {
+				return workflow.Finished, "", nil
+			},
+			deleteGroupsStep: b.deleteGroups,
+		},
+	})
+
+	b.workflows.Register(manualSnapshotWorkflow, workflow.Definition{
+		Steps: map[string]workflow.Step{
+			manualSnapshotStep: b.waitForManualSnapshot,
+		},
+	})
+
+	b.workflows.Register(encryptionChangeWorkflow, workflow.Definition{
+		Steps: map[string]workflow.Step{
+			encryptionChangeSnapshotStep:  b.copyEncryptionChangeSnapshot,
+			encryptionChangeCopyStep:      b.restoreEncryptedSnapshot,
+			encryptionChangeRestoreStep:   b.configureRestoredDBInstance,
+			encryptionChangeConfigureStep: b.renameOriginalDBInstance,
+			encryptionChangeRenameStep:    b.renameRestoredDBInstance,
+			encryptionChangeCleanupStep:   b.cleanupEncryptionChange,
+			deleteGroupsStep:              b.deleteEncryptionChangeGroups,
+			encryptionChangeRollbackStep:  b.rollbackEncryptionChange,
+		},
+		RollbackStep: encryptionChangeRollbackStep,
+	})
+
+	b.workflows.Register(postProvisionWorkflow, workflow.Definition{
+		Steps: map[string]workflow.Step{
+			postProvisionSQLStep:        b.runPostProvisionSQL,
+			postProvisionExtensionsStep: b.createPostProvisionExtensions,
+			postProvisionTagStep:        b.tagPostProvisionExtensions,
+		},
+	})
+
+	b.workflows.Register(deleteGroupsWorkflow, workflow.Definition{
+		Steps: map[string]workflow.Step{
+			deleteGroupsStep: b.deleteGroups,
+		},
+	})
+}
+
+// checkNoWorkflowInProgress refuses to start an operation while a workflow is
+// running for the service instance, unless it is of one of the given kinds.
+func (b *RDSBroker) checkNoWorkflowInProgress(instanceID string, allowedKinds ...string) error {
+	w, err := b.workflows.Get(instanceID)
+	if err == workflow.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if w.State != workflow.StateInProgress {
+		return nil
+	}
+	for _, kind := range allowedKinds {
+		if w.Kind == kind {
+			return nil
+		}
+	}
+
+	return NewFailureResponse(ErrOperationInProgress, statusUnprocessableEntity, "operation-in-progress")
+}
+
+// workflowLastOperation runs the next step of the workflow of a service
+// instance and reports its progress. It returns false if there is no workflow
+// left to report on.
+func (b *RDSBroker) workflowLastOperation(instanceID string) (brokerapi.LastOperationResponse, bool, error) {
+	lastOperationResponse := brokerapi.LastOperationResponse{State: brokerapi.LastOperationFailed}
+
+	w, err := b.workflows.Advance(instanceID)
+	if err == workflow.ErrNotFound {
+		return lastOperationResponse, false, nil
+	}
+	if err != nil {
+		return lastOperationResponse, true, err
+	}
+
+	switch w.State {
+	case workflow.StateInProgress:
+		lastOperationResponse.State = brokerapi.LastOperationInProgress
+		lastOperationResponse.Description = w.Description
+		return lastOperationResponse, true, nil
+
+	case workflow.StateFailed:
+		lastOperationResponse.Description = w.Description
+		if err := b.workflows.Forget(instanceID); err != nil {
+			return lastOperationResponse, true, err
+		}
+		return lastOperationResponse, true, nil
+	}
+
+	// Once succeeded, the status of the DB instance is reported
+	if err := b.workflows.Forget(instanceID); err != nil {
+		return lastOperationResponse, true, err
+	}
+	return lastOperationResponse, false, nil
+}
+
+// updateWorkflowData records an update to apply at the end of a workflow.
+func (b *RDSBroker) updateWorkflowData(snapshotID string, servicePlan ServicePlan, details brokerapi.UpdateDetails) (map[string]string, error) {
+	data := map[string]string{
+		snapshotIDData:     snapshotID,
+		serviceIDData:      details.ServiceID,
+		planIDData:         details.PlanID,
+		previousPlanIDData: details.PreviousValues.PlanID,
+	}
+
+	if len(details.Parameters) > 0 && b.userParametersAllowed(UpdateParameters{}, servicePlan) {
+		parameters, err := json.Marshal(details.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		data[parametersData] = string(parameters)
+	}
+
+	return data, nil
+}
+
+// targetChange reads back the update recorded by updateWorkflowData.
+func (b *RDSBroker) targetChange(data map[string]string) (ServicePlan, UpdateParameters, brokerapi.UpdateDetails, error) {
+	updateParameters := UpdateParameters{}
+
+	servicePlan, ok := b.catalog.FindServicePlan(data[planIDData])
+	if !ok {
+		return servicePlan, updateParameters, brokerapi.UpdateDetails{}, fmt.Errorf("Service Plan '%s' not found", data[planIDData])
+	}
+
+	details := brokerapi.UpdateDetails{
+		ServiceID: data[serviceIDData],
+		PlanID:    servicePlan.ID,
+		PreviousValues: brokerapi.PreviousValues{
+			PlanID: data[previousPlanIDData],
+		},
+	}
+
+	if value := data[parametersData]; value != "" {
+		if err := json.Unmarshal([]byte(value), &details.Parameters); err != nil {
+			return servicePlan, updateParameters, details, err
+		}
+		userParameters, _, err := NormalizeParameters(updateParameters, details.Parameters)
+		if err != nil {
+			return servicePlan, updateParameters, details, err
+		}
+		if err := DecodeParameters(userParameters, &updateParameters); err != nil {
+			return servicePlan, updateParameters, details, err
+		}
+	}
+
+	return servicePlan, updateParameters, details, nil
+}
+
+// waitForPreChangeSnapshot deletes the earlier pre-change snapshots and
+// modifies the DB instance once its pre-change snapshot is available.
+func (b *RDSBroker) waitForPreChangeSnapshot(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+	snapshotID := w.Data[snapshotIDData]
+
+	dbSnapshots, err := b.dbInstance.DescribeInstanceSnapshots(dbInstanceIdentifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	var dbSnapshot *awsrds.DBSnapshotDetails
+	var previousSnapshots []*awsrds.DBSnapshotDetails
+	for _, s := range dbSnapshots {
+		if s.Identifier == snapshotID {
+			dbSnapshot = s
+		} else if s.Tags[snapshotTypeTag] == preChangeSnapshotType && s.Status == "available" {
+			previousSnapshots = append(previousSnapshots, s)
+		}
+	}
+	if dbSnapshot == nil || (dbSnapshot.Status != "creating" && dbSnapshot.Status != "available") {
+		return "", "", workflow.Failf("Pre-change DB Snapshot '%s' failed, DB Instance '%s' has not been modified", snapshotID, dbInstanceIdentifier)
+	}
+
+	dbInstanceDetails, err := b.dbInstance.Describe(dbInstanceIdentifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	if dbSnapshot.Status != "available" || dbInstanceDetails.Status != "available" {
+		return preChangeSnapshotStep, "", nil
+	}
+
+	servicePlan, updateParameters, details, err := b.targetChange(w.Data)
+	if err != nil {
+		return "", "", workflow.Failf("Could not modify DB Instance '%s' after pre-change DB Snapshot '%s': %s", dbInstanceIdentifier, snapshotID, err)
+	}
+
+	if err := b.applyPendingGroupsAndExtensions(w, dbInstanceIdentifier, servicePlan, updateParameters, details); err != nil {
+		return "", "", err
+	}
+
+	for _, previousSnapshot := range previousSnapshots {
+		logData := lager.Data{instanceIDLogKey: w.InstanceID, "snapshot-id": previousSnapshot.Identifier}
+		b.logger.Info("rotate-pre-change-snapshot", logData)
+		if err := b.dbInstance.DeleteSnapshot(previousSnapshot.Identifier); err != nil {
+			b.logger.Error("rotate-pre-change-snapshot", err, logData)
+		}
+	}
+
+	modifyDBInstance := b.modifyDBInstance(w.InstanceID, servicePlan, updateParameters, details)
+	if err := b.dbInstance.Modify(dbInstanceIdentifier, *modifyDBInstance, updateParameters.ApplyImmediately); err != nil {
+		b.logger.Error("apply-pending-change", err, lager.Data{instanceIDLogKey: w.InstanceID, "snapshot-id": snapshotID})
+		return "", "", workflow.Failf("Could not modify DB Instance '%s' after pre-change DB Snapshot '%s': %s", dbInstanceIdentifier, snapshotID, err)
+	}
+
+	description := fmt.Sprintf("Pre-change DB Snapshot '%s' is available, modifying DB Instance '%s'", snapshotID, dbInstanceIdentifier)
+
+	// The groups left behind by the plan change are deleted once released
+	if leftGroups := b.changeLeftGroups(w); len(leftGroups) > 0 {
+		for key, name := range leftGroups {
+			w.Data[key] = name
+		}
+		return deleteGroupsStep, description, nil
+	}
+
+	return preChangeModifyStep, description, nil
+}
+
+// applyPendingGroupsAndExtensions applies the changes to the groups and the
+// extensions of an update recorded by updateWorkflowData, once its snapshot
+// has been taken. The extensions are updated on the given DB instance.
+func (b *RDSBroker) applyPendingGroupsAndExtensions(w *workflow.Workflow, dbInstanceIdentifier string, servicePlan ServicePlan, updateParameters UpdateParameters, details brokerapi.UpdateDetails) error {
+	previousServicePlan, ok := b.catalog.FindServicePlan(details.PreviousValues.PlanID)
+	if !ok {
+		return workflow.Failf("Service Plan '%s' not found", details.PreviousValues.PlanID)
+	}
+
+	if err := b.updateGroups(w.InstanceID, servicePlan, previousServicePlan, updateParameters, details); err != nil {
+		b.logger.Error("apply-pending-groups", err, lager.Data{instanceIDLogKey: w.InstanceID})
+		return err
+	}
+
+	if updateParameters.Extensions != nil {
+		if err := b.updateExtensions(w.InstanceID, dbInstanceIdentifier, servicePlan, updateParameters.Extensions); err != nil {
+			b.logger.Error("apply-pending-extensions", err, lager.Data{instanceIDLogKey: w.InstanceID})
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitForManualSnapshot deletes the oldest manual snapshots of the DB instance
+// past the limit of its plan once the new manual snapshot is available, so that
+// a failed snapshot never costs the user a restore point.
+func (b *RDSBroker) waitForManualSnapshot(w *workflow.Workflow) (string, string, error) {
+	dbInstanceIdentifier := b.dbInstanceIdentifier(w.InstanceID)
+	snapshotID := w.Data[snapshotIDData]
+
+	dbSnapshots, err := b.dbInstance.DescribeInstanceSnapshots(dbInstanceIdentifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	var dbSnapshot *awsrds.DBSnapshotDetails
+	var manualSnapshots []*awsrds.DBSnapshotDetails
+	for _, s := range dbSnapshots {
+		if s.Identifier == snapshotID {
+			dbSnapshot = s
+		} else if s.Tags[snapshotTypeTag] == manualSnapshotType {
+			manualSnapshots = append(manualSnapshots, s)
+		}
+	}
+	if dbSnapshot == nil || (dbSnapshot.Status != "creating" && dbSnapshot.Status != "available") {
+		return "", "", workflow.Failf("Manual DB Snapshot '%s' of DB Instance '%s' failed, the previous manual DB Snapshots have been kept", snapshotID, dbInstanceIdentifier)
+	}
+	if dbSnapshot.Status != "available" {
+		return manualSnapshotStep, "", nil
+	}
+
+	servicePlan, ok := b.catalog.FindServicePlan(w.Data[planIDData])
+	if !ok {
+		return "", "", workflow.Failf("Service Plan '%s' not found", w.Data[planIDData])
+	}
+
+	sort.Sort(byCreateTime(manualSnapshots))
+	excess := len(manualSnapshots) + 1 - int(servicePlan.RDSProperties.MaxManualSnapshots)
+	for i := 0; i < excess && i < len(manualSnapshots); i++ {
+		logData := lager.Data{instanceIDLogKey: w.InstanceID, "snapshot-id": manualSnapshots[i].Identifier}
+		b.logger.Info("rotate-manual-snapshot", logData)
+		if err := b.dbInstance.DeleteSnapshot(manualSnapshots[i].Identifier); err != nil {
+			b.logger.Error("rotate-manual-snapshot", err, logData)
+		}
+	}
+
+	return workflow.Finished, "", nil
+}
+
+func (b *RDSBroker) findInstanceSnapshot(dbInstanceIdentifier string, snapshotID string) (*awsrds.DBSnapshotDetails, error) {
+	dbSnapshots, err := b.dbInstance.DescribeInstanceSnapshots(dbInstanceIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dbSnapshot := range dbSnapshots {
+		if dbSnapshot.Identifier == snapshotID {
+			return dbSnapshot, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,83 @@
+package fakes
+
+import (
+	"github.com/alphagov/paas-rds-broker/secretstore"
+)
+
+// FakeSecretStore keeps the secrets in memory.
+type FakeSecretStore struct {
+	Secrets map[string]string
+	Tags    map[string]map[string]string
+
+	GetCalled bool
+	GetName   string
+	GetError  error
+
+	CreateCalled bool
+	CreateName   string
+	CreateValue  string
+	CreateTags   map[string]string
+	CreateError  error
+
+	DeleteCalled bool
+	DeleteName   string
+	DeleteError  error
+}
+
+func (f *FakeSecretStore) Get(name string) (string, error) {
+	f.GetCalled = true
+	f.GetName = name
+
+	if f.GetError != nil {
+		return "", f.GetError
+	}
+
+	value, ok := f.Secrets[name]
+	if !ok {
+		return "", secretstore.ErrSecretDoesNotExist
+	}
+
+	return value, nil
+}
+
+func (f *FakeSecretStore) Create(name, value string, tags map[string]string) error {
+	f.CreateCalled = true
+	f.CreateName = name
+	f.CreateValue = value
+	f.CreateTags = tags
+
+	if f.CreateError != nil {
+		return f.CreateError
+	}
+
+	if _, ok := f.Secrets[name]; ok {
+		return secretstore.ErrSecretAlreadyExists
+	}
+	if f.Secrets == nil {
+		f.Secrets = map[string]string{}
+	}
+	if f.Tags == nil {
+		f.Tags = map[string]map[string]string{}
+	}
+	f.Secrets[name] = value
+	f.Tags[name] = tags
+
+	return nil
+}
+
+func (f *FakeSecretStore) Delete(name string) error {
+	f.DeleteCalled = true
+	f.DeleteName = name
+
+	if f.DeleteError != nil {
+		return f.DeleteError
+	}
+
+	if _, ok := f.Secrets[name]; !ok {
+		return secretstore.ErrSecretDoesNotExist
+	}
+	delete(f.Secrets, name)
+	delete(f.Tags, name)
+
+	return nil
+}
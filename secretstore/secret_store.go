@@ -0,0 +1,18 @@
+package secretstore
+
+import (
+	"errors"
+)
+
+// SecretStore keeps secret values, such as the master passwords of the DB
+// instances, by name.
+type SecretStore interface {
+	Get(name string) (string, error)
+	Create(name, value string, tags map[string]string) error
+	Delete(name string) error
+}
+
+var (
+	ErrSecretDoesNotExist  = errors.New("secret does not exist")
+	ErrSecretAlreadyExists = errors.New("secret already exists")
+)
@@ -0,0 +1,115 @@
+package secretstore
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pivotal-golang/lager"
+)
+
+// SecretsManagerStore keeps the secrets in AWS Secrets Manager, encrypted
+// with the given KMS key or, if empty, with the default key of the account.
+type SecretsManagerStore struct {
+	kmsKeyID          string
+	secretsmanagersvc *secretsmanager.SecretsManager
+	logger            lager.Logger
+}
+
+func NewSecretsManagerStore(
+	kmsKeyID string,
+	secretsmanagersvc *secretsmanager.SecretsManager,
+	logger lager.Logger,
+) *SecretsManagerStore {
+	return &SecretsManagerStore{
+		kmsKeyID:          kmsKeyID,
+		secretsmanagersvc: secretsmanagersvc,
+		logger:            logger.Session("secret-store"),
+	}
+}
+
+func (s *SecretsManagerStore) Get(name string) (string, error) {
+	getSecretValueInput := &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(name),
+	}
+
+	s.logger.Debug("get-secret-value", lager.Data{"name": name})
+
+	getSecretValueOutput, err := s.secretsmanagersvc.GetSecretValue(getSecretValueInput)
+	if err != nil {
+		return "", s.secretError(err)
+	}
+
+	return aws.StringValue(getSecretValueOutput.SecretString), nil
+}
+
+// Create creates the secret with the given tags. It never overwrites an
+// existing secret.
+func (s *SecretsManagerStore) Create(name, value string, tags map[string]string) error {
+	createSecretInput := &secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		SecretString: aws.String(value),
+		Tags:         buildSecretTags(tags),
+	}
+	if s.kmsKeyID != "" {
+		createSecretInput.KmsKeyId = aws.String(s.kmsKeyID)
+	}
+
+	s.logger.Debug("create-secret", lager.Data{"name": name, "tags": tags})
+
+	if _, err := s.secretsmanagersvc.CreateSecret(createSecretInput); err != nil {
+		return s.secretError(err)
+	}
+
+	return nil
+}
+
+// Delete schedules the deletion of the secret, which can be restored during
+// the default recovery window of Secrets Manager.
+func (s *SecretsManagerStore) Delete(name string) error {
+	deleteSecretInput := &secretsmanager.DeleteSecretInput{
+		SecretId: aws.String(name),
+	}
+
+	s.logger.Debug("delete-secret", lager.Data{"name": name})
+
+	if _, err := s.secretsmanagersvc.DeleteSecret(deleteSecretInput); err != nil {
+		return s.secretError(err)
+	}
+
+	return nil
+}
+
+func (s *SecretsManagerStore) secretError(err error) error {
+	s.logger.Error("aws-secretsmanager-error", err)
+	if awsErr, ok := err.(awserr.Error); ok {
+		if awsErr.Code() == "ResourceNotFoundException" {
+			return ErrSecretDoesNotExist
+		}
+		if awsErr.Code() == "ResourceExistsException" {
+			return ErrSecretAlreadyExists
+		}
+		return errors.New(awsErr.Code() + ": " + awsErr.Message())
+	}
+	return err
+}
+
+func buildSecretTags(tags map[string]string) []*secretsmanager.Tag {
+	var keys []string
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var secretTags []*secretsmanager.Tag
+	for _, key := range keys {
+		secretTags = append(secretTags, &secretsmanager.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(tags[key]),
+		})
+	}
+
+	return secretTags
+}
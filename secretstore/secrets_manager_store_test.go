@@ -0,0 +1,166 @@
+package secretstore_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/alphagov/paas-rds-broker/secretstore"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+)
+
+var _ = Describe("Secrets Manager Store", func() {
+	var (
+		kmsKeyID string
+
+		secretsmanagersvc *secretsmanager.SecretsManager
+		operations        []string
+		inputs            []interface{}
+		errs              map[string]error
+
+		logger lager.Logger
+
+		secretStore SecretStore
+	)
+
+	BeforeEach(func() {
+		kmsKeyID = ""
+		operations = nil
+		inputs = nil
+		errs = map[string]error{}
+	})
+
+	JustBeforeEach(func() {
+		secretsmanagersvc = secretsmanager.New(session.New(nil))
+		secretsmanagersvc.Handlers.Clear()
+		secretsmanagersvc.Handlers.Send.PushBack(func(r *request.Request) {
+			operations = append(operations, r.Operation.Name)
+			inputs = append(inputs, r.Params)
+			if output, ok := r.Data.(*secretsmanager.GetSecretValueOutput); ok {
+				output.SecretString = aws.String("secret-value")
+			}
+			r.Error = errs[r.Operation.Name]
+		})
+
+		logger = lager.NewLogger("secretsmanagerstore_test")
+		logger.RegisterSink(lagertest.NewTestSink())
+
+		secretStore = NewSecretsManagerStore(kmsKeyID, secretsmanagersvc, logger)
+	})
+
+	Describe("Get", func() {
+		It("returns the value of the secret", func() {
+			value, err := secretStore.Get("secret-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal("secret-value"))
+			Expect(inputs).To(Equal([]interface{}{
+				&secretsmanager.GetSecretValueInput{SecretId: aws.String("secret-name")},
+			}))
+		})
+
+		Context("when the secret does not exist", func() {
+			BeforeEach(func() {
+				errs["GetSecretValue"] = awserr.New("ResourceNotFoundException", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				_, err := secretStore.Get("secret-name")
+				Expect(err).To(Equal(ErrSecretDoesNotExist))
+			})
+		})
+
+		Context("when getting the secret fails", func() {
+			BeforeEach(func() {
+				errs["GetSecretValue"] = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				_, err := secretStore.Get("secret-name")
+				Expect(err).To(MatchError("code: message"))
+			})
+		})
+	})
+
+	Describe("Create", func() {
+		It("creates the secret with its tags", func() {
+			err := secretStore.Create("secret-name", "secret-value", map[string]string{"b": "2", "a": "1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputs).To(Equal([]interface{}{
+				&secretsmanager.CreateSecretInput{
+					Name:         aws.String("secret-name"),
+					SecretString: aws.String("secret-value"),
+					Tags: []*secretsmanager.Tag{
+						{Key: aws.String("a"), Value: aws.String("1")},
+						{Key: aws.String("b"), Value: aws.String("2")},
+					},
+				},
+			}))
+		})
+
+		Context("when a KMS key is configured", func() {
+			BeforeEach(func() {
+				kmsKeyID = "kms-key-id"
+			})
+
+			It("encrypts the secret with the KMS key", func() {
+				err := secretStore.Create("secret-name", "secret-value", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(inputs).To(HaveLen(1))
+				Expect(inputs[0].(*secretsmanager.CreateSecretInput).KmsKeyId).To(Equal(aws.String("kms-key-id")))
+			})
+		})
+
+		Context("when the secret already exists", func() {
+			BeforeEach(func() {
+				errs["CreateSecret"] = awserr.New("ResourceExistsException", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error without overwriting it", func() {
+				err := secretStore.Create("secret-name", "secret-value", map[string]string{"a": "1"})
+				Expect(err).To(Equal(ErrSecretAlreadyExists))
+				Expect(operations).To(Equal([]string{"CreateSecret"}))
+			})
+		})
+
+		Context("when creating the secret fails", func() {
+			BeforeEach(func() {
+				errs["CreateSecret"] = awserr.New("code", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := secretStore.Create("secret-name", "secret-value", nil)
+				Expect(err).To(MatchError("code: message"))
+				Expect(operations).To(Equal([]string{"CreateSecret"}))
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the secret", func() {
+			err := secretStore.Delete("secret-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputs).To(Equal([]interface{}{
+				&secretsmanager.DeleteSecretInput{SecretId: aws.String("secret-name")},
+			}))
+		})
+
+		Context("when the secret does not exist", func() {
+			BeforeEach(func() {
+				errs["DeleteSecret"] = awserr.New("ResourceNotFoundException", "message", errors.New("operation failed"))
+			})
+
+			It("returns the proper error", func() {
+				err := secretStore.Delete("secret-name")
+				Expect(err).To(Equal(ErrSecretDoesNotExist))
+			})
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"fmt"
 	"io"
 )
 
@@ -55,3 +56,28 @@ func makeNonce(size int) ([]byte, error) {
 	_, err := io.ReadFull(rand.Reader, nonce)
 	return nonce, err
 }
+
+// StateEncryptionKeys are the keys secrets stored in the broker state may be
+// encrypted with, by key ID, and the ID of the key new secrets are encrypted
+// with. Secrets stored before key IDs were recorded have the empty key ID.
+type StateEncryptionKeys struct {
+	ActiveKeyID string
+	Keys        map[string]string
+}
+
+func (k StateEncryptionKeys) encrypt(plaintext string) (keyID string, ciphertext string, err error) {
+	key, ok := k.Keys[k.ActiveKeyID]
+	if !ok {
+		return "", "", fmt.Errorf("Unknown state encryption key ID '%s'", k.ActiveKeyID)
+	}
+	ciphertext, err = encryptString(key, plaintext)
+	return k.ActiveKeyID, ciphertext, err
+}
+
+func (k StateEncryptionKeys) decrypt(keyID string, ciphertext string) (string, error) {
+	key, ok := k.Keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("Unknown state encryption key ID '%s'", keyID)
+	}
+	return decryptString(key, ciphertext)
+}
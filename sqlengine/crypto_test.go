@@ -42,3 +42,45 @@ var _ = Describe("crypto functions", func() {
 		Expect(err).To(MatchError(ContainSubstring("message authentication failed")))
 	})
 })
+
+var _ = Describe("StateEncryptionKeys", func() {
+	var keys StateEncryptionKeys
+
+	BeforeEach(func() {
+		keys = StateEncryptionKeys{
+			ActiveKeyID: "new",
+			Keys: map[string]string{
+				"":    "legacy key",
+				"new": "new key",
+			},
+		}
+	})
+
+	It("encrypts with the active key and records its ID", func() {
+		keyID, encrypted, err := keys.encrypt("a secret message")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keyID).To(Equal("new"))
+
+		decrypted, err := decryptString("new key", encrypted)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decrypted).To(Equal("a secret message"))
+	})
+
+	It("decrypts with the key of the given ID", func() {
+		encrypted, err := encryptString("legacy key", "a secret message")
+		Expect(err).NotTo(HaveOccurred())
+
+		decrypted, err := keys.decrypt("", encrypted)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decrypted).To(Equal("a secret message"))
+	})
+
+	It("refuses unknown key IDs", func() {
+		_, err := keys.decrypt("retired", "ciphertext")
+		Expect(err).To(MatchError("Unknown state encryption key ID 'retired'"))
+
+		keys.ActiveKeyID = "missing"
+		_, _, err = keys.encrypt("a secret message")
+		Expect(err).To(MatchError("Unknown state encryption key ID 'missing'"))
+	})
+})
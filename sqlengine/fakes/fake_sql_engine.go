@@ -2,6 +2,8 @@ package fakes
 
 import (
 	"fmt"
+
+	"github.com/alphagov/paas-rds-broker/sqlengine"
 )
 
 type FakeSQLEngine struct {
@@ -12,12 +14,16 @@ type FakeSQLEngine struct {
 	OpenUsername string
 	OpenPassword string
 	OpenError    error
+	// OpenAcceptedPassword, when set, is the only password accepted by Open,
+	// which fails with LoginFailedError for the others.
+	OpenAcceptedPassword string
 
 	CloseCalled bool
 
 	CreateUserCalled    bool
 	CreateUserBindingID string
 	CreateUserDBName    string
+	CreateUserOptions   sqlengine.UserOptions
 	// returns
 	CreateUserUsername string
 	CreateUserPassword string
@@ -26,6 +32,51 @@ type FakeSQLEngine struct {
 	DropUserCalled    bool
 	DropUserBindingID string
 	DropUserError     error
+
+	DropExpiredUsersCalled bool
+	DropExpiredUsersCount  int
+	DropExpiredUsersError  error
+
+	ListServiceKeyUsersCalled bool
+	// returns
+	ListServiceKeyUsersUsers []sqlengine.ServiceKeyUser
+	ListServiceKeyUsersError error
+
+	CreateIAMUserCalled    bool
+	CreateIAMUserBindingID string
+	CreateIAMUserDBName    string
+	// returns
+	CreateIAMUserUsername string
+	CreateIAMUserError    error
+
+	DropIAMUserCalled    bool
+	DropIAMUserBindingID string
+	DropIAMUserError     error
+
+	ExecuteStatementsCalled     bool
+	ExecuteStatementsStatements []string
+	ExecuteStatementsError      error
+
+	ListExtensionsCalled bool
+	// returns
+	ListExtensionsExtensions []string
+	ListExtensionsError      error
+
+	CreateExtensionsCalled     bool
+	CreateExtensionsExtensions []string
+	CreateExtensionsError      error
+
+	DropExtensionsCalled     bool
+	DropExtensionsExtensions []string
+	DropExtensionsError      error
+
+	ReencryptStateCalled bool
+	ReencryptStateCount  int
+	ReencryptStateError  error
+
+	MigrateStateCalled bool
+	MigrateStateCount  int
+	MigrateStateError  error
 }
 
 func (f *FakeSQLEngine) Open(address string, port int64, dbname string, username string, password string) error {
@@ -36,6 +87,10 @@ func (f *FakeSQLEngine) Open(address string, port int64, dbname string, username
 	f.OpenUsername = username
 	f.OpenPassword = password
 
+	if f.OpenAcceptedPassword != "" && password != f.OpenAcceptedPassword {
+		return sqlengine.LoginFailedError
+	}
+
 	return f.OpenError
 }
 
@@ -43,10 +98,11 @@ func (f *FakeSQLEngine) Close() {
 	f.CloseCalled = true
 }
 
-func (f *FakeSQLEngine) CreateUser(bindingID, dbname string) (username, password string, err error) {
+func (f *FakeSQLEngine) CreateUser(bindingID, dbname string, options sqlengine.UserOptions) (username, password string, err error) {
 	f.CreateUserCalled = true
 	f.CreateUserBindingID = bindingID
 	f.CreateUserDBName = dbname
+	f.CreateUserOptions = options
 
 	return f.CreateUserUsername, f.CreateUserPassword, f.CreateUserError
 }
@@ -58,6 +114,72 @@ func (f *FakeSQLEngine) DropUser(bindingID string) error {
 	return f.DropUserError
 }
 
+func (f *FakeSQLEngine) DropExpiredUsers() (int, error) {
+	f.DropExpiredUsersCalled = true
+
+	return f.DropExpiredUsersCount, f.DropExpiredUsersError
+}
+
+func (f *FakeSQLEngine) ListServiceKeyUsers() ([]sqlengine.ServiceKeyUser, error) {
+	f.ListServiceKeyUsersCalled = true
+
+	return f.ListServiceKeyUsersUsers, f.ListServiceKeyUsersError
+}
+
+func (f *FakeSQLEngine) CreateIAMUser(bindingID, dbname string) (string, error) {
+	f.CreateIAMUserCalled = true
+	f.CreateIAMUserBindingID = bindingID
+	f.CreateIAMUserDBName = dbname
+
+	return f.CreateIAMUserUsername, f.CreateIAMUserError
+}
+
+func (f *FakeSQLEngine) DropIAMUser(bindingID string) error {
+	f.DropIAMUserCalled = true
+	f.DropIAMUserBindingID = bindingID
+
+	return f.DropIAMUserError
+}
+
+func (f *FakeSQLEngine) ExecuteStatements(statements []string) error {
+	f.ExecuteStatementsCalled = true
+	f.ExecuteStatementsStatements = statements
+
+	return f.ExecuteStatementsError
+}
+
+func (f *FakeSQLEngine) ListExtensions() ([]string, error) {
+	f.ListExtensionsCalled = true
+
+	return f.ListExtensionsExtensions, f.ListExtensionsError
+}
+
+func (f *FakeSQLEngine) CreateExtensions(extensions []string) error {
+	f.CreateExtensionsCalled = true
+	f.CreateExtensionsExtensions = extensions
+
+	return f.CreateExtensionsError
+}
+
+func (f *FakeSQLEngine) DropExtensions(extensions []string) error {
+	f.DropExtensionsCalled = true
+	f.DropExtensionsExtensions = extensions
+
+	return f.DropExtensionsError
+}
+
+func (f *FakeSQLEngine) ReencryptState() (int, error) {
+	f.ReencryptStateCalled = true
+
+	return f.ReencryptStateCount, f.ReencryptStateError
+}
+
+func (f *FakeSQLEngine) MigrateState() (int, error) {
+	f.MigrateStateCalled = true
+
+	return f.MigrateStateCount, f.MigrateStateError
+}
+
 func (f *FakeSQLEngine) URI(address string, port int64, dbname string, username string, password string) string {
 	return fmt.Sprintf("fake://%s:%s@%s:%d/%s?reconnect=true", username, password, address, port, dbname)
 }
@@ -3,6 +3,7 @@ package sqlengine
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql" // MySQL Driver
 
@@ -10,17 +11,23 @@ import (
 )
 
 type MySQLEngine struct {
-	logger lager.Logger
-	db     *sql.DB
+	logger     lager.Logger
+	stateStore StateStore
+	db         *sql.DB
+	address    string
 }
 
-func NewMySQLEngine(logger lager.Logger) *MySQLEngine {
+// NewMySQLEngine returns a MySQL engine keeping when the users of expiring
+// bindings expire in stateStore. Without a state store, users cannot expire.
+func NewMySQLEngine(logger lager.Logger, stateStore StateStore) *MySQLEngine {
 	return &MySQLEngine{
-		logger: logger.Session("mysql-engine"),
+		logger:     logger.Session("mysql-engine"),
+		stateStore: stateStore,
 	}
 }
 
 func (d *MySQLEngine) Open(address string, port int64, dbname string, username string, password string) error {
+	d.address = address
 	connectionString := d.connectionString(address, port, dbname, username, password)
 	d.logger.Debug("sql-open", lager.Data{"connection-string": connectionString})
 
@@ -40,19 +47,44 @@ func (d *MySQLEngine) Close() {
 	}
 }
 
-func (d *MySQLEngine) CreateUser(bindingID, dbname string) (username, password string, err error) {
+// CreateUser creates the user of the binding. When ValidUntil is set, the user
+// is dropped by DropExpiredUsers once it has expired. MySQL has no session
+// settings by user.
+func (d *MySQLEngine) CreateUser(bindingID, dbname string, options UserOptions) (username, password string, err error) {
+	if options.ServiceKey && d.stateStore == nil {
+		return "", "", ErrServiceKeysNotSupported
+	}
+	if !options.ValidUntil.IsZero() && d.stateStore == nil {
+		return "", "", ErrExpiringUsersNotSupported
+	}
+	if options.StatementTimeout > 0 || options.IdleInTransactionSessionTimeout > 0 {
+		return "", "", ErrUserSettingsNotSupported
+	}
+
 	username = generateUsername(bindingID)
 	password = generatePassword()
 
-	createUserStatement := "CREATE USER '" + username + "' IDENTIFIED BY '" + password + "'"
-	d.logger.Debug("create-user", lager.Data{"statement": createUserStatement})
+	var (
+		createUserStatement          = "CREATE USER '" + username + "' IDENTIFIED BY '" + password + "'"
+		sanitizedCreateUserStatement = "CREATE USER '" + username + "' IDENTIFIED BY 'REDACTED'"
+	)
+	if options.ConnectionLimit > 0 {
+		userOptionsClause := fmt.Sprintf(" WITH MAX_USER_CONNECTIONS %d", options.ConnectionLimit)
+		createUserStatement += userOptionsClause
+		sanitizedCreateUserStatement += userOptionsClause
+	}
+	d.logger.Debug("create-user", lager.Data{"statement": sanitizedCreateUserStatement})
 
 	if _, err := d.db.Exec(createUserStatement); err != nil {
 		d.logger.Error("sql-error", err)
 		return "", "", err
 	}
 
-	grantPrivilegesStatement := "GRANT ALL PRIVILEGES ON " + dbname + ".* TO '" + username + "'@'%'"
+	privileges := "ALL PRIVILEGES"
+	if options.ReadOnly {
+		privileges = "SELECT"
+	}
+	grantPrivilegesStatement := "GRANT " + privileges + " ON " + dbname + ".* TO '" + username + "'@'%'"
 	d.logger.Debug("grant-privileges", lager.Data{"statement": grantPrivilegesStatement})
 
 	if _, err := d.db.Exec(grantPrivilegesStatement); err != nil {
@@ -60,13 +92,27 @@ func (d *MySQLEngine) CreateUser(bindingID, dbname string) (username, password s
 		return "", "", err
 	}
 
+	if !options.ValidUntil.IsZero() {
+		if err := d.stateStore.PutExpiringUser(d.address, username, options.ValidUntil); err != nil {
+			return "", "", err
+		}
+	}
+
+	if options.ServiceKey {
+		if err := recordServiceKeyUser(d.stateStore, d.address, bindingID, username, options); err != nil {
+			return "", "", err
+		}
+	}
+
 	return username, password, nil
 }
 
+// DropUser drops the user of the binding. It does nothing if the user has
+// already been dropped, e.g. by DropExpiredUsers.
 func (d *MySQLEngine) DropUser(bindingID string) error {
 	username := generateUsername(bindingID)
 
-	dropUserStatement := "DROP USER '" + username + "'@'%'"
+	dropUserStatement := "DROP USER IF EXISTS '" + username + "'@'%'"
 	d.logger.Debug("drop-user", lager.Data{"statement": dropUserStatement})
 
 	if _, err := d.db.Exec(dropUserStatement); err != nil {
@@ -74,9 +120,124 @@ func (d *MySQLEngine) DropUser(bindingID string) error {
 		return err
 	}
 
+	if d.stateStore != nil {
+		if err := d.stateStore.DeleteExpiringUser(d.address, username); err != nil {
+			return err
+		}
+		return d.stateStore.DeleteServiceKeyUser(d.address, bindingID)
+	}
+
 	return nil
 }
 
+// ListServiceKeyUsers returns the recorded users of the service keys of the
+// DB instance.
+func (d *MySQLEngine) ListServiceKeyUsers() ([]ServiceKeyUser, error) {
+	if d.stateStore == nil {
+		return nil, ErrServiceKeysNotSupported
+	}
+
+	return d.stateStore.ListServiceKeyUsers(d.address)
+}
+
+// DropExpiredUsers drops the users of the expiring bindings which have
+// expired, and returns how many were dropped.
+func (d *MySQLEngine) DropExpiredUsers() (int, error) {
+	if d.stateStore == nil {
+		return 0, nil
+	}
+
+	now := time.Now()
+	usernames, err := d.stateStore.ListExpiredUsers(d.address, now)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, username := range usernames {
+		dropUserStatement := "DROP USER IF EXISTS '" + username + "'@'%'"
+		d.logger.Debug("drop-user", lager.Data{"statement": dropUserStatement})
+
+		if _, err := d.db.Exec(dropUserStatement); err != nil {
+			d.logger.Error("sql-error", err)
+			return i, err
+		}
+
+		if err := d.stateStore.DeleteExpiringUser(d.address, username); err != nil {
+			return i, err
+		}
+	}
+
+	// The users of the expired service keys were dropped with the others
+	serviceKeyUsers, err := expiredServiceKeyUsers(d.stateStore, d.address, now)
+	if err != nil {
+		return len(usernames), err
+	}
+	for _, user := range serviceKeyUsers {
+		if err := d.stateStore.DeleteServiceKeyUser(d.address, user.BindingID); err != nil {
+			return len(usernames), err
+		}
+	}
+
+	return len(usernames), nil
+}
+
+// CreateIAMUser creates a user for the binding which logs in with IAM
+// authentication tokens instead of a password. It does not create the user
+// again when a bind is retried.
+func (d *MySQLEngine) CreateIAMUser(bindingID, dbname string) (string, error) {
+	username := generateUsername(bindingID)
+
+	createUserStatement := "CREATE USER IF NOT EXISTS '" + username + "' IDENTIFIED WITH AWSAuthenticationPlugin AS 'RDS'"
+	d.logger.Debug("create-user", lager.Data{"statement": createUserStatement})
+
+	if _, err := d.db.Exec(createUserStatement); err != nil {
+		d.logger.Error("sql-error", err)
+		return "", err
+	}
+
+	grantPrivilegesStatement := "GRANT ALL PRIVILEGES ON " + dbname + ".* TO '" + username + "'@'%'"
+	d.logger.Debug("grant-privileges", lager.Data{"statement": grantPrivilegesStatement})
+
+	if _, err := d.db.Exec(grantPrivilegesStatement); err != nil {
+		d.logger.Error("sql-error", err)
+		return "", err
+	}
+
+	return username, nil
+}
+
+// DropIAMUser drops the user of the binding, named like the users with a
+// password.
+func (d *MySQLEngine) DropIAMUser(bindingID string) error {
+	return d.DropUser(bindingID)
+}
+
+func (d *MySQLEngine) ExecuteStatements(statements []string) error {
+	return executeStatements(d.db, d.logger, statements)
+}
+
+func (d *MySQLEngine) ListExtensions() ([]string, error) {
+	return nil, ErrExtensionsNotSupported
+}
+
+func (d *MySQLEngine) CreateExtensions(extensions []string) error {
+	return ErrExtensionsNotSupported
+}
+
+func (d *MySQLEngine) DropExtensions(extensions []string) error {
+	return ErrExtensionsNotSupported
+}
+
+// ReencryptState does nothing, as MySQL keeps no broker state.
+func (d *MySQLEngine) ReencryptState() (int, error) {
+	return 0, nil
+}
+
+// MigrateState does nothing, as MySQL keeps no broker state.
+func (d *MySQLEngine) MigrateState() (int, error) {
+	return 0, nil
+}
+
 func (d *MySQLEngine) URI(address string, port int64, dbname string, username string, password string) string {
 	return fmt.Sprintf("mysql://%s:%s@%s:%d/%s?reconnect=true", username, password, address, port, dbname)
 }
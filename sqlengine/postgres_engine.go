@@ -3,6 +3,7 @@ package sqlengine
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq" // PostgreSQL Driver
 
@@ -10,19 +11,23 @@ import (
 )
 
 type PostgresEngine struct {
-	logger             lager.Logger
-	stateEncryptionKey string
-	db                 *sql.DB
-	address            string
-	port               int64
-	username           string
-	password           string
+	logger              lager.Logger
+	stateEncryptionKeys StateEncryptionKeys
+	stateStore          StateStore
+	db                  *sql.DB
+	address             string
+	port                int64
+	username            string
+	password            string
 }
 
-func NewPostgresEngine(logger lager.Logger, stateEncryptionKey string) *PostgresEngine {
+// NewPostgresEngine returns a PostgreSQL engine keeping the broker state in
+// stateStore or, when it is nil, in a broker_state database of each instance.
+func NewPostgresEngine(logger lager.Logger, stateEncryptionKeys StateEncryptionKeys, stateStore StateStore) *PostgresEngine {
 	return &PostgresEngine{
-		logger:             logger.Session("postgres-engine"),
-		stateEncryptionKey: stateEncryptionKey,
+		logger:              logger.Session("postgres-engine"),
+		stateEncryptionKeys: stateEncryptionKeys,
+		stateStore:          stateStore,
 	}
 }
 
@@ -63,8 +68,33 @@ func (d *PostgresEngine) Close() {
 	}
 }
 
-func (d *PostgresEngine) CreateUser(bindingID, dbname string) (username, password string, err error) {
-	stateDB, err := d.openStateDB(d.logger, d.stateEncryptionKey)
+// CreateUser returns the user shared by the bindings of the database, creating
+// it if needed, or, when options are set, a user of the binding's own which
+// has the privileges of the shared user, or only reads its tables.
+func (d *PostgresEngine) CreateUser(bindingID, dbname string, options UserOptions) (string, string, error) {
+	if options.ServiceKey && d.stateStore == nil {
+		return "", "", ErrServiceKeysNotSupported
+	}
+
+	username, password, err := d.createDatabaseUser(dbname)
+	if err != nil || options == (UserOptions{}) {
+		return username, password, err
+	}
+
+	username, password, err = d.createBindingUser(bindingID, dbname, username, options)
+	if err != nil || !options.ServiceKey {
+		return username, password, err
+	}
+
+	return username, password, recordServiceKeyUser(d.stateStore, d.address, bindingID, username, options)
+}
+
+func (d *PostgresEngine) createDatabaseUser(dbname string) (username, password string, err error) {
+	if d.stateStore != nil {
+		return d.createUserInStateStore(dbname)
+	}
+
+	stateDB, err := d.openStateDB(d.logger, d.stateEncryptionKeys)
 	if err != nil {
 		return "", "", err
 	}
@@ -127,12 +157,325 @@ func (d *PostgresEngine) CreateUser(bindingID, dbname string) (username, passwor
 	return username, password, nil
 }
 
+// createBindingUser creates the user of a binding with its own, or updates it
+// when a bind is retried. The sessions of the users which are not read-only
+// take the role of the shared user, so that it owns the objects they create.
+func (d *PostgresEngine) createBindingUser(bindingID, dbname, databaseUsername string, options UserOptions) (string, string, error) {
+	username := generateUsername(bindingID)
+	password := generatePassword()
+
+	exists, err := d.roleExists(username)
+	if err != nil {
+		return "", "", err
+	}
+
+	userOptionsClause := ""
+	if !options.ValidUntil.IsZero() {
+		userOptionsClause += " VALID UNTIL '" + options.ValidUntil.UTC().Format(time.RFC3339) + "'"
+	}
+	if options.ConnectionLimit > 0 {
+		userOptionsClause += fmt.Sprintf(" CONNECTION LIMIT %d", options.ConnectionLimit)
+	}
+
+	userStatement := "CREATE USER "
+	if exists {
+		userStatement = "ALTER USER "
+	}
+	var (
+		createUserStatement          = userStatement + pq.QuoteIdentifier(username) + " WITH PASSWORD '" + password + "'" + userOptionsClause
+		sanitizedCreateUserStatement = userStatement + pq.QuoteIdentifier(username) + " WITH PASSWORD 'REDACTED'" + userOptionsClause
+	)
+	d.logger.Debug("create-user", lager.Data{"statement": sanitizedCreateUserStatement})
+	statements := []string{createUserStatement}
+
+	if !options.ReadOnly {
+		statements = append(statements,
+			"GRANT "+pq.QuoteIdentifier(databaseUsername)+" TO "+pq.QuoteIdentifier(username),
+			"ALTER ROLE "+pq.QuoteIdentifier(username)+" SET role = "+pq.QuoteIdentifier(databaseUsername),
+		)
+	}
+
+	// Session settings apply to the sessions the user opens from then on
+	settings := []struct {
+		name  string
+		value int64
+	}{
+		{"statement_timeout", options.StatementTimeout},
+		{"idle_in_transaction_session_timeout", options.IdleInTransactionSessionTimeout},
+	}
+	for _, setting := range settings {
+		if setting.value > 0 {
+			setStatement := fmt.Sprintf("ALTER ROLE %s SET %s = %d", pq.QuoteIdentifier(username), setting.name, setting.value)
+			d.logger.Debug("set-user-setting", lager.Data{"statement": setStatement})
+			statements = append(statements, setStatement)
+		}
+	}
+
+	if options.ReadOnly {
+		statements = append(statements, readOnlyStatements(dbname, databaseUsername, username)...)
+	} else {
+		grantPrivilegesStatement := "GRANT ALL PRIVILEGES ON DATABASE " + pq.QuoteIdentifier(dbname) + " TO " + pq.QuoteIdentifier(username)
+		d.logger.Debug("grant-privileges", lager.Data{"statement": grantPrivilegesStatement})
+		statements = append(statements, grantPrivilegesStatement)
+	}
+
+	loggedStatements := append([]string{sanitizedCreateUserStatement}, statements[1:]...)
+	if err := executeRedactedStatements(d.db, d.logger, statements, loggedStatements); err != nil {
+		return "", "", err
+	}
+
+	return username, password, nil
+}
+
+// readOnlyStatements grant a user reading the tables of the public schema which
+// the shared user owns, including the tables it creates later. The grants are
+// made as the shared user, which the master user becomes a member of.
+func readOnlyStatements(dbname, databaseUsername, username string) []string {
+	return []string{
+		"GRANT CONNECT ON DATABASE " + pq.QuoteIdentifier(dbname) + " TO " + pq.QuoteIdentifier(username),
+		"GRANT " + pq.QuoteIdentifier(databaseUsername) + " TO CURRENT_USER",
+		"SET LOCAL ROLE " + pq.QuoteIdentifier(databaseUsername),
+		"GRANT USAGE ON SCHEMA public TO " + pq.QuoteIdentifier(username),
+		"GRANT SELECT ON ALL TABLES IN SCHEMA public TO " + pq.QuoteIdentifier(username),
+		"GRANT SELECT ON ALL SEQUENCES IN SCHEMA public TO " + pq.QuoteIdentifier(username),
+		"ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT ON TABLES TO " + pq.QuoteIdentifier(username),
+		"RESET ROLE",
+	}
+}
+
+// DropUser drops the user of a binding with its own. The user shared by the other
+// bindings is retained for all bound applications.
 func (d *PostgresEngine) DropUser(bindingID string) error {
-	// For PostgreSQL we don't drop the user because we retain a single user for all bound applications
+	if err := d.dropBindingUser(generateUsername(bindingID)); err != nil {
+		return err
+	}
 
+	if d.stateStore != nil {
+		return d.stateStore.DeleteServiceKeyUser(d.address, bindingID)
+	}
 	return nil
 }
 
+// ListServiceKeyUsers returns the recorded users of the service keys of the
+// DB instance.
+func (d *PostgresEngine) ListServiceKeyUsers() ([]ServiceKeyUser, error) {
+	if d.stateStore == nil {
+		return nil, ErrServiceKeysNotSupported
+	}
+
+	return d.stateStore.ListServiceKeyUsers(d.address)
+}
+
+// DropExpiredUsers drops the users of the expiring bindings of the database
+// which can no longer log in, and the users of the expired service keys of the
+// DB instance, and returns how many were dropped.
+func (d *PostgresEngine) DropExpiredUsers() (int, error) {
+	databaseUsername, err := d.databaseUsername()
+	if err != nil {
+		return 0, err
+	}
+
+	statement := "SELECT r.rolname FROM pg_roles r JOIN pg_auth_members m ON m.member = r.oid JOIN pg_roles g ON g.oid = m.roleid WHERE g.rolname = $1 AND r.rolvaliduntil < now()"
+	d.logger.Debug("list-expired-users", lager.Data{"statement": statement})
+	rows, err := d.db.Query(statement, databaseUsername)
+	if err != nil {
+		d.logger.Error("sql-error", err)
+		return 0, err
+	}
+	usernames := []string{}
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			rows.Close()
+			d.logger.Error("sql-error", err)
+			return 0, err
+		}
+		usernames = append(usernames, username)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		d.logger.Error("sql-error", err)
+		return 0, err
+	}
+
+	for i, username := range usernames {
+		if err := d.dropBindingUser(username); err != nil {
+			return i, err
+		}
+	}
+
+	if d.stateStore == nil {
+		return len(usernames), nil
+	}
+
+	// Read-only service key users are not members of the shared user
+	serviceKeyUsers, err := expiredServiceKeyUsers(d.stateStore, d.address, time.Now())
+	if err != nil {
+		return len(usernames), err
+	}
+	dropped := len(usernames)
+	for _, user := range serviceKeyUsers {
+		if user.ReadOnly {
+			if err := d.dropBindingUser(user.Username); err != nil {
+				return dropped, err
+			}
+			dropped++
+		}
+		if err := d.stateStore.DeleteServiceKeyUser(d.address, user.BindingID); err != nil {
+			return dropped, err
+		}
+	}
+
+	return dropped, nil
+}
+
+// CreateIAMUser creates a user of its own for the binding, which logs in with
+// IAM authentication tokens instead of a password and has the privileges of the
+// shared user, like the users of the bindings with a password. It does not
+// create the user again when a bind is retried.
+func (d *PostgresEngine) CreateIAMUser(bindingID, dbname string) (string, error) {
+	databaseUsername, _, err := d.createDatabaseUser(dbname)
+	if err != nil {
+		return "", err
+	}
+
+	username := generateUsername(bindingID)
+	exists, err := d.roleExists(username)
+	if err != nil {
+		return "", err
+	}
+
+	statements := []string{}
+	if !exists {
+		statements = append(statements, "CREATE USER "+pq.QuoteIdentifier(username))
+	}
+
+	return username, executeStatements(d.db, d.logger, append(statements,
+		"GRANT rds_iam TO "+pq.QuoteIdentifier(username),
+		"GRANT "+pq.QuoteIdentifier(databaseUsername)+" TO "+pq.QuoteIdentifier(username),
+		"ALTER ROLE "+pq.QuoteIdentifier(username)+" SET role = "+pq.QuoteIdentifier(databaseUsername),
+		"GRANT ALL PRIVILEGES ON DATABASE "+pq.QuoteIdentifier(dbname)+" TO "+pq.QuoteIdentifier(username),
+	))
+}
+
+// DropIAMUser drops the user of the binding, handing the objects it owns over
+// to the shared user. It does nothing if the user does not exist.
+func (d *PostgresEngine) DropIAMUser(bindingID string) error {
+	return d.dropBindingUser(generateUsername(bindingID))
+}
+
+// dropBindingUser drops the user of a binding, handing the objects it owns
+// over to the shared user, or to the master user when there is none.
+func (d *PostgresEngine) dropBindingUser(username string) error {
+	exists, err := d.roleExists(username)
+	if err != nil || !exists {
+		return err
+	}
+
+	databaseUsername, err := d.databaseUsername()
+	if err != nil {
+		return err
+	}
+	databaseUserExists, err := d.roleExists(databaseUsername)
+	if err != nil {
+		return err
+	}
+
+	statements := []string{}
+	newOwner := "CURRENT_USER"
+	if databaseUserExists {
+		newOwner = pq.QuoteIdentifier(databaseUsername)
+		statements = append(statements, "GRANT "+newOwner+" TO CURRENT_USER")
+	}
+
+	return executeStatements(d.db, d.logger, append(statements,
+		"REASSIGN OWNED BY "+pq.QuoteIdentifier(username)+" TO "+newOwner,
+		"DROP OWNED BY "+pq.QuoteIdentifier(username),
+		"DROP USER "+pq.QuoteIdentifier(username),
+	))
+}
+
+func (d *PostgresEngine) roleExists(rolname string) (bool, error) {
+	var exists bool
+	if err := d.db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)", rolname).Scan(&exists); err != nil {
+		d.logger.Error("sql-error", err)
+		return false, err
+	}
+	return exists, nil
+}
+
+// databaseUsername returns the name of the user shared by the bindings of the
+// database the engine is connected to.
+func (d *PostgresEngine) databaseUsername() (string, error) {
+	var dbname string
+	if err := d.db.QueryRow("SELECT current_database()").Scan(&dbname); err != nil {
+		d.logger.Error("sql-error", err)
+		return "", err
+	}
+	return generatePostgresUsername(dbname), nil
+}
+
+func (d *PostgresEngine) ExecuteStatements(statements []string) error {
+	return executeStatements(d.db, d.logger, statements)
+}
+
+func (d *PostgresEngine) ListExtensions() ([]string, error) {
+	rows, err := d.db.Query("SELECT extname FROM pg_extension ORDER BY extname")
+	if err != nil {
+		d.logger.Error("sql-error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	extensions := []string{}
+	for rows.Next() {
+		var extension string
+		if err := rows.Scan(&extension); err != nil {
+			d.logger.Error("sql-error", err)
+			return nil, err
+		}
+		extensions = append(extensions, extension)
+	}
+
+	return extensions, rows.Err()
+}
+
+func (d *PostgresEngine) CreateExtensions(extensions []string) error {
+	statements := make([]string, 0, len(extensions))
+	for _, extension := range extensions {
+		statements = append(statements, "CREATE EXTENSION IF NOT EXISTS "+pq.QuoteIdentifier(extension))
+	}
+
+	return executeStatements(d.db, d.logger, statements)
+}
+
+func (d *PostgresEngine) DropExtensions(extensions []string) error {
+	statements := make([]string, 0, len(extensions))
+	for _, extension := range extensions {
+		statements = append(statements, "DROP EXTENSION IF EXISTS "+pq.QuoteIdentifier(extension))
+	}
+
+	return executeStatements(d.db, d.logger, statements)
+}
+
+// ReencryptState encrypts the secrets of the broker state with the active
+// state encryption key, so that the other keys can be retired. It returns how
+// many secrets were re-encrypted, and does not create the broker state.
+func (d *PostgresEngine) ReencryptState() (int, error) {
+	exists, err := d.stateDBExists()
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	stateDB, err := d.openStateDB(d.logger, d.stateEncryptionKeys)
+	if err != nil {
+		return 0, err
+	}
+	defer stateDB.Close()
+
+	return stateDB.reencryptUsers()
+}
+
 func (d *PostgresEngine) URI(address string, port int64, dbname string, username string, password string) string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s", username, password, address, port, dbname)
 }
@@ -14,7 +14,7 @@ const stateDBName = "broker_state"
 // (which is stored in the database) will allow us to migrate more easily.
 const passwordStorageVersion = "1.0"
 
-func (d *PostgresEngine) openStateDB(logger lager.Logger, stateEncryptionKey string) (*postgresEngineState, error) {
+func (d *PostgresEngine) openStateDB(logger lager.Logger, stateEncryptionKeys StateEncryptionKeys) (*postgresEngineState, error) {
 	logger = logger.Session("postgres-engine-state")
 
 	statement := "CREATE DATABASE " + stateDBName
@@ -50,9 +50,9 @@ func (d *PostgresEngine) openStateDB(logger lager.Logger, stateEncryptionKey str
 	}
 
 	s := &postgresEngineState{
-		DB:                 db,
-		logger:             logger,
-		stateEncryptionKey: stateEncryptionKey,
+		DB:                  db,
+		logger:              logger,
+		stateEncryptionKeys: stateEncryptionKeys,
 	}
 
 	err = s.initSchema()
@@ -66,50 +66,180 @@ func (d *PostgresEngine) openStateDB(logger lager.Logger, stateEncryptionKey str
 
 type postgresEngineState struct {
 	*sql.DB
-	logger             lager.Logger
-	stateEncryptionKey string
+	logger              lager.Logger
+	stateEncryptionKeys StateEncryptionKeys
+}
+
+// stateDBMigrations are the schema migrations of the broker_state database.
+var stateDBMigrations = []schemaMigration{
+	migrationStatements("CREATE TABLE IF NOT EXISTS role (username varchar(128) NOT NULL, encrypted_password varchar(128) NOT NULL, password_storage_version varchar(10), PRIMARY KEY(username))"),
+	// Rows stored before key IDs were recorded have a NULL key_id
+	func(tx *sql.Tx, logger lager.Logger) error {
+		var exists bool
+		statement := "SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'role' AND column_name = 'key_id')"
+		logger.Debug("column-exists", lager.Data{"statement": statement})
+		if err := tx.QueryRow(statement).Scan(&exists); err != nil {
+			logger.Error("column-exists.sql-error", err)
+			return err
+		}
+		if exists {
+			// Added before migrations were recorded
+			return nil
+		}
+		return migrationStatements("ALTER TABLE role ADD COLUMN key_id varchar(64)")(tx, logger)
+	},
 }
 
 func (s *postgresEngineState) initSchema() error {
-	statement := "CREATE TABLE IF NOT EXISTS role (username varchar(128) NOT NULL, encrypted_password varchar(128) NOT NULL, password_storage_version varchar(10), PRIMARY KEY(username))"
-	s.logger.Debug("create-table", lager.Data{"statement": statement})
-	_, err := s.Exec(statement)
-	if err != nil {
-		s.logger.Error("create-table.sql-error", err)
-		return err
-	}
-	return nil
+	_, err := migrateSchema(s.DB, "postgres", s.logger, stateDBMigrations)
+	return err
 }
 
 func (s *postgresEngineState) fetchUserPassword(username string) (password string, ok bool, err error) {
-	var encryptedPassword string
-	statement := "SELECT encrypted_password FROM role WHERE username = $1"
+	encryptedPassword, keyID, ok, err := s.fetchEncryptedUserPassword(username)
+	if err != nil || !ok {
+		return "", false, err
+	}
+	password, err = s.stateEncryptionKeys.decrypt(keyID, encryptedPassword)
+	return password, (err == nil), err
+}
+
+func (s *postgresEngineState) fetchEncryptedUserPassword(username string) (encryptedPassword string, keyID string, ok bool, err error) {
+	var nullableKeyID sql.NullString
+	statement := "SELECT encrypted_password, key_id FROM role WHERE username = $1"
 	s.logger.Debug("fetch-user", lager.Data{"statement": statement, "params": []string{username}})
-	err = s.QueryRow(statement, username).Scan(&encryptedPassword)
+	err = s.QueryRow(statement, username).Scan(&encryptedPassword, &nullableKeyID)
 	if err == sql.ErrNoRows {
-		return "", false, nil
+		return "", "", false, nil
 	} else if err != nil {
 		s.logger.Error("fetch-user.sql-error", err)
-		return "", false, err
+		return "", "", false, err
 	}
-	password, err = decryptString(s.stateEncryptionKey, encryptedPassword)
-	return password, (err == nil), err
+	return encryptedPassword, nullableKeyID.String, true, nil
+}
+
+// listUsers returns the users stored in the state database, with their
+// passwords still encrypted.
+func (s *postgresEngineState) listUsers() ([]StateUser, error) {
+	statement := "SELECT username, encrypted_password, key_id FROM role ORDER BY username"
+	s.logger.Debug("list-users", lager.Data{"statement": statement})
+	rows, err := s.Query(statement)
+	if err != nil {
+		s.logger.Error("list-users.sql-error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []StateUser{}
+	for rows.Next() {
+		var (
+			user  StateUser
+			keyID sql.NullString
+		)
+		if err := rows.Scan(&user.Username, &user.EncryptedPassword, &keyID); err != nil {
+			s.logger.Error("list-users.sql-error", err)
+			return nil, err
+		}
+		user.KeyID = keyID.String
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error("list-users.sql-error", err)
+		return nil, err
+	}
+
+	return users, nil
 }
 
 func (s *postgresEngineState) storeUser(username, password string) error {
-	encryptedPassword, err := encryptString(s.stateEncryptionKey, password)
+	keyID, encryptedPassword, err := s.stateEncryptionKeys.encrypt(password)
 	if err != nil {
 		return err
 	}
-	statement := "INSERT INTO role (username, encrypted_password, password_storage_version) VALUES($1, $2, $3)"
+	statement := "INSERT INTO role (username, encrypted_password, password_storage_version, key_id) VALUES($1, $2, $3, $4)"
 	s.logger.Debug("insert-user", lager.Data{
 		"statement": statement,
-		"params":    []string{username, "REDACTED", passwordStorageVersion},
+		"params":    []string{username, "REDACTED", passwordStorageVersion, keyID},
 	})
-	_, err = s.Exec(statement, username, encryptedPassword, passwordStorageVersion)
+	_, err = s.Exec(statement, username, encryptedPassword, passwordStorageVersion, nullKeyID(keyID))
 	if err != nil {
 		s.logger.Error("insert-user.sql-error", err)
 		return err
 	}
 	return nil
 }
+
+// reencryptUsers encrypts the passwords stored with other keys with the active
+// key, and returns how many were re-encrypted.
+func (s *postgresEngineState) reencryptUsers() (int, error) {
+	tx, err := s.Begin()
+	if err != nil {
+		s.logger.Error("sql-error", err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	activeKeyID := s.stateEncryptionKeys.ActiveKeyID
+	statement := "SELECT username, encrypted_password, key_id FROM role WHERE key_id IS DISTINCT FROM $1 FOR UPDATE"
+	s.logger.Debug("select-users", lager.Data{"statement": statement, "params": []string{activeKeyID}})
+	rows, err := tx.Query(statement, nullKeyID(activeKeyID))
+	if err != nil {
+		s.logger.Error("select-users.sql-error", err)
+		return 0, err
+	}
+
+	passwords := map[string]string{}
+	for rows.Next() {
+		var (
+			username          string
+			encryptedPassword string
+			keyID             sql.NullString
+		)
+		if err := rows.Scan(&username, &encryptedPassword, &keyID); err != nil {
+			rows.Close()
+			s.logger.Error("select-users.sql-error", err)
+			return 0, err
+		}
+		password, err := s.stateEncryptionKeys.decrypt(keyID.String, encryptedPassword)
+		if err != nil {
+			rows.Close()
+			s.logger.Error("decrypt-user", err, lager.Data{"username": username, "key-id": keyID.String})
+			return 0, err
+		}
+		passwords[username] = password
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error("select-users.sql-error", err)
+		return 0, err
+	}
+	rows.Close()
+
+	statement = "UPDATE role SET encrypted_password = $2, password_storage_version = $3, key_id = $4 WHERE username = $1"
+	for username, password := range passwords {
+		keyID, encryptedPassword, err := s.stateEncryptionKeys.encrypt(password)
+		if err != nil {
+			return 0, err
+		}
+		s.logger.Debug("update-user", lager.Data{
+			"statement": statement,
+			"params":    []string{username, "REDACTED", passwordStorageVersion, keyID},
+		})
+		if _, err := tx.Exec(statement, username, encryptedPassword, passwordStorageVersion, nullKeyID(keyID)); err != nil {
+			s.logger.Error("update-user.sql-error", err)
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("commit.sql-error", err)
+		return 0, err
+	}
+
+	return len(passwords), nil
+}
+
+// nullKeyID records the empty key ID of the rows stored before key IDs were
+// recorded as NULL.
+func nullKeyID(keyID string) sql.NullString {
+	return sql.NullString{String: keyID, Valid: keyID != ""}
+}
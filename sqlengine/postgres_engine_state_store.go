@@ -0,0 +1,168 @@
+package sqlengine
+
+import (
+	"github.com/pivotal-golang/lager"
+)
+
+// createUserInStateStore creates the user of a database, keeping its password
+// in the state store. Users missing from the store are copied from the
+// broker_state database of the instance, if any, so that existing bindings
+// keep working before the state is migrated. Other existing users, such as
+// those of restored instances, are given a new password.
+func (d *PostgresEngine) createUserInStateStore(dbname string) (string, string, error) {
+	username := generatePostgresUsername(dbname)
+
+	user, ok, err := d.stateStore.GetUser(d.address, username)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok {
+		user, ok, err = d.migrateStateUser(username)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if ok {
+		// User already exists. Nothing further to do.
+		password, err := d.stateEncryptionKeys.decrypt(user.KeyID, user.EncryptedPassword)
+		if err != nil {
+			return "", "", err
+		}
+		return username, password, nil
+	}
+
+	password := generatePassword()
+	user = StateUser{Address: d.address, Username: username}
+	user.KeyID, user.EncryptedPassword, err = d.stateEncryptionKeys.encrypt(password)
+	if err != nil {
+		return "", "", err
+	}
+
+	var roleExists bool
+	if err := d.db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)", username).Scan(&roleExists); err != nil {
+		d.logger.Error("sql-error", err)
+		return "", "", err
+	}
+
+	command := "CREATE USER"
+	if roleExists {
+		command = "ALTER USER"
+	}
+	var (
+		userStatement          = command + " \"" + username + "\" WITH PASSWORD '" + password + "'"
+		sanitizedUserStatement = command + " \"" + username + "\" WITH PASSWORD 'REDACTED'"
+	)
+	d.logger.Debug("create-user", lager.Data{"statement": sanitizedUserStatement})
+
+	grantPrivilegesStatement := "GRANT ALL PRIVILEGES ON DATABASE \"" + dbname + "\" TO \"" + username + "\""
+	d.logger.Debug("grant-privileges", lager.Data{"statement": grantPrivilegesStatement})
+
+	statements := []string{userStatement, grantPrivilegesStatement}
+	loggedStatements := []string{sanitizedUserStatement, grantPrivilegesStatement}
+	if err := executeRedactedStatements(d.db, d.logger, statements, loggedStatements); err != nil {
+		return "", "", err
+	}
+
+	// Should storing fail, the next attempt finds the role and resets its
+	// password
+	if err := d.stateStore.PutUser(user); err != nil {
+		return "", "", err
+	}
+
+	return username, password, nil
+}
+
+// migrateStateUser copies a user from the broker_state database of the
+// instance to the state store, if it is there.
+func (d *PostgresEngine) migrateStateUser(username string) (StateUser, bool, error) {
+	exists, err := d.stateDBExists()
+	if err != nil || !exists {
+		return StateUser{}, false, err
+	}
+
+	stateDB, err := d.openStateDB(d.logger, d.stateEncryptionKeys)
+	if err != nil {
+		return StateUser{}, false, err
+	}
+	defer stateDB.Close()
+
+	encryptedPassword, keyID, ok, err := stateDB.fetchEncryptedUserPassword(username)
+	if err != nil || !ok {
+		return StateUser{}, false, err
+	}
+
+	// The state store may have been re-encrypted already
+	user, err := reencryptStateUser(StateUser{
+		Address:           d.address,
+		Username:          username,
+		EncryptedPassword: encryptedPassword,
+		KeyID:             keyID,
+	}, d.stateEncryptionKeys)
+	if err != nil {
+		return StateUser{}, false, err
+	}
+	if err := d.stateStore.PutUser(user); err != nil {
+		return StateUser{}, false, err
+	}
+
+	return user, true, nil
+}
+
+// MigrateState copies the users of the broker_state database of the instance
+// missing from the state store, with their password encrypted with the active
+// key, and returns how many were copied. The
+// broker_state database is left in place, and can be dropped once every
+// instance has been migrated.
+func (d *PostgresEngine) MigrateState() (int, error) {
+	if d.stateStore == nil {
+		return 0, nil
+	}
+
+	exists, err := d.stateDBExists()
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	stateDB, err := d.openStateDB(d.logger, d.stateEncryptionKeys)
+	if err != nil {
+		return 0, err
+	}
+	defer stateDB.Close()
+
+	users, err := stateDB.listUsers()
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, user := range users {
+		_, ok, err := d.stateStore.GetUser(d.address, user.Username)
+		if err != nil {
+			return migrated, err
+		}
+		if ok {
+			continue
+		}
+
+		user.Address = d.address
+		user, err = reencryptStateUser(user, d.stateEncryptionKeys)
+		if err != nil {
+			return migrated, err
+		}
+		if err := d.stateStore.PutUser(user); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+func (d *PostgresEngine) stateDBExists() (bool, error) {
+	var exists bool
+	if err := d.db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = $1)", stateDBName).Scan(&exists); err != nil {
+		d.logger.Error("sql-error", err)
+		return false, err
+	}
+	return exists, nil
+}
@@ -0,0 +1,134 @@
+package sqlengine
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+)
+
+// recordingDriver accepts every statement, and answers queries with a single
+// false value, such as a role not existing.
+type recordingDriver struct {
+	statements []string
+}
+
+var fakeDriver = &recordingDriver{}
+
+func init() {
+	sql.Register("recording", fakeDriver)
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return d, nil
+}
+
+func (d *recordingDriver) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{driver: d, query: query}, nil
+}
+
+func (d *recordingDriver) Close() error {
+	return nil
+}
+
+func (d *recordingDriver) Begin() (driver.Tx, error) {
+	return d, nil
+}
+
+func (d *recordingDriver) Commit() error {
+	return nil
+}
+
+func (d *recordingDriver) Rollback() error {
+	return nil
+}
+
+type recordingStmt struct {
+	driver *recordingDriver
+	query  string
+}
+
+func (s *recordingStmt) Close() error {
+	return nil
+}
+
+func (s *recordingStmt) NumInput() int {
+	return -1
+}
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.driver.statements = append(s.driver.statements, s.query)
+	return driver.RowsAffected(0), nil
+}
+
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &falseRows{}, nil
+}
+
+type falseRows struct {
+	done bool
+}
+
+func (r *falseRows) Columns() []string {
+	return []string{"exists"}
+}
+
+func (r *falseRows) Close() error {
+	return nil
+}
+
+func (r *falseRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = false
+	return nil
+}
+
+var _ = Describe("PostgresEngine", func() {
+	var (
+		testSink *lagertest.TestSink
+		engine   *PostgresEngine
+	)
+
+	BeforeEach(func() {
+		fakeDriver.statements = nil
+
+		db, err := sql.Open("recording", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger := lager.NewLogger("postgres_engine_test")
+		testSink = lagertest.NewTestSink()
+		logger.RegisterSink(testSink)
+
+		engine = &PostgresEngine{db: db, logger: logger}
+	})
+
+	Describe("createBindingUser", func() {
+		It("never logs the password of the user", func() {
+			username, password, err := engine.createBindingUser("binding-id", "dbname", "dbname_owner", UserOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(password).NotTo(BeEmpty())
+
+			Expect(fakeDriver.statements).NotTo(BeEmpty())
+			Expect(fakeDriver.statements[0]).To(Equal(`CREATE USER "` + username + `" WITH PASSWORD '` + password + `'`))
+
+			Expect(testSink.Logs()).NotTo(BeEmpty())
+			Expect(string(testSink.Buffer().Contents())).NotTo(ContainSubstring(password))
+			Expect(string(testSink.Buffer().Contents())).To(ContainSubstring("WITH PASSWORD 'REDACTED'"))
+		})
+
+		It("never logs the password of a read-only user", func() {
+			_, password, err := engine.createBindingUser("binding-id", "dbname", "dbname_owner", UserOptions{ReadOnly: true})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(testSink.Buffer().Contents())).NotTo(ContainSubstring(password))
+		})
+	})
+})
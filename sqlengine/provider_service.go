@@ -8,23 +8,25 @@ import (
 )
 
 type ProviderService struct {
-	logger             lager.Logger
-	stateEncryptionKey string
+	logger              lager.Logger
+	stateEncryptionKeys StateEncryptionKeys
+	stateStore          StateStore
 }
 
-func NewProviderService(logger lager.Logger, stateEncryptionKey string) *ProviderService {
+func NewProviderService(logger lager.Logger, stateEncryptionKeys StateEncryptionKeys, stateStore StateStore) *ProviderService {
 	return &ProviderService{
-		logger:             logger,
-		stateEncryptionKey: stateEncryptionKey,
+		logger:              logger,
+		stateEncryptionKeys: stateEncryptionKeys,
+		stateStore:          stateStore,
 	}
 }
 
 func (p *ProviderService) GetSQLEngine(engine string) (SQLEngine, error) {
 	switch strings.ToLower(engine) {
 	case "mariadb", "mysql":
-		return NewMySQLEngine(p.logger), nil
+		return NewMySQLEngine(p.logger, p.stateStore), nil
 	case "postgres", "postgresql":
-		return NewPostgresEngine(p.logger, p.stateEncryptionKey), nil
+		return NewPostgresEngine(p.logger, p.stateEncryptionKeys, p.stateStore), nil
 	}
 
 	return nil, fmt.Errorf("SQL Engine '%s' not supported", engine)
@@ -17,7 +17,7 @@ var _ = Describe("Provider Service", func() {
 
 	BeforeEach(func() {
 		logger = lager.NewLogger("provider_service_test")
-		sqlProvider = NewProviderService(logger, "encryption key")
+		sqlProvider = NewProviderService(logger, StateEncryptionKeys{Keys: map[string]string{"": "encryption key"}}, nil)
 	})
 
 	Describe("GetSQLEngine", func() {
@@ -0,0 +1,99 @@
+package sqlengine
+
+import (
+	"database/sql"
+
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/utils"
+)
+
+// schemaMigration changes the schema of a broker state database within a
+// transaction. Migrations are applied once, in order, and their version is
+// recorded, so they must never be changed or removed once released.
+type schemaMigration func(tx *sql.Tx, logger lager.Logger) error
+
+// migrationStatements returns a migration running statements in order.
+func migrationStatements(statements ...string) schemaMigration {
+	return func(tx *sql.Tx, logger lager.Logger) error {
+		for _, statement := range statements {
+			logger.Debug("execute-statement", lager.Data{"statement": statement})
+			if _, err := tx.Exec(statement); err != nil {
+				logger.Error("sql-error", err)
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// migrateSchema applies the migrations of a database it has not recorded in
+// its schema_migrations table yet, and returns its schema version. Databases
+// created before migrations were recorded start from version 0, so the first
+// migrations must accept the schema they may already have.
+func migrateSchema(db *sql.DB, driver string, logger lager.Logger, migrations []schemaMigration) (int, error) {
+	logger = logger.Session("migrate-schema")
+
+	statement := "CREATE TABLE IF NOT EXISTS schema_migrations (version integer NOT NULL, PRIMARY KEY(version))"
+	logger.Debug("create-table", lager.Data{"statement": statement})
+	if _, err := db.Exec(statement); err != nil {
+		logger.Error("create-table.sql-error", err)
+		return 0, err
+	}
+
+	version, err := schemaVersion(db, logger)
+	if err != nil {
+		return 0, err
+	}
+
+	for ; version < len(migrations); version++ {
+		if err := applySchemaMigration(db, driver, logger, version+1, migrations[version]); err != nil {
+			// Another broker may have applied it in the meantime
+			if current, currentErr := schemaVersion(db, logger); currentErr == nil && current > version {
+				continue
+			}
+			return version, err
+		}
+		logger.Info("migrated", lager.Data{"version": version + 1})
+	}
+
+	return version, nil
+}
+
+func applySchemaMigration(db *sql.DB, driver string, logger lager.Logger, version int, migration schemaMigration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		logger.Error("sql-error", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := migration(tx, logger); err != nil {
+		return err
+	}
+
+	statement := utils.BindPlaceholders(driver, "INSERT INTO schema_migrations (version) VALUES ($1)")
+	logger.Debug("record-version", lager.Data{"statement": statement, "params": []int{version}})
+	if _, err := tx.Exec(statement, version); err != nil {
+		logger.Error("record-version.sql-error", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("commit.sql-error", err)
+		return err
+	}
+
+	return nil
+}
+
+func schemaVersion(db *sql.DB, logger lager.Logger) (int, error) {
+	var version int
+	statement := "SELECT COALESCE(MAX(version), 0) FROM schema_migrations"
+	logger.Debug("schema-version", lager.Data{"statement": statement})
+	if err := db.QueryRow(statement).Scan(&version); err != nil {
+		logger.Error("schema-version.sql-error", err)
+		return 0, err
+	}
+	return version, nil
+}
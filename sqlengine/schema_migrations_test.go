@@ -0,0 +1,123 @@
+package sqlengine
+
+import (
+	"database/sql"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-golang/lager"
+)
+
+var _ = Describe("migrateSchema", func() {
+	var (
+		db     *sql.DB
+		logger lager.Logger
+	)
+
+	columns := func(table string) []string {
+		rows, err := db.Query("SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position", table)
+		Expect(err).NotTo(HaveOccurred())
+		defer rows.Close()
+
+		names := []string{}
+		for rows.Next() {
+			var name string
+			Expect(rows.Scan(&name)).To(Succeed())
+			names = append(names, name)
+		}
+		return names
+	}
+
+	// Set STATE_STORE_POSTGRES_URL to run these against a PostgreSQL database
+	url := os.Getenv("STATE_STORE_POSTGRES_URL")
+	describePostgres := Context
+	if url == "" {
+		describePostgres = PContext
+	}
+
+	describePostgres("with PostgreSQL", func() {
+		BeforeEach(func() {
+			var err error
+			db, err = sql.Open("postgres", url)
+			Expect(err).NotTo(HaveOccurred())
+			// The schema is set for the session
+			db.SetMaxOpenConns(1)
+
+			_, err = db.Exec("DROP SCHEMA IF EXISTS schema_migrations_test CASCADE")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec("CREATE SCHEMA schema_migrations_test")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec("SET search_path TO schema_migrations_test")
+			Expect(err).NotTo(HaveOccurred())
+
+			logger = lager.NewLogger("schema_migrations_test")
+		})
+
+		AfterEach(func() {
+			_, err := db.Exec("DROP SCHEMA schema_migrations_test CASCADE")
+			Expect(err).NotTo(HaveOccurred())
+			db.Close()
+		})
+
+		It("applies the migrations once and records the version", func() {
+			version, err := migrateSchema(db, "postgres", logger, stateStoreMigrations)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(len(stateStoreMigrations)))
+			Expect(columns("users")).To(Equal([]string{"address", "username", "encrypted_password", "password_storage_version", "key_id"}))
+
+			version, err = migrateSchema(db, "postgres", logger, stateStoreMigrations)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(len(stateStoreMigrations)))
+
+			var count int
+			Expect(db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(len(stateStoreMigrations)))
+		})
+
+		It("stops at the first failing migration", func() {
+			migrations := append([]schemaMigration{}, stateStoreMigrations...)
+			migrations = append(migrations, migrationStatements("ALTER TABLE missing ADD COLUMN created_at bigint"))
+
+			version, err := migrateSchema(db, "postgres", logger, migrations)
+			Expect(err).To(HaveOccurred())
+			Expect(version).To(Equal(len(stateStoreMigrations)))
+
+			version, err = schemaVersion(db, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(len(stateStoreMigrations)))
+		})
+
+		It("applies the migrations of the broker_state databases", func() {
+			version, err := migrateSchema(db, "postgres", logger, stateDBMigrations)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(len(stateDBMigrations)))
+			Expect(columns("role")).To(Equal([]string{"username", "encrypted_password", "password_storage_version", "key_id"}))
+		})
+
+		It("migrates the broker_state databases created before key IDs", func() {
+			_, err := db.Exec("CREATE TABLE role (username varchar(128) NOT NULL, encrypted_password varchar(128) NOT NULL, password_storage_version varchar(10), PRIMARY KEY(username))")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec("INSERT INTO role VALUES ('user', 'encrypted', '1.0')")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = migrateSchema(db, "postgres", logger, stateDBMigrations)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns("role")).To(ContainElement("key_id"))
+
+			var keyID sql.NullString
+			Expect(db.QueryRow("SELECT key_id FROM role WHERE username = 'user'").Scan(&keyID)).To(Succeed())
+			Expect(keyID.Valid).To(BeFalse())
+		})
+
+		It("migrates the broker_state databases created before migrations were recorded", func() {
+			_, err := db.Exec("CREATE TABLE role (username varchar(128) NOT NULL, encrypted_password varchar(128) NOT NULL, password_storage_version varchar(10), key_id varchar(64), PRIMARY KEY(username))")
+			Expect(err).NotTo(HaveOccurred())
+
+			version, err := migrateSchema(db, "postgres", logger, stateDBMigrations)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(len(stateDBMigrations)))
+		})
+	})
+})
@@ -1,8 +1,12 @@
 package sqlengine
 
 import (
+	"database/sql"
 	"errors"
 	"strings"
+	"time"
+
+	"github.com/pivotal-golang/lager"
 
 	"github.com/alphagov/paas-rds-broker/utils"
 )
@@ -15,14 +19,88 @@ const (
 type SQLEngine interface {
 	Open(address string, port int64, dbname string, username string, password string) error
 	Close()
-	CreateUser(bindingID, dbname string) (string, string, error)
+	CreateUser(bindingID, dbname string, options UserOptions) (string, string, error)
 	DropUser(bindingID string) error
+	DropExpiredUsers() (int, error)
+	ListServiceKeyUsers() ([]ServiceKeyUser, error)
+	CreateIAMUser(bindingID, dbname string) (string, error)
+	DropIAMUser(bindingID string) error
+	ExecuteStatements(statements []string) error
+	ListExtensions() ([]string, error)
+	CreateExtensions(extensions []string) error
+	DropExtensions(extensions []string) error
+	ReencryptState() (int, error)
+	MigrateState() (int, error)
 	URI(address string, port int64, dbname string, username string, password string) string
 	JDBCURI(address string, port int64, dbname string, username string, password string) string
 }
 
+// UserOptions limit what the user of a binding can do. The zero values leave
+// the defaults of the engine. Timeouts are in milliseconds. The users of
+// service keys are recorded in the state store.
+type UserOptions struct {
+	ServiceKey                      bool
+	ReadOnly                        bool
+	ValidUntil                      time.Time
+	ConnectionLimit                 int64
+	StatementTimeout                int64
+	IdleInTransactionSessionTimeout int64
+}
+
 var LoginFailedError = errors.New("Login failed")
 
+var ErrExtensionsNotSupported = errors.New("Extensions are not supported by this engine")
+
+var ErrExpiringUsersNotSupported = errors.New("Expiring users need a state store with this engine")
+
+var ErrServiceKeysNotSupported = errors.New("Service keys need a state store to be recorded")
+
+var ErrUserSettingsNotSupported = errors.New("Session settings by user are not supported by this engine")
+
+// executeStatements runs statements in order within a transaction, as far as
+// the engine supports transactional DDL.
+func executeStatements(db *sql.DB, logger lager.Logger, statements []string) error {
+	return executeRedactedStatements(db, logger, statements, statements)
+}
+
+// executeRedactedStatements runs statements like executeStatements, but logs
+// loggedStatements instead, which leave out the passwords the statements set.
+func executeRedactedStatements(db *sql.DB, logger lager.Logger, statements []string, loggedStatements []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		logger.Error("sql-error", err)
+		return err
+	}
+
+	for i, statement := range statements {
+		logger.Debug("execute-statement", lager.Data{"statement": loggedStatements[i]})
+		if _, err := tx.Exec(statement); err != nil {
+			logger.Error("sql-error", err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("commit.sql-error", err)
+		return err
+	}
+
+	return nil
+}
+
+// recordServiceKeyUser records the user of a service key in the state store.
+func recordServiceKeyUser(stateStore StateStore, address, bindingID, username string, options UserOptions) error {
+	return stateStore.PutServiceKeyUser(ServiceKeyUser{
+		Address:   address,
+		BindingID: bindingID,
+		Username:  username,
+		ReadOnly:  options.ReadOnly,
+		ExpiresAt: options.ValidUntil,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
 func generateUsername(seed string) string {
 	return "u" + strings.Replace(utils.GetMD5B64(seed, usernameLength-1), "-", "_", -1)
 }
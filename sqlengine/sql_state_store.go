@@ -0,0 +1,268 @@
+package sqlengine
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/utils"
+)
+
+// SQLStateStore keeps the broker state in a table of a dedicated PostgreSQL
+// or MySQL database, which tenants have no access to.
+type SQLStateStore struct {
+	db     *sql.DB
+	driver string
+	logger lager.Logger
+}
+
+func NewSQLStateStore(driver string, url string, logger lager.Logger) (*SQLStateStore, error) {
+	if driver != "postgres" && driver != "mysql" {
+		return nil, fmt.Errorf("Unsupported state store driver '%s'", driver)
+	}
+
+	db, err := sql.Open(driver, url)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SQLStateStore{
+		db:     db,
+		driver: driver,
+		logger: logger.Session("sql-state-store"),
+	}
+
+	if err := s.initSchema(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *SQLStateStore) Close() error {
+	return s.db.Close()
+}
+
+// stateStoreMigrations are the schema migrations of the state store.
+var stateStoreMigrations = []schemaMigration{
+	migrationStatements(`CREATE TABLE IF NOT EXISTS users (
+		address varchar(255) NOT NULL,
+		username varchar(128) NOT NULL,
+		encrypted_password varchar(128) NOT NULL,
+		password_storage_version varchar(10) NOT NULL,
+		key_id varchar(64) NOT NULL,
+		PRIMARY KEY(address, username)
+	)`),
+	migrationStatements(`CREATE TABLE IF NOT EXISTS expiring_users (
+		address varchar(255) NOT NULL,
+		username varchar(128) NOT NULL,
+		expires_at bigint NOT NULL,
+		PRIMARY KEY(address, username)
+	)`),
+	migrationStatements(`CREATE TABLE IF NOT EXISTS service_key_users (
+		address varchar(255) NOT NULL,
+		binding_id varchar(255) NOT NULL,
+		username varchar(128) NOT NULL,
+		read_only boolean NOT NULL,
+		expires_at bigint NOT NULL,
+		created_at bigint NOT NULL,
+		PRIMARY KEY(address, binding_id)
+	)`),
+}
+
+func (s *SQLStateStore) initSchema() error {
+	_, err := migrateSchema(s.db, s.driver, s.logger, stateStoreMigrations)
+	return err
+}
+
+func (s *SQLStateStore) GetUser(address, username string) (StateUser, bool, error) {
+	user := StateUser{Address: address, Username: username}
+	statement := s.bind("SELECT encrypted_password, key_id FROM users WHERE address = $1 AND username = $2")
+	s.logger.Debug("get-user", lager.Data{"statement": statement, "params": []string{address, username}})
+	err := s.db.QueryRow(statement, address, username).Scan(&user.EncryptedPassword, &user.KeyID)
+	if err == sql.ErrNoRows {
+		return StateUser{}, false, nil
+	} else if err != nil {
+		s.logger.Error("get-user.sql-error", err)
+		return StateUser{}, false, err
+	}
+	return user, true, nil
+}
+
+func (s *SQLStateStore) PutUser(user StateUser) error {
+	statement := s.bind("UPDATE users SET encrypted_password = $1, password_storage_version = $2, key_id = $3 WHERE address = $4 AND username = $5")
+	s.logger.Debug("update-user", lager.Data{
+		"statement": statement,
+		"params":    []string{"REDACTED", passwordStorageVersion, user.KeyID, user.Address, user.Username},
+	})
+	result, err := s.db.Exec(statement, user.EncryptedPassword, passwordStorageVersion, user.KeyID, user.Address, user.Username)
+	if err != nil {
+		s.logger.Error("update-user.sql-error", err)
+		return err
+	}
+	if updated, err := result.RowsAffected(); err == nil && updated > 0 {
+		return nil
+	}
+
+	statement = s.bind("INSERT INTO users (address, username, encrypted_password, password_storage_version, key_id) VALUES ($1, $2, $3, $4, $5)")
+	s.logger.Debug("insert-user", lager.Data{
+		"statement": statement,
+		"params":    []string{user.Address, user.Username, "REDACTED", passwordStorageVersion, user.KeyID},
+	})
+	if _, err := s.db.Exec(statement, user.Address, user.Username, user.EncryptedPassword, passwordStorageVersion, user.KeyID); err != nil {
+		s.logger.Error("insert-user.sql-error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStateStore) ListUsers() ([]StateUser, error) {
+	statement := "SELECT address, username, encrypted_password, key_id FROM users ORDER BY address, username"
+	s.logger.Debug("list-users", lager.Data{"statement": statement})
+	rows, err := s.db.Query(statement)
+	if err != nil {
+		s.logger.Error("list-users.sql-error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []StateUser{}
+	for rows.Next() {
+		var user StateUser
+		if err := rows.Scan(&user.Address, &user.Username, &user.EncryptedPassword, &user.KeyID); err != nil {
+			s.logger.Error("list-users.sql-error", err)
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error("list-users.sql-error", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (s *SQLStateStore) PutExpiringUser(address, username string, expiresAt time.Time) error {
+	statement := s.bind("INSERT INTO expiring_users (address, username, expires_at) VALUES ($1, $2, $3)")
+	s.logger.Debug("insert-expiring-user", lager.Data{"statement": statement, "params": []interface{}{address, username, expiresAt.Unix()}})
+	if _, err := s.db.Exec(statement, address, username, expiresAt.Unix()); err != nil {
+		s.logger.Error("insert-expiring-user.sql-error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStateStore) ListExpiredUsers(address string, now time.Time) ([]string, error) {
+	statement := s.bind("SELECT username FROM expiring_users WHERE address = $1 AND expires_at < $2 ORDER BY username")
+	s.logger.Debug("list-expired-users", lager.Data{"statement": statement, "params": []interface{}{address, now.Unix()}})
+	rows, err := s.db.Query(statement, address, now.Unix())
+	if err != nil {
+		s.logger.Error("list-expired-users.sql-error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	usernames := []string{}
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			s.logger.Error("list-expired-users.sql-error", err)
+			return nil, err
+		}
+		usernames = append(usernames, username)
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error("list-expired-users.sql-error", err)
+		return nil, err
+	}
+
+	return usernames, nil
+}
+
+func (s *SQLStateStore) DeleteExpiringUser(address, username string) error {
+	statement := s.bind("DELETE FROM expiring_users WHERE address = $1 AND username = $2")
+	s.logger.Debug("delete-expiring-user", lager.Data{"statement": statement, "params": []string{address, username}})
+	if _, err := s.db.Exec(statement, address, username); err != nil {
+		s.logger.Error("delete-expiring-user.sql-error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStateStore) PutServiceKeyUser(user ServiceKeyUser) error {
+	var expiresAt int64
+	if !user.ExpiresAt.IsZero() {
+		expiresAt = user.ExpiresAt.Unix()
+	}
+
+	statement := s.bind("UPDATE service_key_users SET username = $1, read_only = $2, expires_at = $3, created_at = $4 WHERE address = $5 AND binding_id = $6")
+	s.logger.Debug("update-service-key-user", lager.Data{"statement": statement, "params": []interface{}{user.Username, user.ReadOnly, expiresAt, user.CreatedAt.Unix(), user.Address, user.BindingID}})
+	result, err := s.db.Exec(statement, user.Username, user.ReadOnly, expiresAt, user.CreatedAt.Unix(), user.Address, user.BindingID)
+	if err != nil {
+		s.logger.Error("update-service-key-user.sql-error", err)
+		return err
+	}
+	if updated, err := result.RowsAffected(); err == nil && updated > 0 {
+		return nil
+	}
+
+	statement = s.bind("INSERT INTO service_key_users (address, binding_id, username, read_only, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6)")
+	s.logger.Debug("insert-service-key-user", lager.Data{"statement": statement, "params": []interface{}{user.Address, user.BindingID, user.Username, user.ReadOnly, expiresAt, user.CreatedAt.Unix()}})
+	if _, err := s.db.Exec(statement, user.Address, user.BindingID, user.Username, user.ReadOnly, expiresAt, user.CreatedAt.Unix()); err != nil {
+		s.logger.Error("insert-service-key-user.sql-error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStateStore) ListServiceKeyUsers(address string) ([]ServiceKeyUser, error) {
+	statement := s.bind("SELECT binding_id, username, read_only, expires_at, created_at FROM service_key_users WHERE address = $1 ORDER BY binding_id")
+	s.logger.Debug("list-service-key-users", lager.Data{"statement": statement, "params": []string{address}})
+	rows, err := s.db.Query(statement, address)
+	if err != nil {
+		s.logger.Error("list-service-key-users.sql-error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []ServiceKeyUser{}
+	for rows.Next() {
+		var expiresAt, createdAt int64
+		user := ServiceKeyUser{Address: address}
+		if err := rows.Scan(&user.BindingID, &user.Username, &user.ReadOnly, &expiresAt, &createdAt); err != nil {
+			s.logger.Error("list-service-key-users.sql-error", err)
+			return nil, err
+		}
+		if expiresAt != 0 {
+			user.ExpiresAt = time.Unix(expiresAt, 0).UTC()
+		}
+		user.CreatedAt = time.Unix(createdAt, 0).UTC()
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error("list-service-key-users.sql-error", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (s *SQLStateStore) DeleteServiceKeyUser(address, bindingID string) error {
+	statement := s.bind("DELETE FROM service_key_users WHERE address = $1 AND binding_id = $2")
+	s.logger.Debug("delete-service-key-user", lager.Data{"statement": statement, "params": []string{address, bindingID}})
+	if _, err := s.db.Exec(statement, address, bindingID); err != nil {
+		s.logger.Error("delete-service-key-user.sql-error", err)
+		return err
+	}
+	return nil
+}
+
+// bind replaces the PostgreSQL placeholders of a statement for MySQL.
+func (s *SQLStateStore) bind(statement string) string {
+	return utils.BindPlaceholders(s.driver, statement)
+}
@@ -0,0 +1,222 @@
+package sqlengine
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// StateStore keeps the broker state outside of the tenant DB instances: the
+// encrypted passwords of the users created by the broker, by DB instance
+// address and username, when the users of expiring bindings expire, and the
+// users of the service keys. PutUser creates or replaces the user.
+type StateStore interface {
+	GetUser(address, username string) (StateUser, bool, error)
+	PutUser(user StateUser) error
+	ListUsers() ([]StateUser, error)
+	PutExpiringUser(address, username string, expiresAt time.Time) error
+	ListExpiredUsers(address string, now time.Time) ([]string, error)
+	DeleteExpiringUser(address, username string) error
+	PutServiceKeyUser(user ServiceKeyUser) error
+	ListServiceKeyUsers(address string) ([]ServiceKeyUser, error)
+	DeleteServiceKeyUser(address, bindingID string) error
+}
+
+// StateUser is a user created by the broker, with its password encrypted with
+// the state encryption key of KeyID.
+type StateUser struct {
+	Address           string
+	Username          string
+	EncryptedPassword string
+	KeyID             string
+}
+
+// ServiceKeyUser is the user of a service key, recorded so that the keys of a
+// DB instance can be listed and revoked. ExpiresAt is zero when the user does
+// not expire.
+type ServiceKeyUser struct {
+	Address   string
+	BindingID string
+	Username  string
+	ReadOnly  bool
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// MemoryStateStore keeps the broker state in memory. The state is lost when
+// the broker restarts, so it is only meant for tests.
+type MemoryStateStore struct {
+	users         map[string]StateUser
+	expiringUsers map[string]map[string]time.Time
+	serviceKeys   map[string]map[string]ServiceKeyUser
+	mutex         sync.Mutex
+}
+
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		users:         make(map[string]StateUser),
+		expiringUsers: make(map[string]map[string]time.Time),
+		serviceKeys:   make(map[string]map[string]ServiceKeyUser),
+	}
+}
+
+func (s *MemoryStateStore) GetUser(address, username string) (StateUser, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	user, ok := s.users[address+"/"+username]
+	return user, ok, nil
+}
+
+func (s *MemoryStateStore) PutUser(user StateUser) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.users[user.Address+"/"+user.Username] = user
+	return nil
+}
+
+func (s *MemoryStateStore) ListUsers() ([]StateUser, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys := []string{}
+	for key := range s.users {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	users := []StateUser{}
+	for _, key := range keys {
+		users = append(users, s.users[key])
+	}
+	return users, nil
+}
+
+func (s *MemoryStateStore) PutExpiringUser(address, username string, expiresAt time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.expiringUsers[address] == nil {
+		s.expiringUsers[address] = make(map[string]time.Time)
+	}
+	s.expiringUsers[address][username] = expiresAt
+	return nil
+}
+
+func (s *MemoryStateStore) ListExpiredUsers(address string, now time.Time) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	usernames := []string{}
+	for username, expiresAt := range s.expiringUsers[address] {
+		if expiresAt.Before(now) {
+			usernames = append(usernames, username)
+		}
+	}
+	sort.Strings(usernames)
+	return usernames, nil
+}
+
+func (s *MemoryStateStore) DeleteExpiringUser(address, username string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.expiringUsers[address], username)
+	return nil
+}
+
+func (s *MemoryStateStore) PutServiceKeyUser(user ServiceKeyUser) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.serviceKeys[user.Address] == nil {
+		s.serviceKeys[user.Address] = make(map[string]ServiceKeyUser)
+	}
+	s.serviceKeys[user.Address][user.BindingID] = user
+	return nil
+}
+
+func (s *MemoryStateStore) ListServiceKeyUsers(address string) ([]ServiceKeyUser, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bindingIDs := []string{}
+	for bindingID := range s.serviceKeys[address] {
+		bindingIDs = append(bindingIDs, bindingID)
+	}
+	sort.Strings(bindingIDs)
+
+	users := []ServiceKeyUser{}
+	for _, bindingID := range bindingIDs {
+		users = append(users, s.serviceKeys[address][bindingID])
+	}
+	return users, nil
+}
+
+func (s *MemoryStateStore) DeleteServiceKeyUser(address, bindingID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.serviceKeys[address], bindingID)
+	return nil
+}
+
+// expiredServiceKeyUsers returns the users of the service keys of an address
+// which have expired.
+func expiredServiceKeyUsers(store StateStore, address string, now time.Time) ([]ServiceKeyUser, error) {
+	users, err := store.ListServiceKeyUsers(address)
+	if err != nil {
+		return nil, err
+	}
+
+	expired := []ServiceKeyUser{}
+	for _, user := range users {
+		if !user.ExpiresAt.IsZero() && user.ExpiresAt.Before(now) {
+			expired = append(expired, user)
+		}
+	}
+	return expired, nil
+}
+
+// ReencryptStateStore encrypts the passwords of a state store stored with
+// other keys with the active key, and returns how many were re-encrypted.
+func ReencryptStateStore(store StateStore, stateEncryptionKeys StateEncryptionKeys) (int, error) {
+	users, err := store.ListUsers()
+	if err != nil {
+		return 0, err
+	}
+
+	reencrypted := 0
+	for _, user := range users {
+		if user.KeyID == stateEncryptionKeys.ActiveKeyID {
+			continue
+		}
+
+		user, err = reencryptStateUser(user, stateEncryptionKeys)
+		if err != nil {
+			return reencrypted, err
+		}
+		if err := store.PutUser(user); err != nil {
+			return reencrypted, err
+		}
+		reencrypted++
+	}
+
+	return reencrypted, nil
+}
+
+// reencryptStateUser returns the user with its password encrypted with the
+// active key.
+func reencryptStateUser(user StateUser, stateEncryptionKeys StateEncryptionKeys) (StateUser, error) {
+	if user.KeyID == stateEncryptionKeys.ActiveKeyID {
+		return user, nil
+	}
+
+	password, err := stateEncryptionKeys.decrypt(user.KeyID, user.EncryptedPassword)
+	if err != nil {
+		return user, err
+	}
+	user.KeyID, user.EncryptedPassword, err = stateEncryptionKeys.encrypt(password)
+	return user, err
+}
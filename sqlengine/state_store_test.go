@@ -0,0 +1,167 @@
+package sqlengine
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-golang/lager"
+)
+
+func describeStateStore(newStore func() StateStore) {
+	var store StateStore
+
+	BeforeEach(func() {
+		store = newStore()
+	})
+
+	It("returns nothing for unknown users", func() {
+		_, ok, err := store.GetUser("db.example.com", "unknown")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("stores users by address and username", func() {
+		user := StateUser{Address: "db.example.com", Username: "user", EncryptedPassword: "encrypted", KeyID: "key"}
+		Expect(store.PutUser(user)).To(Succeed())
+		Expect(store.PutUser(StateUser{Address: "other.example.com", Username: "user", EncryptedPassword: "other", KeyID: "key"})).To(Succeed())
+
+		stored, ok, err := store.GetUser("db.example.com", "user")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(stored).To(Equal(user))
+	})
+
+	It("replaces existing users", func() {
+		Expect(store.PutUser(StateUser{Address: "db.example.com", Username: "user", EncryptedPassword: "old", KeyID: ""})).To(Succeed())
+		Expect(store.PutUser(StateUser{Address: "db.example.com", Username: "user", EncryptedPassword: "new", KeyID: "key"})).To(Succeed())
+
+		users, err := store.ListUsers()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(users).To(ContainElement(StateUser{Address: "db.example.com", Username: "user", EncryptedPassword: "new", KeyID: "key"}))
+		Expect(users).NotTo(ContainElement(StateUser{Address: "db.example.com", Username: "user", EncryptedPassword: "old", KeyID: ""}))
+	})
+
+	It("lists the expired users of an address", func() {
+		now := time.Now()
+		Expect(store.PutExpiringUser("db.example.com", "expired", now.Add(-time.Minute))).To(Succeed())
+		Expect(store.PutExpiringUser("db.example.com", "valid", now.Add(time.Minute))).To(Succeed())
+		Expect(store.PutExpiringUser("other.example.com", "expired", now.Add(-time.Minute))).To(Succeed())
+
+		usernames, err := store.ListExpiredUsers("db.example.com", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(usernames).To(Equal([]string{"expired"}))
+
+		Expect(store.DeleteExpiringUser("db.example.com", "expired")).To(Succeed())
+		usernames, err = store.ListExpiredUsers("db.example.com", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(usernames).To(BeEmpty())
+	})
+
+	It("records the service key users by address and binding", func() {
+		createdAt := time.Unix(1500000000, 0).UTC()
+		user := ServiceKeyUser{Address: "db.example.com", BindingID: "binding-1", Username: "user", ReadOnly: true, CreatedAt: createdAt}
+		Expect(store.PutServiceKeyUser(user)).To(Succeed())
+		Expect(store.PutServiceKeyUser(ServiceKeyUser{Address: "other.example.com", BindingID: "binding-2", Username: "other", CreatedAt: createdAt})).To(Succeed())
+
+		users, err := store.ListServiceKeyUsers("db.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(users).To(Equal([]ServiceKeyUser{user}))
+
+		Expect(store.DeleteServiceKeyUser("db.example.com", "binding-1")).To(Succeed())
+		users, err = store.ListServiceKeyUsers("db.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(users).To(BeEmpty())
+	})
+
+	It("replaces the service key user of a binding", func() {
+		createdAt := time.Unix(1500000000, 0).UTC()
+		expiresAt := time.Unix(1600000000, 0).UTC()
+		Expect(store.PutServiceKeyUser(ServiceKeyUser{Address: "db.example.com", BindingID: "binding-1", Username: "old", CreatedAt: createdAt})).To(Succeed())
+		user := ServiceKeyUser{Address: "db.example.com", BindingID: "binding-1", Username: "new", ExpiresAt: expiresAt, CreatedAt: createdAt}
+		Expect(store.PutServiceKeyUser(user)).To(Succeed())
+
+		users, err := store.ListServiceKeyUsers("db.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(users).To(Equal([]ServiceKeyUser{user}))
+	})
+}
+
+var _ = Describe("MemoryStateStore", func() {
+	describeStateStore(func() StateStore {
+		return NewMemoryStateStore()
+	})
+})
+
+var _ = Describe("SQLStateStore", func() {
+	It("returns an error for an unsupported driver", func() {
+		_, err := NewSQLStateStore("sqlite", "", lager.NewLogger("state_store_test"))
+		Expect(err).To(MatchError("Unsupported state store driver 'sqlite'"))
+	})
+
+	// Set STATE_STORE_POSTGRES_URL to run these against a PostgreSQL database
+	if url := os.Getenv("STATE_STORE_POSTGRES_URL"); url != "" {
+		Context("with PostgreSQL", func() {
+			describeStateStore(func() StateStore {
+				store, err := NewSQLStateStore("postgres", url, lager.NewLogger("state_store_test"))
+				Expect(err).NotTo(HaveOccurred())
+				_, err = store.db.Exec("DELETE FROM users")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = store.db.Exec("DELETE FROM expiring_users")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = store.db.Exec("DELETE FROM service_key_users")
+				Expect(err).NotTo(HaveOccurred())
+				return store
+			})
+		})
+	}
+})
+
+var _ = Describe("ReencryptStateStore", func() {
+	var (
+		store *MemoryStateStore
+		keys  StateEncryptionKeys
+	)
+
+	BeforeEach(func() {
+		store = NewMemoryStateStore()
+		keys = StateEncryptionKeys{
+			ActiveKeyID: "new",
+			Keys: map[string]string{
+				"":    "legacy key",
+				"new": "new key",
+			},
+		}
+	})
+
+	It("re-encrypts the passwords stored with other keys", func() {
+		legacyPassword, err := encryptString("legacy key", "legacy password")
+		Expect(err).NotTo(HaveOccurred())
+		newPassword, err := encryptString("new key", "new password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.PutUser(StateUser{Address: "db.example.com", Username: "legacy", EncryptedPassword: legacyPassword})).To(Succeed())
+		Expect(store.PutUser(StateUser{Address: "db.example.com", Username: "new", EncryptedPassword: newPassword, KeyID: "new"})).To(Succeed())
+
+		count, err := ReencryptStateStore(store, keys)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+
+		user, _, _ := store.GetUser("db.example.com", "legacy")
+		Expect(user.KeyID).To(Equal("new"))
+		password, err := decryptString("new key", user.EncryptedPassword)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(password).To(Equal("legacy password"))
+
+		user, _, _ = store.GetUser("db.example.com", "new")
+		Expect(user.EncryptedPassword).To(Equal(newPassword))
+	})
+
+	It("fails on passwords stored with unknown keys", func() {
+		Expect(store.PutUser(StateUser{Address: "db.example.com", Username: "user", EncryptedPassword: "encrypted", KeyID: "retired"})).To(Succeed())
+
+		_, err := ReencryptStateStore(store, keys)
+		Expect(err).To(MatchError("Unknown state encryption key ID 'retired'"))
+	})
+})
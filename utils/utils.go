@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"io"
+	"regexp"
 )
 
 var alpha = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
@@ -46,3 +49,24 @@ func GetMD5B64(text string, maxLength int) string {
 		return encoded
 	}
 }
+
+func GetHMACSHA256B64(key, text string, maxLength int) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(text))
+	encoded := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+	if len(encoded) > maxLength {
+		return encoded[0:maxLength]
+	}
+	return encoded
+}
+
+var placeholderPattern = regexp.MustCompile(`\$[0-9]+`)
+
+// BindPlaceholders replaces the PostgreSQL placeholders ($1, $2...) of a
+// statement with the ones of the given database driver.
+func BindPlaceholders(driver, statement string) string {
+	if driver != "mysql" {
+		return statement
+	}
+	return placeholderPattern.ReplaceAllString(statement, "?")
+}
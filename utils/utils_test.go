@@ -34,3 +34,24 @@ var _ = Describe("GetMD5B64", func() {
 		Expect(md5b64).To(Equal("4P7L73_9u3fGZbGG-GDHOw=="))
 	})
 })
+
+var _ = Describe("GetHMACSHA256B64", func() {
+	It("returns the URL safe Base64 encoded HMAC-SHA256 of the given string, truncated", func() {
+		hmacb64 := GetHMACSHA256B64("seed", "ce71b484-d542-40f7-9dd4-5526e38c81ba", 32)
+		// Expectation generated with
+		// echo -n ce71b484-d542-40f7-9dd4-5526e38c81ba | openssl dgst -sha256 -hmac seed -binary | openssl enc -base64 | tr '+/' '-_'
+		Expect(hmacb64).To(Equal("KdkO1ridciXUmEvlrQyoRktbVSZOfpMO"))
+	})
+})
+
+var _ = Describe("BindPlaceholders", func() {
+	It("replaces the placeholders for MySQL", func() {
+		statement := BindPlaceholders("mysql", "UPDATE t SET a = $1, b = $12 WHERE c = $2")
+		Expect(statement).To(Equal("UPDATE t SET a = ?, b = ? WHERE c = ?"))
+	})
+
+	It("keeps the placeholders for the other drivers", func() {
+		statement := BindPlaceholders("postgres", "UPDATE t SET a = $1 WHERE c = $2")
+		Expect(statement).To(Equal("UPDATE t SET a = $1 WHERE c = $2"))
+	})
+})
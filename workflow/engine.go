@@ -0,0 +1,211 @@
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+// maxAttempts is the number of consecutive errors after which a step fails
+// its workflow.
+const maxAttempts = 5
+
+// leaseDuration is how long a step may run before the workflow can be advanced
+// again by someone else.
+const leaseDuration = 10 * time.Minute
+
+// Engine runs the workflows kept in a Store, one step at a time. It is driven
+// both by the broker's LastOperation calls and by a background worker, so
+// workflows carry on across broker restarts. Concurrent changes, from this
+// broker or others sharing the store, are detected by the versions of the
+// workflows, and a step is only run by whoever holds the lease of its workflow.
+type Engine struct {
+	store       Store
+	definitions map[string]Definition
+	logger      lager.Logger
+}
+
+func NewEngine(store Store, logger lager.Logger) *Engine {
+	return &Engine{
+		store:       store,
+		definitions: make(map[string]Definition),
+		logger:      logger.Session("workflow-engine"),
+	}
+}
+
+func (e *Engine) Register(kind string, definition Definition) {
+	e.definitions[kind] = definition
+}
+
+// Start records a new workflow for a service instance, which replaces any
+// finished one. Its first step is run by the next call to Advance.
+func (e *Engine) Start(instanceID string, kind string, firstStep string, description string, data map[string]string) error {
+	if _, ok := e.definitions[kind]; !ok {
+		return fmt.Errorf("Unknown workflow kind '%s'", kind)
+	}
+
+	w := &Workflow{
+		InstanceID:  instanceID,
+		Kind:        kind,
+		Step:        firstStep,
+		State:       StateInProgress,
+		Description: description,
+		Data:        data,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	existing, err := e.store.Get(instanceID)
+	if err == nil {
+		if existing.State == StateInProgress {
+			return ErrInProgress
+		}
+		w.Version = existing.Version
+	} else if err != ErrNotFound {
+		return err
+	}
+
+	e.logger.Info("start", lager.Data{"instance-id": instanceID, "kind": kind, "step": firstStep})
+
+	return e.save(w)
+}
+
+// Get returns the workflow of a service instance, or ErrNotFound.
+func (e *Engine) Get(instanceID string) (*Workflow, error) {
+	return e.store.Get(instanceID)
+}
+
+// Forget deletes the workflow of a service instance once its outcome has been
+// reported.
+func (e *Engine) Forget(instanceID string) error {
+	err := e.store.Delete(instanceID)
+	if err == ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// Advance runs the current step of the workflow of a service instance, if it
+// is in progress and no one else is running it, and returns the workflow. It
+// returns ErrNotFound if there is no workflow for the service instance.
+func (e *Engine) Advance(instanceID string) (*Workflow, error) {
+	w, err := e.store.Get(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.State != StateInProgress || time.Now().Before(w.LeaseExpiresAt) {
+		return w, nil
+	}
+
+	// Saving the lease fails if someone else has taken it in the meantime
+	w.LeaseExpiresAt = time.Now().UTC().Add(leaseDuration)
+	if err := e.save(w); err != nil {
+		if err == ErrConflict {
+			return e.store.Get(instanceID)
+		}
+		return nil, err
+	}
+
+	e.runStep(w)
+
+	w.LeaseExpiresAt = time.Time{}
+	if err := e.save(w); err != nil {
+		if err == ErrConflict {
+			// Advanced by another broker in the meantime
+			return e.store.Get(instanceID)
+		}
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// AdvanceAll runs the current step of every workflow in progress.
+func (e *Engine) AdvanceAll() {
+	workflows, err := e.store.ListInProgress()
+	if err != nil {
+		e.logger.Error("list-in-progress", err)
+		return
+	}
+
+	for _, w := range workflows {
+		if _, err := e.Advance(w.InstanceID); err != nil && err != ErrNotFound {
+			e.logger.Error("advance", err, lager.Data{"instance-id": w.InstanceID})
+		}
+	}
+}
+
+func (e *Engine) runStep(w *Workflow) {
+	logData := lager.Data{"instance-id": w.InstanceID, "kind": w.Kind, "step": w.Step}
+
+	definition, ok := e.definitions[w.Kind]
+	if !ok {
+		e.fail(w, definition, fmt.Errorf("Unknown workflow kind '%s'", w.Kind))
+		return
+	}
+
+	step, ok := definition.Steps[w.Step]
+	if !ok {
+		e.fail(w, definition, fmt.Errorf("Unknown step '%s' of workflow '%s'", w.Step, w.Kind))
+		return
+	}
+
+	e.logger.Debug("run-step", logData)
+
+	next, description, err := step(w)
+	if err != nil {
+		e.logger.Error("run-step", err, logData)
+		w.LastError = err.Error()
+		w.Attempts++
+		// Rollbacks are retried until they succeed
+		if w.Failure == "" && (isFailure(err) || w.Attempts >= maxAttempts) {
+			e.fail(w, definition, err)
+		}
+		return
+	}
+
+	w.Attempts = 0
+	w.LastError = ""
+	if description != "" {
+		w.Description = description
+	}
+
+	if next != Finished {
+		if next != w.Step {
+			e.logger.Info("next-step", lager.Data{"instance-id": w.InstanceID, "kind": w.Kind, "step": next})
+		}
+		w.Step = next
+		return
+	}
+
+	if w.Failure != "" {
+		w.State = StateFailed
+		if description == "" {
+			w.Description = w.Failure
+		}
+	} else {
+		w.State = StateSucceeded
+	}
+	e.logger.Info("finish", lager.Data{"instance-id": w.InstanceID, "kind": w.Kind, "state": w.State})
+}
+
+func (e *Engine) fail(w *Workflow, definition Definition, err error) {
+	e.logger.Info("fail", lager.Data{"instance-id": w.InstanceID, "kind": w.Kind, "step": w.Step, "reason": err.Error()})
+
+	w.Failure = err.Error()
+	w.Description = err.Error()
+	w.Attempts = 0
+
+	if definition.RollbackStep != "" {
+		w.Step = definition.RollbackStep
+		return
+	}
+
+	w.State = StateFailed
+}
+
+func (e *Engine) save(w *Workflow) error {
+	w.UpdatedAt = time.Now().UTC()
+	return e.store.Save(w)
+}
@@ -0,0 +1,332 @@
+package workflow_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/alphagov/paas-rds-broker/workflow"
+
+	"github.com/pivotal-golang/lager"
+)
+
+var _ = Describe("Engine", func() {
+	var (
+		store  *MemoryStore
+		engine *Engine
+
+		calls        []string
+		waitErr      error
+		waitFor      int
+		rollbackErrs []error
+	)
+
+	BeforeEach(func() {
+		store = NewMemoryStore()
+		engine = NewEngine(store, lager.NewLogger("engine_test"))
+
+		calls = []string{}
+		waitErr = nil
+		waitFor = 0
+		rollbackErrs = []error{}
+
+		engine.Register("test", Definition{
+			Steps: map[string]Step{
+				"first": func(w *Workflow) (string, string, error) {
+					calls = append(calls, "first")
+					w.Data["first"] = "done"
+					return "wait", "Waiting", nil
+				},
+				"wait": func(w *Workflow) (string, string, error) {
+					calls = append(calls, "wait")
+					if waitErr != nil {
+						return "", "", waitErr
+					}
+					if waitFor > 0 {
+						waitFor--
+						return "wait", "", nil
+					}
+					return Finished, "Finished", nil
+				},
+				"rollback": func(w *Workflow) (string, string, error) {
+					calls = append(calls, "rollback")
+					if len(rollbackErrs) > 0 {
+						err := rollbackErrs[0]
+						rollbackErrs = rollbackErrs[1:]
+						return "", "", err
+					}
+					return Finished, "", nil
+				},
+			},
+			RollbackStep: "rollback",
+		})
+		engine.Register("no-rollback", Definition{
+			Steps: map[string]Step{
+				"first": func(w *Workflow) (string, string, error) {
+					return "", "", Failf("Cannot do it")
+				},
+			},
+		})
+	})
+
+	Describe("Start", func() {
+		It("saves a workflow in progress", func() {
+			err := engine.Start("instance-id", "test", "first", "Starting", map[string]string{"key": "value"})
+			Expect(err).ToNot(HaveOccurred())
+
+			w, err := store.Get("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.Kind).To(Equal("test"))
+			Expect(w.Step).To(Equal("first"))
+			Expect(w.State).To(Equal(StateInProgress))
+			Expect(w.Description).To(Equal("Starting"))
+			Expect(w.Data).To(Equal(map[string]string{"key": "value"}))
+			Expect(calls).To(BeEmpty())
+		})
+
+		It("returns an error if the kind is unknown", func() {
+			err := engine.Start("instance-id", "unknown", "first", "Starting", map[string]string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Unknown workflow kind 'unknown'"))
+		})
+
+		It("returns an error if a workflow is in progress", func() {
+			Expect(engine.Start("instance-id", "test", "first", "Starting", map[string]string{})).To(Succeed())
+
+			err := engine.Start("instance-id", "test", "first", "Starting", map[string]string{})
+			Expect(err).To(Equal(ErrInProgress))
+		})
+
+		It("replaces a finished workflow", func() {
+			Expect(engine.Start("instance-id", "no-rollback", "first", "Starting", map[string]string{})).To(Succeed())
+			_, err := engine.Advance("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = engine.Start("instance-id", "test", "first", "Starting", map[string]string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			w, err := store.Get("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.Kind).To(Equal("test"))
+			Expect(w.State).To(Equal(StateInProgress))
+		})
+	})
+
+	Describe("Advance", func() {
+		BeforeEach(func() {
+			Expect(engine.Start("instance-id", "test", "first", "Starting", map[string]string{})).To(Succeed())
+		})
+
+		It("returns ErrNotFound if there is no workflow", func() {
+			_, err := engine.Advance("unknown-id")
+			Expect(err).To(Equal(ErrNotFound))
+		})
+
+		It("runs one step at a time until the workflow is done", func() {
+			w, err := engine.Advance("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.Step).To(Equal("wait"))
+			Expect(w.State).To(Equal(StateInProgress))
+			Expect(w.Description).To(Equal("Waiting"))
+			Expect(w.Data).To(HaveKeyWithValue("first", "done"))
+
+			waitFor = 1
+			w, err = engine.Advance("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.Step).To(Equal("wait"))
+			Expect(w.Description).To(Equal("Waiting"))
+
+			w, err = engine.Advance("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.State).To(Equal(StateSucceeded))
+			Expect(w.Description).To(Equal("Finished"))
+			Expect(calls).To(Equal([]string{"first", "wait", "wait"}))
+
+			w, err = engine.Advance("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.State).To(Equal(StateSucceeded))
+			Expect(calls).To(HaveLen(3))
+		})
+
+		It("persists the progress in the store", func() {
+			_, err := engine.Advance("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			restarted := NewEngine(store, lager.NewLogger("engine_test"))
+			restarted.Register("test", Definition{
+				Steps: map[string]Step{
+					"wait": func(w *Workflow) (string, string, error) {
+						Expect(w.Data).To(HaveKeyWithValue("first", "done"))
+						return Finished, "Finished", nil
+					},
+				},
+			})
+
+			w, err := restarted.Advance("instance-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.State).To(Equal(StateSucceeded))
+		})
+
+		Context("when a step returns a temporary error", func() {
+			BeforeEach(func() {
+				_, err := engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				waitErr = errors.New("operation failed")
+			})
+
+			It("retries the step", func() {
+				w, err := engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Step).To(Equal("wait"))
+				Expect(w.State).To(Equal(StateInProgress))
+				Expect(w.Attempts).To(Equal(1))
+				Expect(w.LastError).To(Equal("operation failed"))
+
+				waitErr = nil
+				w, err = engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.State).To(Equal(StateSucceeded))
+				Expect(w.Attempts).To(Equal(0))
+				Expect(w.LastError).To(BeEmpty())
+			})
+
+			It("rolls back after too many attempts", func() {
+				var w *Workflow
+				var err error
+				for i := 0; i < 5; i++ {
+					w, err = engine.Advance("instance-id")
+					Expect(err).ToNot(HaveOccurred())
+				}
+				Expect(w.Step).To(Equal("rollback"))
+				Expect(w.State).To(Equal(StateInProgress))
+				Expect(w.Failure).To(Equal("operation failed"))
+
+				w, err = engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.State).To(Equal(StateFailed))
+				Expect(w.Description).To(Equal("operation failed"))
+			})
+		})
+
+		Context("when a step fails", func() {
+			BeforeEach(func() {
+				_, err := engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				waitErr = Failf("Snapshot '%s' failed", "snapshot-id")
+			})
+
+			It("rolls back straight away", func() {
+				w, err := engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Step).To(Equal("rollback"))
+				Expect(w.Description).To(Equal("Snapshot 'snapshot-id' failed"))
+
+				w, err = engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.State).To(Equal(StateFailed))
+				Expect(w.Description).To(Equal("Snapshot 'snapshot-id' failed"))
+				Expect(calls).To(Equal([]string{"first", "wait", "rollback"}))
+			})
+
+			It("retries the rollback until it succeeds", func() {
+				_, err := engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+
+				for i := 0; i < 10; i++ {
+					rollbackErrs = append(rollbackErrs, Failf("rollback failed"))
+				}
+				for i := 0; i < 10; i++ {
+					w, err := engine.Advance("instance-id")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(w.Step).To(Equal("rollback"))
+					Expect(w.State).To(Equal(StateInProgress))
+				}
+
+				w, err := engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.State).To(Equal(StateFailed))
+			})
+		})
+
+		Context("when the step is being run by someone else", func() {
+			It("leaves the workflow alone", func() {
+				engine.Register("reentrant", Definition{
+					Steps: map[string]Step{
+						"first": func(w *Workflow) (string, string, error) {
+							calls = append(calls, "reentrant")
+							running, err := engine.Advance("other-id")
+							Expect(err).ToNot(HaveOccurred())
+							Expect(running.Step).To(Equal("first"))
+							return Finished, "Finished", nil
+						},
+					},
+				})
+				Expect(engine.Start("other-id", "reentrant", "first", "Starting", map[string]string{})).To(Succeed())
+
+				w, err := engine.Advance("other-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.State).To(Equal(StateSucceeded))
+				Expect(w.LeaseExpiresAt.IsZero()).To(BeTrue())
+				Expect(calls).To(Equal([]string{"reentrant"}))
+			})
+
+			It("takes the workflow over once the lease has expired", func() {
+				w, err := store.Get("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				w.LeaseExpiresAt = time.Now().Add(time.Minute)
+				Expect(store.Save(w)).To(Succeed())
+
+				_, err = engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calls).To(BeEmpty())
+
+				w.LeaseExpiresAt = time.Now().Add(-time.Minute)
+				Expect(store.Save(w)).To(Succeed())
+
+				w, err = engine.Advance("instance-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Step).To(Equal("wait"))
+				Expect(calls).To(Equal([]string{"first"}))
+			})
+		})
+
+		Context("when the workflow has no rollback step", func() {
+			It("fails straight away", func() {
+				Expect(engine.Start("other-id", "no-rollback", "first", "Starting", map[string]string{})).To(Succeed())
+
+				w, err := engine.Advance("other-id")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.State).To(Equal(StateFailed))
+				Expect(w.Description).To(Equal("Cannot do it"))
+			})
+		})
+	})
+
+	Describe("AdvanceAll", func() {
+		It("advances every workflow in progress", func() {
+			Expect(engine.Start("instance-1", "test", "first", "Starting", map[string]string{})).To(Succeed())
+			Expect(engine.Start("instance-2", "test", "first", "Starting", map[string]string{})).To(Succeed())
+
+			engine.AdvanceAll()
+
+			Expect(calls).To(Equal([]string{"first", "first"}))
+		})
+	})
+
+	Describe("Forget", func() {
+		It("deletes the workflow", func() {
+			Expect(engine.Start("instance-id", "test", "first", "Starting", map[string]string{})).To(Succeed())
+
+			Expect(engine.Forget("instance-id")).To(Succeed())
+
+			_, err := engine.Get("instance-id")
+			Expect(err).To(Equal(ErrNotFound))
+		})
+
+		It("does not fail if there is no workflow", func() {
+			Expect(engine.Forget("unknown-id")).To(Succeed())
+		})
+	})
+})
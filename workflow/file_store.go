@@ -0,0 +1,136 @@
+package workflow
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileStore keeps each workflow in a JSON file of a local directory, so that
+// a single broker can resume its workflows after a restart.
+type FileStore struct {
+	directory string
+	mutex     sync.Mutex
+}
+
+func NewFileStore(directory string) (*FileStore, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileStore{directory: directory}, nil
+}
+
+func (s *FileStore) Get(instanceID string) (*Workflow, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.read(s.path(instanceID))
+}
+
+func (s *FileStore) Save(w *Workflow) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var version int64
+	existing, err := s.read(s.path(w.InstanceID))
+	if err == nil {
+		version = existing.Version
+	} else if err != ErrNotFound {
+		return err
+	}
+	if w.Version != version {
+		return ErrConflict
+	}
+
+	saved := w.copy()
+	saved.Version++
+	content, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+
+	// Written to a temporary file first so that a crash cannot leave it partial
+	tmpFile, err := ioutil.TempFile(s.directory, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	if err := os.Rename(tmpFile.Name(), s.path(w.InstanceID)); err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	w.Version++
+
+	return nil
+}
+
+func (s *FileStore) Delete(instanceID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := os.Remove(s.path(instanceID))
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (s *FileStore) ListInProgress() ([]*Workflow, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.directory, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	workflows := []*Workflow{}
+	for _, path := range paths {
+		w, err := s.read(path)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if w.State == StateInProgress {
+			workflows = append(workflows, w)
+		}
+	}
+
+	return workflows, nil
+}
+
+func (s *FileStore) read(path string) (*Workflow, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var w Workflow
+	if err := json.Unmarshal(content, &w); err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (s *FileStore) path(instanceID string) string {
+	return filepath.Join(s.directory, strings.Replace(instanceID, string(filepath.Separator), "_", -1)+".json")
+}
@@ -0,0 +1,230 @@
+package workflow
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/pivotal-golang/lager"
+
+	"github.com/alphagov/paas-rds-broker/utils"
+)
+
+const workflowColumns = "instance_id, kind, step, state, description, data, failure, attempts, last_error, version, created_at, updated_at, lease_expires_at"
+
+// SQLStore keeps the workflows in a table of a PostgreSQL or MySQL database,
+// which can be local to the broker or shared by several brokers.
+type SQLStore struct {
+	db     *sql.DB
+	driver string
+	logger lager.Logger
+}
+
+func NewSQLStore(driver string, url string, logger lager.Logger) (*SQLStore, error) {
+	if driver != "postgres" && driver != "mysql" {
+		return nil, fmt.Errorf("Unsupported workflow store driver '%s'", driver)
+	}
+
+	db, err := sql.Open(driver, url)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SQLStore{
+		db:     db,
+		driver: driver,
+		logger: logger.Session("workflow-sql-store"),
+	}
+
+	if err := s.initSchema(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLStore) initSchema() error {
+	statement := `CREATE TABLE IF NOT EXISTS workflows (
+		instance_id varchar(64) NOT NULL,
+		kind varchar(64) NOT NULL,
+		step varchar(64) NOT NULL,
+		state varchar(16) NOT NULL,
+		description text NOT NULL,
+		data text NOT NULL,
+		failure text NOT NULL,
+		attempts integer NOT NULL,
+		last_error text NOT NULL,
+		version bigint NOT NULL,
+		created_at bigint NOT NULL,
+		updated_at bigint NOT NULL,
+		lease_expires_at bigint NOT NULL DEFAULT 0,
+		PRIMARY KEY(instance_id)
+	)`
+	s.logger.Debug("create-table", lager.Data{"statement": statement})
+	if _, err := s.db.Exec(statement); err != nil {
+		s.logger.Error("create-table.sql-error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) Get(instanceID string) (*Workflow, error) {
+	statement := s.bind("SELECT " + workflowColumns + " FROM workflows WHERE instance_id = $1")
+	s.logger.Debug("get", lager.Data{"statement": statement, "params": []string{instanceID}})
+
+	w, err := scanWorkflow(s.db.QueryRow(statement, instanceID))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		s.logger.Error("get.sql-error", err)
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (s *SQLStore) Save(w *Workflow) error {
+	data, err := json.Marshal(w.Data)
+	if err != nil {
+		return err
+	}
+
+	var result sql.Result
+	if w.Version == 0 {
+		statement := s.bind("INSERT INTO workflows (" + workflowColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)")
+		s.logger.Debug("insert", lager.Data{"statement": statement, "instance-id": w.InstanceID})
+		result, err = s.db.Exec(statement,
+			w.InstanceID, w.Kind, w.Step, w.State, w.Description, string(data), w.Failure, w.Attempts, w.LastError,
+			w.Version+1, w.CreatedAt.UnixNano(), w.UpdatedAt.UnixNano(), unixNano(w.LeaseExpiresAt),
+		)
+		if err != nil {
+			// The workflow has been inserted by someone else
+			if _, getErr := s.Get(w.InstanceID); getErr == nil {
+				return ErrConflict
+			}
+			s.logger.Error("insert.sql-error", err)
+			return err
+		}
+	} else {
+		statement := s.bind("UPDATE workflows SET kind = $1, step = $2, state = $3, description = $4, data = $5, failure = $6, attempts = $7, last_error = $8, version = $9, created_at = $10, updated_at = $11, lease_expires_at = $12 WHERE instance_id = $13 AND version = $14")
+		s.logger.Debug("update", lager.Data{"statement": statement, "instance-id": w.InstanceID})
+		result, err = s.db.Exec(statement,
+			w.Kind, w.Step, w.State, w.Description, string(data), w.Failure, w.Attempts, w.LastError,
+			w.Version+1, w.CreatedAt.UnixNano(), w.UpdatedAt.UnixNano(), unixNano(w.LeaseExpiresAt),
+			w.InstanceID, w.Version,
+		)
+		if err != nil {
+			s.logger.Error("update.sql-error", err)
+			return err
+		}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows != 1 {
+		return ErrConflict
+	}
+
+	w.Version++
+
+	return nil
+}
+
+func (s *SQLStore) Delete(instanceID string) error {
+	statement := s.bind("DELETE FROM workflows WHERE instance_id = $1")
+	s.logger.Debug("delete", lager.Data{"statement": statement, "params": []string{instanceID}})
+
+	result, err := s.db.Exec(statement, instanceID)
+	if err != nil {
+		s.logger.Error("delete.sql-error", err)
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *SQLStore) ListInProgress() ([]*Workflow, error) {
+	statement := s.bind("SELECT " + workflowColumns + " FROM workflows WHERE state = $1")
+	s.logger.Debug("list-in-progress", lager.Data{"statement": statement})
+
+	rows, err := s.db.Query(statement, StateInProgress)
+	if err != nil {
+		s.logger.Error("list-in-progress.sql-error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	workflows := []*Workflow{}
+	for rows.Next() {
+		w, err := scanWorkflow(rows)
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, w)
+	}
+
+	return workflows, rows.Err()
+}
+
+// bind replaces the PostgreSQL placeholders of a statement for MySQL.
+func (s *SQLStore) bind(statement string) string {
+	return utils.BindPlaceholders(s.driver, statement)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWorkflow(row scanner) (*Workflow, error) {
+	var (
+		w                                    Workflow
+		data                                 string
+		createdAt, updatedAt, leaseExpiresAt int64
+	)
+
+	err := row.Scan(
+		&w.InstanceID, &w.Kind, &w.Step, &w.State, &w.Description, &data, &w.Failure, &w.Attempts, &w.LastError,
+		&w.Version, &createdAt, &updatedAt, &leaseExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(data), &w.Data); err != nil {
+		return nil, err
+	}
+	w.CreatedAt = time.Unix(0, createdAt).UTC()
+	w.UpdatedAt = time.Unix(0, updatedAt).UTC()
+	if leaseExpiresAt != 0 {
+		w.LeaseExpiresAt = time.Unix(0, leaseExpiresAt).UTC()
+	}
+
+	return &w, nil
+}
+
+// unixNano stores the zero time as 0, as its UnixNano overflows.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
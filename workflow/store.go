@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	"sync"
+)
+
+// Store keeps the workflows. Save fails with ErrConflict if the workflow has
+// been saved by someone else since it was read, and increments its Version.
+type Store interface {
+	Get(instanceID string) (*Workflow, error)
+	Save(w *Workflow) error
+	Delete(instanceID string) error
+	ListInProgress() ([]*Workflow, error)
+}
+
+// MemoryStore keeps the workflows in memory. The workflows in progress are
+// lost when the broker restarts.
+type MemoryStore struct {
+	workflows map[string]*Workflow
+	mutex     sync.Mutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{workflows: make(map[string]*Workflow)}
+}
+
+func (s *MemoryStore) Get(instanceID string) (*Workflow, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	w, ok := s.workflows[instanceID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return w.copy(), nil
+}
+
+func (s *MemoryStore) Save(w *Workflow) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var version int64
+	if existing, ok := s.workflows[w.InstanceID]; ok {
+		version = existing.Version
+	}
+	if w.Version != version {
+		return ErrConflict
+	}
+
+	w.Version++
+	s.workflows[w.InstanceID] = w.copy()
+
+	return nil
+}
+
+func (s *MemoryStore) Delete(instanceID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.workflows[instanceID]; !ok {
+		return ErrNotFound
+	}
+
+	delete(s.workflows, instanceID)
+
+	return nil
+}
+
+func (s *MemoryStore) ListInProgress() ([]*Workflow, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	workflows := []*Workflow{}
+	for _, w := range s.workflows {
+		if w.State == StateInProgress {
+			workflows = append(workflows, w.copy())
+		}
+	}
+
+	return workflows, nil
+}
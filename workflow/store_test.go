@@ -0,0 +1,149 @@
+package workflow_test
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/alphagov/paas-rds-broker/workflow"
+
+	"github.com/pivotal-golang/lager"
+)
+
+func describeStore(newStore func() Store) {
+	var store Store
+
+	BeforeEach(func() {
+		store = newStore()
+	})
+
+	AfterEach(func() {
+		for _, id := range []string{"instance-1", "instance-2"} {
+			store.Delete(id)
+		}
+	})
+
+	It("saves and gets a workflow", func() {
+		w := &Workflow{
+			InstanceID: "instance-1",
+			Kind:       "test",
+			Step:       "first",
+			State:      StateInProgress,
+			Data:       map[string]string{"key": "value"},
+		}
+		Expect(store.Save(w)).To(Succeed())
+		Expect(w.Version).To(Equal(int64(1)))
+
+		saved, err := store.Get("instance-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(saved.Kind).To(Equal("test"))
+		Expect(saved.Step).To(Equal("first"))
+		Expect(saved.Data).To(Equal(map[string]string{"key": "value"}))
+		Expect(saved.Version).To(Equal(int64(1)))
+		Expect(saved.LeaseExpiresAt.IsZero()).To(BeTrue())
+	})
+
+	It("keeps the lease of a workflow", func() {
+		leaseExpiresAt := time.Unix(1500000000, 0).UTC()
+		w := &Workflow{InstanceID: "instance-1", State: StateInProgress, Data: map[string]string{}, LeaseExpiresAt: leaseExpiresAt}
+		Expect(store.Save(w)).To(Succeed())
+
+		saved, err := store.Get("instance-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(saved.LeaseExpiresAt.Equal(leaseExpiresAt)).To(BeTrue())
+	})
+
+	It("returns ErrNotFound for an unknown workflow", func() {
+		_, err := store.Get("unknown-id")
+		Expect(err).To(Equal(ErrNotFound))
+
+		Expect(store.Delete("unknown-id")).To(Equal(ErrNotFound))
+	})
+
+	It("detects concurrent changes", func() {
+		Expect(store.Save(&Workflow{InstanceID: "instance-1", State: StateInProgress, Data: map[string]string{}})).To(Succeed())
+
+		first, err := store.Get("instance-1")
+		Expect(err).ToNot(HaveOccurred())
+		second, err := store.Get("instance-1")
+		Expect(err).ToNot(HaveOccurred())
+
+		first.Step = "first"
+		Expect(store.Save(first)).To(Succeed())
+
+		second.Step = "second"
+		Expect(store.Save(second)).To(Equal(ErrConflict))
+
+		Expect(store.Save(&Workflow{InstanceID: "instance-1", Data: map[string]string{}})).To(Equal(ErrConflict))
+	})
+
+	It("lists the workflows in progress", func() {
+		Expect(store.Save(&Workflow{InstanceID: "instance-1", State: StateInProgress, Data: map[string]string{}})).To(Succeed())
+		Expect(store.Save(&Workflow{InstanceID: "instance-2", State: StateFailed, Data: map[string]string{}})).To(Succeed())
+
+		workflows, err := store.ListInProgress()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(workflows).To(HaveLen(1))
+		Expect(workflows[0].InstanceID).To(Equal("instance-1"))
+	})
+}
+
+var _ = Describe("MemoryStore", func() {
+	describeStore(func() Store {
+		return NewMemoryStore()
+	})
+})
+
+var _ = Describe("FileStore", func() {
+	var directory string
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "workflows")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	describeStore(func() Store {
+		store, err := NewFileStore(directory)
+		Expect(err).ToNot(HaveOccurred())
+		return store
+	})
+
+	It("keeps the workflows across restarts", func() {
+		store, err := NewFileStore(directory)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.Save(&Workflow{InstanceID: "instance-1", Step: "first", State: StateInProgress, Data: map[string]string{}})).To(Succeed())
+
+		store, err = NewFileStore(directory)
+		Expect(err).ToNot(HaveOccurred())
+		w, err := store.Get("instance-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(w.Step).To(Equal("first"))
+	})
+})
+
+var _ = Describe("SQLStore", func() {
+	It("returns an error for an unsupported driver", func() {
+		_, err := NewSQLStore("sqlite", "", lager.NewLogger("store_test"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Unsupported workflow store driver 'sqlite'"))
+	})
+
+	// Set WORKFLOW_STORE_POSTGRES_URL to run these against a PostgreSQL database
+	if url := os.Getenv("WORKFLOW_STORE_POSTGRES_URL"); url != "" {
+		Context("with PostgreSQL", func() {
+			describeStore(func() Store {
+				store, err := NewSQLStore("postgres", url, lager.NewLogger("store_test"))
+				Expect(err).ToNot(HaveOccurred())
+				return store
+			})
+		})
+	}
+})
@@ -0,0 +1,93 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	StateInProgress = "in progress"
+	StateSucceeded  = "succeeded"
+	StateFailed     = "failed"
+)
+
+// Finished is returned as the next step by the last step of a workflow.
+const Finished = ""
+
+var (
+	ErrNotFound   = errors.New("workflow not found")
+	ErrConflict   = errors.New("workflow has been modified concurrently")
+	ErrInProgress = errors.New("another workflow is in progress for this instance")
+)
+
+// Workflow is a multi-step operation on a service instance. There is at most
+// one workflow per service instance.
+type Workflow struct {
+	InstanceID  string
+	Kind        string
+	Step        string
+	State       string
+	Description string
+	Data        map[string]string
+
+	// Failure is the reason the workflow failed, set while rolling back
+	Failure string
+
+	// Attempts counts the consecutive errors of the current step
+	Attempts  int
+	LastError string
+
+	// Version is incremented on every save, to detect concurrent changes
+	Version int64
+
+	// LeaseExpiresAt is set while a step runs, so that other brokers leave the
+	// workflow alone until the step is over or the broker running it is gone
+	LeaseExpiresAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// A Step runs one step of a workflow. It returns the next step to run, the
+// same step to run it again later while waiting for something, or Finished. The
+// description reports the progress and is kept as it is when empty.
+//
+// Errors returned with Failf fail the workflow straight away. Other errors are
+// considered temporary and the step is retried, up to a limit.
+type Step func(w *Workflow) (next string, description string, err error)
+
+// Definition describes a kind of workflow. When a workflow fails, it carries on
+// from the RollbackStep, if any, and it is marked as failed once the rollback
+// is done.
+type Definition struct {
+	Steps        map[string]Step
+	RollbackStep string
+}
+
+type failureError struct {
+	reason string
+}
+
+func (e failureError) Error() string {
+	return e.reason
+}
+
+// Failf returns an error failing the workflow without retrying the step.
+func Failf(format string, args ...interface{}) error {
+	return failureError{reason: fmt.Sprintf(format, args...)}
+}
+
+func isFailure(err error) bool {
+	_, ok := err.(failureError)
+	return ok
+}
+
+func (w *Workflow) copy() *Workflow {
+	c := *w
+	c.Data = make(map[string]string, len(w.Data))
+	for key, value := range w.Data {
+		c.Data[key] = value
+	}
+	return &c
+}